@@ -2,11 +2,23 @@ package main
 
 import (
 	"catalog-service/internal/api"
+	"catalog-service/internal/cache"
 	"catalog-service/internal/config"
+	"catalog-service/internal/events"
+	"catalog-service/internal/grpcapi"
+	"catalog-service/internal/health"
 	"catalog-service/internal/logger"
+	"catalog-service/internal/metrics"
+	"catalog-service/internal/migrate"
 	"catalog-service/internal/opensearch"
+	"catalog-service/internal/outbox"
 	"catalog-service/internal/repository"
+	"catalog-service/internal/server"
+	"catalog-service/internal/tracing"
+	"catalog-service/internal/usecase"
+	"catalog-service/internal/webhook"
 	"context"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
@@ -18,39 +30,161 @@ import (
 func main() {
 	config.Load()
 	logger.Setup(config.LogLevel(), config.LogFormat())
+	shutdownTracing, err := tracing.Setup(context.Background())
+	if err != nil {
+		fatal(err, "failed to set up tracing")
+	}
 
 	client, err := opensearch.NewClient(config.OpenSearch().Host())
 	if err != nil {
-		logger.NonContext.Error("failed to create opensearch client: %v", err)
+		fatal(err, "failed to create opensearch client")
 	}
 	repo, err := repository.NewServiceRepository(client)
 	if err != nil {
-		logger.NonContext.Error("failed to create service repository: %v", err)
+		fatal(err, "failed to create service repository")
+	}
+	if size := config.ServiceCacheSize(); size > 0 {
+		repo = repository.NewCachedServiceRepository(repo, cache.NewLRU(size), repository.CacheOptions{
+			FindByIDTTL: config.ServiceCacheFindByIDTTL(),
+			SearchTTL:   config.ServiceCacheSearchTTL(),
+		})
 	}
 
-	r := api.NewRouter(repo)
+	specRepo, err := repository.NewSpecRepository(client)
+	if err != nil {
+		fatal(err, "failed to create spec repository")
+	}
 
-	srv := &http.Server{
+	eventRepo, err := repository.NewEventRepository(client, config.EventLogSize())
+	if err != nil {
+		fatal(err, "failed to create event repository")
+	}
+	broker := events.NewBroker(eventRepo)
+
+	webhookRepo, err := repository.NewWebhookRepository(client)
+	if err != nil {
+		fatal(err, "failed to create webhook repository")
+	}
+	dispatcher := webhook.NewDispatcher(webhookRepo, webhook.Options{
+		Workers:        config.WebhookWorkers(),
+		MaxAttempts:    config.WebhookMaxAttempts(),
+		InitialBackoff: config.WebhookInitialBackoff(),
+		MaxBackoff:     config.WebhookMaxBackoff(),
+		Timeout:        config.WebhookTimeout(),
+	})
+
+	readiness := health.NewReadiness(health.Options{
+		CacheTTL: config.HealthCacheTTL(),
+		Timeout:  config.HealthCheckTimeout(),
+	},
+		health.ClusterHealth(client),
+		health.IndexExists(client, repository.ServiceIndexName),
+		health.MigrationVersion(migrate.New(client.Client).CurrentVersion, config.HealthMinMigrationVersion()),
+	)
+
+	r := api.NewRouter(api.Dependencies{
+		Services:   repo,
+		Specs:      specRepo,
+		Events:     broker,
+		Webhooks:   webhookRepo,
+		Deliveries: dispatcher,
+		Readiness:  readiness,
+	})
+
+	httpSrv := &http.Server{
 		Addr:    ":" + strconv.Itoa(config.Port()),
 		Handler: r,
 	}
+	httpSrv.RegisterOnShutdown(broker.Close)
+	grpcSrv := grpcapi.NewServer(usecase.NewServiceUsecase(repo, specRepo))
 
+	outboxRepo, err := repository.NewOutboxRepository(client)
+	if err != nil {
+		fatal(err, "failed to create outbox repository")
+	}
+	sink, err := newOutboxSink(broker)
+	if err != nil {
+		fatal(err, "failed to create outbox sink")
+	}
+	relay := outbox.NewRelay(outboxRepo, sink, outbox.Options{
+		PollInterval: config.OutboxPollInterval(),
+		BatchSize:    config.OutboxBatchSize(),
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := dispatcher.Start(ctx, broker); err != nil {
+		logger.NonContext.Errorf(err, "failed to start webhook dispatcher")
+	}
+	relayDone := make(chan struct{})
+	go func() {
+		defer close(relayDone)
+		relay.Run(ctx)
+	}()
+
+	err = server.Run(drainOnShutdown(ctx, readiness, config.ShutdownDrainDelay()), config.ShutdownTimeout(),
+		server.HTTP("http", httpSrv),
+		server.GRPC("grpc", ":"+strconv.Itoa(config.GRPCPort()), grpcSrv),
+		server.HTTP("metrics", &http.Server{
+			Addr:    ":" + strconv.Itoa(config.MetricsPort()),
+			Handler: metrics.Handler(),
+		}),
+	)
+	stop()
+	dispatcher.Wait()
+	<-relayDone
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout())
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.NonContext.Errorf(err, "failed to flush traces")
+	}
+	cancel()
+	if err != nil {
+		fatal(err, "server error")
+	}
+}
+
+func fatal(err error, msg string) {
+	logger.NonContext.Errorf(err, "%s", msg)
+	os.Exit(1)
+}
+
+// drainOnShutdown returns a context that is cancelled delay after ctx. In
+// between, /readyz reports draining, so load balancers stop routing new
+// requests before the servers stop accepting them.
+func drainOnShutdown(ctx context.Context, readiness *health.Readiness, delay time.Duration) context.Context {
+	serveCtx, stopServing := context.WithCancel(context.Background())
 	go func() {
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			logger.NonContext.Error("server error: %v", err)
+		defer stopServing()
+		<-ctx.Done()
+		readiness.Drain()
+		if delay > 0 {
+			logger.NonContext.Infof("draining for %s before shutting down", delay)
+			time.Sleep(delay)
 		}
 	}()
+	return serveCtx
+}
 
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-	logger.NonContext.Info("Shutting down server...")
-
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	if err := srv.Shutdown(ctx); err != nil {
-		logger.NonContext.Error("Server forced to shutdown: %v", err)
-	} else {
-		logger.NonContext.Info("Server exited gracefully")
+// newOutboxSink publishes outbox messages to the event stream and webhooks, and
+// to the sink selected by OUTBOX_SINK if any.
+func newOutboxSink(broker *events.Broker) (outbox.Sink, error) {
+	streams := outbox.NewBrokerSink(broker)
+	switch config.OutboxSink() {
+	case "":
+		return streams, nil
+	case "file":
+		file, err := outbox.NewFileSink(config.OutboxFilePath())
+		if err != nil {
+			return nil, err
+		}
+		return outbox.NewFanout(streams, file), nil
+	case "http":
+		if config.OutboxHTTPURL() == "" {
+			return nil, fmt.Errorf("OUTBOX_HTTP_URL is required for the http outbox sink")
+		}
+		return outbox.NewFanout(streams, outbox.NewHTTPSink(config.OutboxHTTPURL(), config.OutboxHTTPTimeout())), nil
+	default:
+		return nil, fmt.Errorf("unknown OUTBOX_SINK %q", config.OutboxSink())
 	}
 }
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"catalog-service/internal/config"
+	"catalog-service/internal/ingest"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	"catalog-service/internal/opensearch"
+	"catalog-service/internal/repository"
+)
+
+var (
+	outputFile = flag.String("output", "-", "Path of the file to write, or - for stdout")
+	format     = flag.String("format", string(ingest.FormatJSONL), "Output format: jsonl, json, csv or yaml")
+	query      = flag.String("q", "", "Only export services matching this search query")
+)
+
+func main() {
+	os.Exit(run())
+}
+
+func run() int {
+	flag.Parse()
+	config.Load()
+	logger.Setup(config.LogLevel(), config.LogFormat())
+	logger.NonContext.Info("starting services export")
+
+	if ingest.ContentType(ingest.Format(*format)) == "" {
+		logger.NonContext.Errorf(nil, "invalid format %q: must be jsonl, json, csv or yaml", *format)
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	client, err := opensearch.NewClient(config.OpenSearch().Host())
+	if err != nil {
+		logger.NonContext.Errorf(err, "failed to create opensearch client")
+		return 1
+	}
+	repo, err := repository.NewServiceRepository(client)
+	if err != nil {
+		logger.NonContext.Errorf(err, "failed to initialize service repository")
+		return 1
+	}
+
+	out, closeOut, err := openOutput(*outputFile)
+	if err != nil {
+		logger.NonContext.Errorf(err, "failed to open output: %s", *outputFile)
+		return 1
+	}
+
+	count, err := export(ctx, repo, out)
+	if cerr := closeOut(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		logger.NonContext.Errorf(err, "export failed after %d services", count)
+		return 1
+	}
+	logger.NonContext.Infof("exported %d services to %s", count, *outputFile)
+	return 0
+}
+
+func export(ctx context.Context, repo repository.ServiceRepository, w io.Writer) (int, error) {
+	enc, err := ingest.NewEncoder(ingest.Format(*format), w)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	err = repo.Export(ctx, *query, models.ServiceFilter{}, func(service *models.Service) error {
+		count++
+		return enc.Encode(service)
+	})
+	if err != nil {
+		return count, err
+	}
+	return count, enc.Close()
+}
+
+func openOutput(path string) (io.Writer, func() error, error) {
+	var f *os.File
+	if path == "-" {
+		f = os.Stdout
+	} else {
+		var err error
+		if f, err = os.Create(path); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	w := bufio.NewWriter(f)
+	return w, func() error {
+		if err := w.Flush(); err != nil {
+			return fmt.Errorf("failed to flush output: %w", err)
+		}
+		if f == os.Stdout {
+			return nil
+		}
+		return f.Close()
+	}, nil
+}
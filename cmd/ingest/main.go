@@ -1,99 +1,321 @@
 package main
 
 import (
-	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
+	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"catalog-service/internal/config"
+	"catalog-service/internal/ingest"
 	"catalog-service/internal/logger"
+	"catalog-service/internal/metrics"
 	"catalog-service/internal/models"
 	"catalog-service/internal/opensearch"
 	"catalog-service/internal/repository"
+	"catalog-service/internal/usecase"
+)
+
+const (
+	modeUpsert = "upsert"
+	modeCreate = "create"
 )
 
 var (
-	dataFile = flag.String("data-file", "data.jsonl", "Path to the JSONL data file")
+	dataFile       = flag.String("data-file", "data.jsonl", "Path to a data file, a directory of data files, or - for stdin")
+	format         = flag.String("format", string(ingest.FormatJSONL), "Input format: jsonl, json, csv, yaml, backstage or openapi")
+	mappingSpec    = flag.String("mapping", "", "Comma separated field mapping, e.g. name=Service Name,description=Summary")
+	mappingFile    = flag.String("mapping-file", "", "YAML file mapping service fields to input fields")
+	batchSize      = flag.Int("batch-size", opensearch.DefaultBulkBatchSize, "Maximum number of documents per bulk request")
+	batchBytes     = flag.Int("batch-bytes", opensearch.DefaultBulkBatchBytes, "Maximum size in bytes of a bulk request body")
+	workers        = flag.Int("workers", opensearch.DefaultBulkWorkers, "Number of concurrent bulk requests")
+	mode           = flag.String("mode", modeUpsert, "Ingest mode: upsert (idempotent, keyed on --key-field) or create")
+	keyField       = flag.String("key-field", repository.DefaultNaturalKeyField, "Natural key field used to match existing services in upsert mode")
+	deadLetterFile = flag.String("dead-letter", "", "Append lines that fail to parse or index to this JSONL file")
+	checkpointFile = flag.String("checkpoint", "", "Resume from and record the last committed offset in this file")
+	note           = flag.String("note", "", "Changelog recorded on the version imported from each OpenAPI document")
+	timeout        = flag.Duration("timeout", 5*time.Minute, "Maximum duration of the run, 0 for no limit")
+	metricsAddr    = flag.String("metrics-addr", "", "Serve Prometheus metrics on this address during the run, e.g. :9091")
 )
 
 func main() {
+	os.Exit(run())
+}
+
+func run() int {
 	flag.Parse()
 	config.Load()
 	logger.Setup(config.LogLevel(), config.LogFormat())
 	logger.NonContext.Info("starting db ingestion")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-	defer cancel()
+	if *mode != modeUpsert && *mode != modeCreate {
+		logger.NonContext.Errorf(nil, "invalid mode %q: must be %s or %s", *mode, modeUpsert, modeCreate)
+		return 2
+	}
+	if *mode == modeUpsert {
+		if err := repository.ValidateNaturalKeyField(*keyField); err != nil {
+			logger.NonContext.Errorf(err, "invalid --key-field")
+			return 2
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
+	decoder, err := newDecoder()
+	if err != nil {
+		logger.NonContext.Errorf(err, "invalid input options")
+		return 2
+	}
+	sources, err := ingest.Sources(*dataFile, ingest.Format(*format))
+	if err != nil {
+		logger.NonContext.Errorf(err, "failed to resolve input: %s", *dataFile)
+		return 1
+	}
+	if *dataFile == ingest.StdinSource && *checkpointFile != "" {
+		logger.NonContext.Errorf(nil, "--checkpoint cannot be used when reading from stdin")
+		return 2
+	}
+
+	if *metricsAddr != "" {
+		metricsSrv := &http.Server{Addr: *metricsAddr, Handler: metrics.Handler()}
+		go func() {
+			if err := metricsSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.NonContext.Errorf(err, "metrics server failed")
+			}
+		}()
+		defer metricsSrv.Close()
+	}
 
-	serviceRepo, err := loadServiceRepo()
+	started := time.Now()
+	serviceRepo, specRepo, err := loadRepos()
 	if err != nil {
 		logger.NonContext.Errorf(err, "failed to initialize service repository")
-		return
+		return 1
 	}
 
-	count, err := processFile(ctx, *dataFile, serviceRepo)
+	prog, err := newProgress(*dataFile, *checkpointFile, *deadLetterFile)
 	if err != nil {
-		logger.NonContext.Errorf(err, "failed to process file: %s", *dataFile)
-		return
+		logger.NonContext.Errorf(err, "failed to initialize ingest progress")
+		return 1
 	}
 
-	logger.NonContext.Infof("data ingestion completed. successfully indexed %d documents.", count)
+	opts := opensearch.BulkOptions{
+		BatchSize:  *batchSize,
+		BatchBytes: *batchBytes,
+		Workers:    *workers,
+		Refresh:    true,
+		OnItem: func(item opensearch.BulkItemResult) {
+			prog.onItem(item.Refs, item.Err)
+		},
+	}
+
+	var result *opensearch.BulkResult
+	var sum summary
+	if ingest.Format(*format) == ingest.FormatOpenAPI {
+		result, err = importSpecs(ctx, sources, decoder, usecase.NewSpecUsecase(serviceRepo, specRepo), prog)
+		sum = prog.close(err == nil)
+		sum.Mode = modeUpsert
+	} else {
+		result, err = processInput(ctx, sources, decoder, serviceRepo, opts, prog)
+		sum = prog.close(err == nil)
+		sum.Mode = *mode
+	}
+	sum.Format = *format
+	sum.Sources = len(sources)
+	sum.DurationMs = time.Since(started).Milliseconds()
+	if result != nil {
+		sum.Created, sum.Updated, sum.Unchanged = result.Created, result.Updated, result.Unchanged
+	}
+	if err != nil {
+		logger.NonContext.Errorf(err, "failed to process input: %s", *dataFile)
+		sum.Error = err.Error()
+	}
+
+	out, _ := json.Marshal(sum)
+	fmt.Println(string(out))
+	logger.NonContext.Infof("data ingestion finished. ok %d, failed %d, skipped %d of %d records.", sum.OK, sum.Failed, sum.Skipped, sum.Total)
+
+	if err != nil || sum.Failed > 0 {
+		return 1
+	}
+	return 0
 }
 
-func loadServiceRepo() (repository.ServiceRepository, error) {
-	client, err := opensearch.NewClient(config.OpenSearch().Host())
+func newDecoder() (ingest.Decoder, error) {
+	mapping := ingest.Mapping{}
+	if *mappingFile != "" {
+		m, err := ingest.LoadMapping(*mappingFile)
+		if err != nil {
+			return nil, err
+		}
+		mapping = m
+	}
+	override, err := ingest.ParseMapping(*mappingSpec)
 	if err != nil {
 		return nil, err
 	}
-	return repository.NewServiceRepository(client)
+	return ingest.NewDecoder(ingest.Format(*format), mapping.Merge(override))
 }
 
-func processFile(ctx context.Context, filePath string, repo repository.ServiceRepository) (int, error) {
-	file, err := os.Open(filePath)
+func loadRepos() (repository.ServiceRepository, repository.SpecRepository, error) {
+	client, err := opensearch.NewClient(config.OpenSearch().Host())
+	if err != nil {
+		return nil, nil, err
+	}
+	serviceRepo, err := repository.NewServiceRepository(client)
+	if err != nil {
+		return nil, nil, err
+	}
+	specRepo, err := repository.NewSpecRepository(client)
 	if err != nil {
-		return 0, err
+		return nil, nil, err
 	}
-	defer file.Close()
+	return serviceRepo, specRepo, nil
+}
 
-	scanner := bufio.NewScanner(file)
-	var count int
-	for scanner.Scan() {
-		if err := processLine(ctx, scanner.Bytes(), count+1, repo); err != nil {
-			logger.NonContext.Errorf(err, "Failed to process line %d: %v", count+1, err)
-			continue
-		}
-		count++
+func processInput(ctx context.Context, sources []string, decoder ingest.Decoder, repo repository.ServiceRepository, opts opensearch.BulkOptions, prog *progress) (*opensearch.BulkResult, error) {
+	type bulkOutcome struct {
+		result *opensearch.BulkResult
+		err    error
 	}
+	// The bulk writer can return before reading every service, e.g. when a
+	// lookup fails; cancelling ctx then stops the decoder instead of leaving it
+	// blocked on services.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	services := make(chan *models.Service, opts.BatchSize)
+	done := make(chan bulkOutcome, 1)
+	go func() {
+		var result *opensearch.BulkResult
+		var err error
+		if *mode == modeUpsert {
+			result, err = repo.BulkUpsert(ctx, services, *keyField, opts)
+		} else {
+			result, err = repo.BulkCreate(ctx, services, opts)
+		}
+		cancel()
+		done <- bulkOutcome{result: result, err: err}
+	}()
 
-	if err := scanner.Err(); err != nil {
-		return count, err
+	decodeErr := decodeSources(ctx, sources, decoder, prog, func(rec ingest.Record, r *record) error {
+		if err := prepareService(rec.Service); err != nil {
+			prog.finish(r, outcomeFailed, err)
+			return nil
+		}
+
+		prog.bind(rec.Service, r)
+		select {
+		case services <- rec.Service:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	close(services)
+	outcome := <-done
+
+	if outcome.err != nil {
+		return outcome.result, outcome.err
 	}
-	return count, nil
+	return outcome.result, decodeErr
+}
+
+func importSpecs(ctx context.Context, sources []string, decoder ingest.Decoder, importer usecase.SpecUsecase, prog *progress) (*opensearch.BulkResult, error) {
+	result := &opensearch.BulkResult{}
+	err := decodeSources(ctx, sources, decoder, prog, func(rec ingest.Record, r *record) error {
+		_, created, err := importer.ImportOpenAPI(ctx, rec.Raw, *note)
+		if err != nil {
+			prog.finish(r, outcomeFailed, err)
+			return nil
+		}
+		result.Indexed++
+		if created {
+			result.Created++
+		} else {
+			result.Updated++
+		}
+		prog.finish(r, outcomeOK, nil)
+		return ctx.Err()
+	})
+	return result, err
 }
 
-func processLine(ctx context.Context, line []byte, lineNum int, repo repository.ServiceRepository) error {
-	if len(line) == 0 {
-		return nil
+func decodeSources(ctx context.Context, sources []string, decoder ingest.Decoder, prog *progress, handle func(ingest.Record, *record) error) error {
+	resumeSource, resumeAt := prog.resumePoint()
+	start := 0
+	if resumeSource != "" {
+		start = -1
+		for i, source := range sources {
+			if source == resumeSource {
+				start = i
+				break
+			}
+		}
+		if start < 0 {
+			return fmt.Errorf("checkpoint source %s is no longer part of the input", resumeSource)
+		}
+		logger.NonContext.Infof("resuming %s after record %d", resumeSource, resumeAt.Index)
+	}
+
+	emit := func(rec ingest.Record) error {
+		r := prog.begin(rec)
+		if rec.Err != nil {
+			prog.finish(r, outcomeFailed, rec.Err)
+			return nil
+		}
+		if rec.Skipped() {
+			prog.finish(r, outcomeSkipped, nil)
+			return nil
+		}
+		return handle(rec, r)
 	}
 
-	var service models.Service
-	if err := json.Unmarshal(line, &service); err != nil {
+	for i := start; i < len(sources); i++ {
+		from := ingest.Position{}
+		if i == start {
+			from = resumeAt
+		}
+		if err := decodeSource(ctx, sources[i], decoder, from, emit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeSource(ctx context.Context, source string, decoder ingest.Decoder, from ingest.Position, emit ingest.EmitFunc) error {
+	r, err := ingest.Open(source)
+	if err != nil {
 		return err
 	}
+	defer r.Close()
+
+	if err := decoder.Decode(ctx, r, source, from, emit); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", source, err)
+	}
+	return nil
+}
+
+func prepareService(service *models.Service) error {
+	if *mode == modeUpsert && repository.NaturalKey(service, *keyField) == "" {
+		return fmt.Errorf("missing natural key field %q", *keyField)
+	}
 
 	now := time.Now().UTC()
 	if service.CreatedAt.IsZero() {
 		service.CreatedAt = now
 	}
 	service.UpdatedAt = now
-
-	if err := repo.Create(ctx, &service); err != nil {
-		return err
-	}
-
-	logger.NonContext.Infof("Successfully indexed service from line %d", lineNum)
 	return nil
 }
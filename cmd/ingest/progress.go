@@ -0,0 +1,262 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+
+	"catalog-service/internal/ingest"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/metrics"
+	"catalog-service/internal/models"
+)
+
+const checkpointInterval = time.Second
+
+type outcome int
+
+const (
+	outcomeOK outcome = iota
+	outcomeFailed
+	outcomeSkipped
+)
+
+type record struct {
+	seq    int
+	source string
+	index  int
+	offset int64
+	raw    []byte
+	done   bool
+}
+
+type checkpoint struct {
+	Input  string `json:"input"`
+	Source string `json:"source"`
+	Index  int    `json:"index"`
+	Offset int64  `json:"offset"`
+}
+
+type deadLetter struct {
+	File  string `json:"file"`
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+	Data  string `json:"data"`
+}
+
+type summary struct {
+	Input             string `json:"input"`
+	Format            string `json:"format"`
+	Mode              string `json:"mode"`
+	Sources           int    `json:"sources"`
+	ResumedFromSource string `json:"resumed_from_source,omitempty"`
+	ResumedFromLine   int    `json:"resumed_from_line"`
+	Total             int    `json:"total"`
+	OK                int    `json:"ok"`
+	Failed            int    `json:"failed"`
+	Skipped           int    `json:"skipped"`
+	Created           int    `json:"created"`
+	Updated           int    `json:"updated"`
+	Unchanged         int    `json:"unchanged"`
+	DurationMs        int64  `json:"duration_ms"`
+	Completed         bool   `json:"completed"`
+	Error             string `json:"error,omitempty"`
+}
+
+type progress struct {
+	mu             sync.Mutex
+	checkpointPath string
+	deadLetter     *os.File
+	records        map[int]*record
+	pending        map[*models.Service]*record
+	seq            int
+	next           int
+	committed      checkpoint
+	lastSaved      time.Time
+	summary        summary
+}
+
+func newProgress(input, checkpointPath, deadLetterPath string) (*progress, error) {
+	p := &progress{
+		checkpointPath: checkpointPath,
+		records:        map[int]*record{},
+		pending:        map[*models.Service]*record{},
+		next:           1,
+		committed:      checkpoint{Input: input},
+		summary:        summary{Input: input},
+	}
+
+	if checkpointPath != "" {
+		cp, err := loadCheckpoint(checkpointPath)
+		if err != nil {
+			return nil, err
+		}
+		if cp != nil && cp.Input == input {
+			p.committed = *cp
+			p.summary.ResumedFromSource = cp.Source
+			p.summary.ResumedFromLine = cp.Index
+		} else if cp != nil {
+			logger.NonContext.Warnf("ignoring checkpoint %s recorded for a different input: %s", checkpointPath, cp.Input)
+		}
+	}
+
+	if deadLetterPath != "" {
+		f, err := os.OpenFile(deadLetterPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open dead letter file: %w", err)
+		}
+		p.deadLetter = f
+	}
+	return p, nil
+}
+
+func loadCheckpoint(path string) (*checkpoint, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	var cp checkpoint
+	if err := json.Unmarshal(b, &cp); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint file %s: %w", path, err)
+	}
+	return &cp, nil
+}
+
+func (p *progress) resumePoint() (string, ingest.Position) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.committed.Source, ingest.Position{Index: p.committed.Index, Offset: p.committed.Offset}
+}
+
+func (p *progress) begin(rec ingest.Record) *record {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.seq++
+	r := &record{seq: p.seq, source: rec.Source, index: rec.Index, offset: rec.Offset, raw: rec.Raw}
+	p.records[r.seq] = r
+	p.summary.Total++
+	return r
+}
+
+func (p *progress) bind(service *models.Service, rec *record) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pending[service] = rec
+}
+
+func (p *progress) finish(rec *record, result outcome, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.finishLocked(rec, result, err)
+}
+
+func (p *progress) onItem(refs []interface{}, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, ref := range refs {
+		service, ok := ref.(*models.Service)
+		if !ok {
+			continue
+		}
+		rec, ok := p.pending[service]
+		if !ok {
+			continue
+		}
+		delete(p.pending, service)
+		if err != nil {
+			p.finishLocked(rec, outcomeFailed, err)
+		} else {
+			p.finishLocked(rec, outcomeOK, nil)
+		}
+	}
+}
+
+func (p *progress) finishLocked(rec *record, result outcome, err error) {
+	rec.done = true
+	switch result {
+	case outcomeOK:
+		p.summary.OK++
+		metrics.IngestRecord("ok")
+	case outcomeSkipped:
+		p.summary.Skipped++
+		metrics.IngestRecord("skipped")
+	case outcomeFailed:
+		p.summary.Failed++
+		metrics.IngestRecord("failed")
+		logger.NonContext.Errorf(err, "Failed to process record %d of %s: %v", rec.index, rec.source, err)
+		p.writeDeadLetter(rec, err)
+	}
+	rec.raw = nil
+
+	for {
+		next, ok := p.records[p.next]
+		if !ok || !next.done {
+			break
+		}
+		p.committed.Source = next.source
+		p.committed.Index = next.index
+		p.committed.Offset = next.offset
+		delete(p.records, p.next)
+		p.next++
+	}
+
+	if time.Since(p.lastSaved) >= checkpointInterval {
+		p.saveCheckpointLocked()
+	}
+}
+
+func (p *progress) writeDeadLetter(rec *record, err error) {
+	if p.deadLetter == nil {
+		return
+	}
+	entry, _ := json.Marshal(deadLetter{File: rec.source, Line: rec.index, Error: err.Error(), Data: string(rec.raw)})
+	if _, werr := p.deadLetter.Write(append(entry, '\n')); werr != nil {
+		logger.NonContext.Errorf(werr, "failed to write dead letter for record %d of %s", rec.index, rec.source)
+	}
+}
+
+func (p *progress) saveCheckpointLocked() {
+	p.lastSaved = time.Now()
+	if p.checkpointPath == "" {
+		return
+	}
+	b, _ := json.Marshal(p.committed)
+	tmp := p.checkpointPath + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		logger.NonContext.Errorf(err, "failed to write checkpoint")
+		return
+	}
+	if err := os.Rename(tmp, p.checkpointPath); err != nil {
+		logger.NonContext.Errorf(err, "failed to write checkpoint")
+	}
+}
+
+func (p *progress) close(completed bool) summary {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if completed && len(p.records) == 0 {
+		if p.checkpointPath != "" {
+			if err := os.Remove(p.checkpointPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				logger.NonContext.Errorf(err, "failed to remove checkpoint")
+			}
+		}
+	} else {
+		p.saveCheckpointLocked()
+	}
+
+	if p.deadLetter != nil {
+		if err := p.deadLetter.Close(); err != nil {
+			logger.NonContext.Errorf(err, "failed to close dead letter file")
+		}
+	}
+	p.summary.Completed = completed
+	return p.summary
+}
@@ -4,21 +4,39 @@ import (
 	"catalog-service/internal/config"
 	"catalog-service/internal/logger"
 	"catalog-service/internal/migrate"
+	"encoding/json"
 	"flag"
+	"fmt"
 	"log"
+	"os"
+	"strings"
 
 	"github.com/opensearch-project/opensearch-go/v2"
 )
 
-var (
-	schemaDir = flag.String("schema-dir", "migrations", "Directory containing schema files")
-)
+const usage = `Usage:
+  migrate [up] [--schema-dir <dir>]          apply schema files
+  migrate diff [--schema-dir <dir>] [--json] show changes against the live cluster
+  migrate rollback --to <version> [--allow-data-loss]
+                                             revert operations recorded after <version>;
+                                             deleting indices requires --allow-data-loss`
 
 func main() {
-	flag.Parse()
+	command, args := "up", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
+
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	flags.Usage = func() { fmt.Fprintln(os.Stderr, usage) }
+	schemaDir := flags.String("schema-dir", "migrations", "Directory containing schema files")
+	asJSON := flags.Bool("json", false, "Print the diff as JSON")
+	to := flags.Int("to", -1, "Migration version to roll back to")
+	allowDataLoss := flags.Bool("allow-data-loss", false, "Allow a rollback to delete indices and their documents")
+	_ = flags.Parse(args)
+
 	config.Load()
 	logger.Setup(config.LogLevel(), config.LogFormat())
-	logger.NonContext.Info("starting db migrations")
 
 	config := opensearch.Config{
 		Addresses: config.OpenSearch().Host(),
@@ -32,11 +50,107 @@ func main() {
 
 	migrator := migrate.New(client)
 
-	log.Printf("Starting migrations from directory: %s", *schemaDir)
-	if err := migrator.Run(*schemaDir); err != nil {
-		logger.NonContext.Errorf(err, "failed to run migrations")
-		panic("failed to run migrations")
+	switch command {
+	case "up":
+		logger.NonContext.Info("starting db migrations")
+		log.Printf("Starting migrations from directory: %s", *schemaDir)
+		if err := migrator.Run(*schemaDir); err != nil {
+			logger.NonContext.Errorf(err, "failed to run migrations")
+			panic("failed to run migrations")
+		}
+		log.Println("migrations completed successfully")
+	case "diff":
+		diffs, err := migrator.Diff(*schemaDir)
+		if err != nil {
+			logger.NonContext.Errorf(err, "failed to diff migrations")
+			os.Exit(1)
+		}
+		printDiffs(diffs, *asJSON)
+	case "rollback":
+		if *to < 0 {
+			fmt.Fprintln(os.Stderr, usage)
+			os.Exit(2)
+		}
+		ops, err := migrator.PlanRollback(*to)
+		if err != nil {
+			logger.NonContext.Errorf(err, "failed to plan rollback")
+			os.Exit(1)
+		}
+		if dropped := migrate.DroppedIndices(ops); len(dropped) > 0 {
+			fmt.Printf("rolling back to version %d deletes these indices and all their documents:\n", *to)
+			for _, index := range dropped {
+				fmt.Printf("  %s\n", index)
+			}
+			if !*allowDataLoss {
+				fmt.Fprintln(os.Stderr, "refusing to delete indices, rerun with --allow-data-loss")
+				os.Exit(2)
+			}
+		}
+		if err := migrator.Rollback(*to, *allowDataLoss); err != nil {
+			logger.NonContext.Errorf(err, "failed to roll back migrations")
+			os.Exit(1)
+		}
+		log.Printf("rolled back to migration version %d", *to)
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func printDiffs(diffs []migrate.IndexDiff, asJSON bool) {
+	if asJSON {
+		out, _ := json.MarshalIndent(diffs, "", "  ")
+		fmt.Println(string(out))
+		return
+	}
+
+	for _, d := range diffs {
+		switch {
+		case !d.Exists:
+			fmt.Printf("index %s: does not exist, will be created\n", d.Index)
+			continue
+		case len(d.Changes) == 0:
+			fmt.Printf("index %s: up to date\n", d.Index)
+			continue
+		case d.RequiresReindex():
+			fmt.Printf("index %s: %d change(s), REINDEX REQUIRED\n", d.Index, len(d.Changes))
+		default:
+			fmt.Printf("index %s: %d change(s)\n", d.Index, len(d.Changes))
+		}
+
+		for _, c := range d.Changes {
+			line := fmt.Sprintf("  %s %s", changeSymbol(c.Kind), c.Path)
+			switch c.Kind {
+			case migrate.ChangeAdded:
+				line += ": " + compactJSON(c.Desired)
+			case migrate.ChangeUnmanaged:
+				line += ": " + compactJSON(c.Live) + " (" + c.Reason + ")"
+			default:
+				line += ": " + compactJSON(c.Live) + " -> " + compactJSON(c.Desired)
+			}
+			if c.Incompatible {
+				line += " [incompatible: " + c.Reason + "]"
+			}
+			fmt.Println(line)
+		}
+	}
+}
+
+func changeSymbol(kind migrate.ChangeKind) string {
+	switch kind {
+	case migrate.ChangeAdded:
+		return "+"
+	case migrate.ChangeUnmanaged:
+		return "?"
+	default:
+		return "~"
 	}
+}
 
-	log.Println("migrations completed successfully")
+func compactJSON(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
 }
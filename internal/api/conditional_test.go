@@ -0,0 +1,107 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"catalog-service/internal/config"
+	"catalog-service/internal/events"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	"catalog-service/internal/repository"
+	mockrepo "catalog-service/test/mocks/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConditionalTestSuite struct {
+	suite.Suite
+	repo      *mockrepo.ServiceRepository
+	router    *gin.Engine
+	updatedAt time.Time
+}
+
+func TestConditionalSuite(t *testing.T) {
+	suite.Run(t, new(ConditionalTestSuite))
+}
+
+func (s *ConditionalTestSuite) SetupTest() {
+	s.T().Setenv("HTTP_CACHE_CONTROL_SEARCH", "public, max-age=30")
+	config.Load()
+	logger.Setup("INFO", "json")
+	s.updatedAt = time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC)
+	older := &models.Service{ID: "svc-2", Name: "Payments", UpdatedAt: s.updatedAt.Add(-time.Hour)}
+	s.repo = new(mockrepo.ServiceRepository)
+	s.repo.On("FindByID", mock.Anything, "svc-1").Return(&models.Service{ID: "svc-1", Name: "Billing", UpdatedAt: s.updatedAt}, nil)
+	s.repo.On("Search", mock.Anything, "", 1, 10).Return([]*models.Service{older, {ID: "svc-1", Name: "Billing", UpdatedAt: s.updatedAt}}, 2, nil)
+	s.router = NewRouter(Dependencies{Services: s.repo, Events: events.NewBroker(nil)})
+}
+
+func (s *ConditionalTestSuite) get(path string, headers ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *ConditionalTestSuite) Test_GetByID_SendsValidatorsAndCacheControl() {
+	w := s.get("/api/services/svc-1")
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Regexp(`^"[0-9a-f]{32}"$`, w.Header().Get("ETag"))
+	s.Equal("Sun, 01 Mar 2026 12:30:00 GMT", w.Header().Get("Last-Modified"))
+	s.Equal("public, max-age=0, must-revalidate", w.Header().Get("Cache-Control"))
+	s.Equal("application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	s.Contains(w.Body.String(), `"name":"Billing"`)
+	s.Equal(w.Header().Get("ETag"), s.get("/api/services/svc-1").Header().Get("ETag"), "the ETag is stable")
+}
+
+func (s *ConditionalTestSuite) Test_GetByID_AnswersNotModifiedForMatchingETag() {
+	etag := s.get("/api/services/svc-1").Header().Get("ETag")
+
+	for _, match := range []string{etag, "W/" + etag, `"other", ` + etag, "*"} {
+		w := s.get("/api/services/svc-1", "If-None-Match", match)
+		s.Equal(http.StatusNotModified, w.Code, match)
+		s.Empty(w.Body.String())
+		s.Equal(etag, w.Header().Get("ETag"))
+	}
+
+	s.Equal(http.StatusOK, s.get("/api/services/svc-1", "If-None-Match", `"other"`).Code)
+}
+
+func (s *ConditionalTestSuite) Test_GetByID_AnswersNotModifiedSinceUpdatedAt() {
+	s.Equal(http.StatusNotModified, s.get("/api/services/svc-1", "If-Modified-Since", "Sun, 01 Mar 2026 12:30:00 GMT").Code)
+	s.Equal(http.StatusOK, s.get("/api/services/svc-1", "If-Modified-Since", "Sun, 01 Mar 2026 12:29:59 GMT").Code)
+	s.Equal(http.StatusOK, s.get("/api/services/svc-1", "If-Modified-Since", "yesterday").Code)
+	s.Equal(http.StatusOK, s.get("/api/services/svc-1",
+		"If-None-Match", `"other"`,
+		"If-Modified-Since", "Sun, 01 Mar 2026 12:30:00 GMT",
+	).Code, "If-None-Match takes precedence")
+}
+
+func (s *ConditionalTestSuite) Test_Search_RevalidatesWithETagOnly() {
+	w := s.get("/api/services")
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("Sun, 01 Mar 2026 12:30:00 GMT", w.Header().Get("Last-Modified"), "newest updated_at of the page")
+	s.Equal("public, max-age=30", w.Header().Get("Cache-Control"))
+
+	s.Equal(http.StatusNotModified, s.get("/api/services", "If-None-Match", w.Header().Get("ETag")).Code)
+	s.Equal(http.StatusOK, s.get("/api/services", "If-Modified-Since", "Sun, 01 Mar 2026 12:30:00 GMT").Code)
+}
+
+func (s *ConditionalTestSuite) Test_ErrorsAreNotCacheable() {
+	s.repo.On("FindByID", mock.Anything, "missing").Return(nil, repository.ErrNotFound)
+
+	w := s.get("/api/services/missing", "If-None-Match", "*")
+
+	s.Equal(http.StatusNotFound, w.Code)
+	s.Empty(w.Header().Get("ETag"))
+	s.Empty(w.Header().Get("Cache-Control"))
+}
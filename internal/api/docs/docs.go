@@ -0,0 +1,9 @@
+package docs
+
+import _ "embed"
+
+//go:embed openapi.json
+var OpenAPI []byte
+
+//go:embed index.html
+var UI []byte
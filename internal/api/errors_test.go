@@ -0,0 +1,91 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"catalog-service/internal/api/problem"
+	"catalog-service/internal/config"
+	"catalog-service/internal/constants"
+	"catalog-service/internal/dto"
+	"catalog-service/internal/events"
+	"catalog-service/internal/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type ErrorsTestSuite struct {
+	suite.Suite
+	router *gin.Engine
+}
+
+func TestErrorsSuite(t *testing.T) {
+	suite.Run(t, new(ErrorsTestSuite))
+}
+
+func (s *ErrorsTestSuite) SetupTest() {
+	config.Load()
+	logger.Setup("INFO", "json")
+	s.router = NewRouter(Dependencies{Events: events.NewBroker(nil)})
+}
+
+func (s *ErrorsTestSuite) do(method, path, accept string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Accept", accept)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *ErrorsTestSuite) errorCode(w *httptest.ResponseRecorder) string {
+	var body dto.ErrorResponse
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &body))
+	s.Require().Len(body.Errors, 1)
+	return body.Errors[0].Code
+}
+
+func (s *ErrorsTestSuite) Test_UnknownRoutesAnswerJSON() {
+	w := s.do(http.MethodGet, "/api/nothing", "")
+
+	s.Equal(http.StatusNotFound, w.Code)
+	s.Equal(constants.Error_ROUTE_NOT_FOUND, s.errorCode(w))
+}
+
+func (s *ErrorsTestSuite) Test_UnknownMethodsAnswerJSONWithAllow() {
+	w := s.do(http.MethodPatch, "/api/webhooks", "")
+
+	s.Equal(http.StatusMethodNotAllowed, w.Code)
+	s.Equal("GET, POST", w.Header().Get("Allow"))
+	s.Equal(constants.Error_METHOD_NOT_ALLOWED, s.errorCode(w))
+}
+
+func (s *ErrorsTestSuite) Test_NegotiatesProblemDetails() {
+	w := s.do(http.MethodPatch, "/api/webhooks", problem.ContentType)
+
+	s.Equal(problem.ContentType, w.Header().Get("Content-Type"))
+	var body dto.ProblemDetails
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &body))
+	s.Equal(http.StatusMethodNotAllowed, body.Status)
+	s.Equal("/api/errors/115", body.Type)
+	s.Equal("/api/webhooks", body.Instance)
+
+	w = s.do(http.MethodGet, body.Type, "")
+	s.Equal(http.StatusOK, w.Code, "problem types are documented")
+	var def dto.ErrorDefinitionResponse
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &def))
+	s.Equal(&dto.ErrorDefinitionDTO{Code: "115", Status: http.StatusMethodNotAllowed, Title: "Method not allowed", Type: body.Type}, def.Data)
+}
+
+func (s *ErrorsTestSuite) Test_ListsCatalog() {
+	w := s.do(http.MethodGet, "/api/errors", "")
+
+	s.Equal(http.StatusOK, w.Code)
+	var body dto.ErrorCatalogResponse
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &body))
+	s.Len(body.Data, len(constants.ErrorCatalog))
+
+	s.Equal(http.StatusNotFound, s.do(http.MethodGet, "/api/errors/000", "").Code)
+}
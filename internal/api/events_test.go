@@ -0,0 +1,133 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"catalog-service/internal/config"
+	"catalog-service/internal/events"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	"catalog-service/internal/outbox"
+	mockrepo "catalog-service/test/mocks/repository"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type EventStreamTestSuite struct {
+	suite.Suite
+	repo   *mockrepo.ServiceRepository
+	store  *mockrepo.EventRepository
+	broker *events.Broker
+	server *httptest.Server
+}
+
+func TestEventStreamSuite(t *testing.T) {
+	suite.Run(t, new(EventStreamTestSuite))
+}
+
+func (s *EventStreamTestSuite) SetupTest() {
+	config.Load()
+	logger.Setup("INFO", "json")
+	s.repo = new(mockrepo.ServiceRepository)
+	s.store = new(mockrepo.EventRepository)
+	s.store.On("Append", mock.Anything, mock.Anything).Return(nil)
+	s.broker = events.NewBroker(s.store)
+	s.server = httptest.NewServer(NewRouter(Dependencies{Services: s.repo, Events: s.broker}))
+}
+
+func (s *EventStreamTestSuite) TearDownTest() {
+	s.server.CloseClientConnections()
+	s.server.Close()
+}
+
+func (s *EventStreamTestSuite) open(lastEventID string) (*http.Response, *bufio.Reader) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	s.T().Cleanup(cancel)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, s.server.URL+"/api/services/events", nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	res, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { res.Body.Close() })
+	return res, bufio.NewReader(res.Body)
+}
+
+// readEvent returns the lines of the next event, skipping heartbeats.
+func (s *EventStreamTestSuite) readEvent(r *bufio.Reader) []string {
+	var lines []string
+	for {
+		line, err := r.ReadString('\n')
+		s.Require().NoError(err)
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			if len(lines) > 0 {
+				return lines
+			}
+			continue
+		}
+		if !strings.HasPrefix(line, ":") {
+			lines = append(lines, line)
+		}
+	}
+}
+
+func (s *EventStreamTestSuite) Test_StreamsWritesAsTheyHappen() {
+	s.store.On("LastID", mock.Anything).Return(int64(0), nil)
+	// The outbox relay publishes the events stored with the write.
+	sink := outbox.NewBrokerSink(s.broker)
+	s.repo.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.Service).ID = "svc-1"
+		for i, arg := range args[2:] {
+			event := *arg.(*models.ServiceEvent)
+			event.ServiceID = "svc-1"
+			s.Require().NoError(sink.Publish(context.Background(), &models.OutboxMessage{ID: fmt.Sprint("msg-", i), Event: event}))
+		}
+	}).Return(nil)
+
+	res, body := s.open("")
+	s.Equal(http.StatusOK, res.StatusCode)
+	s.Equal("text/event-stream", res.Header.Get("Content-Type"))
+
+	create, err := http.Post(s.server.URL+"/api/services", "application/json",
+		strings.NewReader(`{"name":"billing","versions":[{"version_number":"1.0.0"}]}`))
+	s.Require().NoError(err)
+	create.Body.Close()
+	s.Require().Equal(http.StatusCreated, create.StatusCode)
+
+	lines := s.readEvent(body)
+	s.Require().Len(lines, 3)
+	s.Equal("id: 1", lines[0])
+	s.Equal("event: service.created", lines[1])
+	s.Contains(lines[2], `"service_id":"svc-1"`)
+	s.Contains(lines[2], `"changed_fields":["name","versions"]`)
+	s.Contains(lines[2], `"dedup_id":"msg-0"`)
+	s.Equal("event: version.published", s.readEvent(body)[1])
+}
+
+func (s *EventStreamTestSuite) Test_ResumesFromLastEventID() {
+	s.store.On("LastID", mock.Anything).Return(int64(7), nil)
+	s.store.On("ListAfter", mock.Anything, int64(3), mock.Anything).Return([]*models.ServiceEvent{
+		{ID: 5, Type: models.EventServiceDeleted, ServiceID: "svc-5"},
+		{ID: 7, Type: models.EventServiceUpdated, ServiceID: "svc-7"},
+	}, nil)
+
+	_, body := s.open("3")
+
+	s.Equal([]string{"event: service.gap", `data: {"last_event_id":3}`}, s.readEvent(body))
+	s.Equal("id: 5", s.readEvent(body)[0])
+	s.Equal("id: 7", s.readEvent(body)[0])
+}
+
+func (s *EventStreamTestSuite) Test_RejectsMalformedLastEventID() {
+	res, _ := s.open("abc")
+	s.Equal(http.StatusBadRequest, res.StatusCode)
+}
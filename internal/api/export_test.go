@@ -0,0 +1,84 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"catalog-service/internal/config"
+	"catalog-service/internal/events"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	"catalog-service/internal/repository"
+	mockrepo "catalog-service/test/mocks/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExportTestSuite struct {
+	suite.Suite
+	repo   *mockrepo.ServiceRepository
+	router *gin.Engine
+}
+
+func TestExportSuite(t *testing.T) {
+	suite.Run(t, new(ExportTestSuite))
+}
+
+func (s *ExportTestSuite) SetupTest() {
+	config.Load()
+	logger.Setup("INFO", "json")
+	s.repo = new(mockrepo.ServiceRepository)
+	s.router = NewRouter(Dependencies{Services: s.repo, Events: events.NewBroker(nil)})
+}
+
+func (s *ExportTestSuite) export(exportErr error) *http.Response {
+	s.repo.On("Export", mock.Anything, "", models.ServiceFilter{}, mock.Anything).Run(func(args mock.Arguments) {
+		fn := args.Get(3).(func(*models.Service) error)
+		_ = fn(&models.Service{ID: "svc-1", Name: "Forex Card"})
+	}).Return(exportErr)
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/services/export", nil))
+	return w.Result()
+}
+
+func (s *ExportTestSuite) Test_ReportsCompletionInTrailers() {
+	resp := s.export(nil)
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal("complete", resp.Trailer.Get("Export-Status"))
+	s.Equal("1", resp.Trailer.Get("Export-Count"))
+	s.Empty(resp.Trailer.Get("Export-Error"))
+}
+
+func (s *ExportTestSuite) Test_AppliesSearchFilters() {
+	s.repo.On("Export", mock.Anything, "card", models.ServiceFilter{
+		Version:      "1.0.0",
+		Operation:    "GET /rates",
+		UpdatedAfter: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+	}, mock.Anything).Return(nil)
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/services/export?q=card&version=1.0.0&operation=GET+%2Frates&updated_after=2024-06-01T00:00:00Z", nil))
+
+	s.Equal(http.StatusOK, w.Code)
+	s.repo.AssertExpectations(s.T())
+
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/services/export?updated_before=yesterday", nil))
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *ExportTestSuite) Test_ReportsMidStreamFailureInTrailers() {
+	resp := s.export(fmt.Errorf("%w: scroll expired", repository.ErrUnavailable))
+
+	s.Equal(http.StatusOK, resp.StatusCode, "the status was sent with the first service")
+	s.Equal("failed", resp.Trailer.Get("Export-Status"))
+	s.Equal("1", resp.Trailer.Get("Export-Count"))
+	s.Equal("109 storage is temporarily unavailable, retry later", resp.Trailer.Get("Export-Error"))
+}
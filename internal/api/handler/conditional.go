@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"catalog-service/internal/constants"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CacheControl holds the Cache-Control values sent with successful reads.
+type CacheControl struct {
+	Detail string
+	Search string
+}
+
+// respondCacheable writes response with an ETag over its JSON body and a
+// Last-Modified header, or answers 304 without a body when the request's
+// If-None-Match, or otherwise If-Modified-Since, shows the client already has
+// it. If-Modified-Since is only evaluated when checkModifiedSince is set.
+func respondCacheable(c *gin.Context, response any, lastModified time.Time, cacheControl string, checkModifiedSince bool) {
+	body, err := json.Marshal(response)
+	if err != nil {
+		c.JSON(http.StatusOK, response)
+		return
+	}
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	header := c.Writer.Header()
+	header.Set("ETag", etag)
+	if !lastModified.IsZero() {
+		header.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+	if cacheControl != "" {
+		header.Set("Cache-Control", cacheControl)
+	}
+
+	if notModified(c.Request, etag, lastModified, checkModifiedSince) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+// notModified follows RFC 9110: If-None-Match takes precedence, and
+// If-Modified-Since is ignored when it is present.
+func notModified(req *http.Request, etag string, lastModified time.Time, checkModifiedSince bool) bool {
+	if match := req.Header.Get("If-None-Match"); match != "" {
+		return etagMatches(match, etag)
+	}
+	if !checkModifiedSince || lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(req.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// etagMatches uses the weak comparison, so W/"x" matches "x".
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func parseUpdatedAt(value string) time.Time {
+	t, _ := time.Parse(constants.Iso8601Format, value)
+	return t
+}
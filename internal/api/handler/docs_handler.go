@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+
+	"catalog-service/internal/api/docs"
+	"catalog-service/internal/constants"
+	"catalog-service/internal/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DocsHandler struct{}
+
+func NewDocsHandler() *DocsHandler {
+	return &DocsHandler{}
+}
+
+func (h *DocsHandler) OpenAPI(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json", docs.OpenAPI)
+}
+
+func (h *DocsHandler) UI(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", docs.UI)
+}
+
+// Errors lists the error catalog.
+func (h *DocsHandler) Errors(c *gin.Context) {
+	defs := make([]*dto.ErrorDefinitionDTO, 0, len(constants.ErrorCatalog))
+	for _, d := range constants.ErrorCatalog {
+		defs = append(defs, toErrorDefinitionDTO(d))
+	}
+	c.JSON(http.StatusOK, dto.ErrorCatalogResponse{Success: true, Data: defs})
+}
+
+// Error documents one error code; it is the target of problem types.
+func (h *DocsHandler) Error(c *gin.Context) {
+	d, ok := constants.LookupError(c.Param("code"))
+	if !ok {
+		NotFound(c)
+		return
+	}
+	c.JSON(http.StatusOK, dto.ErrorDefinitionResponse{Success: true, Data: toErrorDefinitionDTO(d)})
+}
+
+func toErrorDefinitionDTO(d constants.ErrorDefinition) *dto.ErrorDefinitionDTO {
+	return &dto.ErrorDefinitionDTO{Code: d.Code, Status: d.Status, Title: d.Title, Type: d.Type()}
+}
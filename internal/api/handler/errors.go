@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"catalog-service/internal/api/problem"
+	"catalog-service/internal/constants"
+	"catalog-service/internal/dto"
+	"catalog-service/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// internalError answers requests that failed for reasons the client cannot
+// fix: 408 when the request timeout expired, 503 while OpenSearch is
+// unavailable, so the client can retry later, and 500 with cause otherwise.
+func internalError(err error, entity, cause string) (int, []dto.ErrorObj) {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return http.StatusRequestTimeout, []dto.ErrorObj{{
+			Code:   constants.Error_REQUEST_TIMEOUT,
+			Entity: entity,
+			Cause:  "request took too long",
+		}}
+	}
+	if errors.Is(err, usecase.ErrUnavailable) {
+		return http.StatusServiceUnavailable, []dto.ErrorObj{{
+			Code:   constants.Error_SERVICE_UNAVAILABLE,
+			Entity: entity,
+			Cause:  "storage is temporarily unavailable, retry later",
+		}}
+	}
+	return http.StatusInternalServerError, []dto.ErrorObj{{
+		Code:   constants.Error_GENERIC_SERVICE_ERROR,
+		Entity: entity,
+		Cause:  cause,
+	}}
+}
+
+// NotFound answers requests for routes the API does not have.
+func NotFound(c *gin.Context) {
+	problem.Render(c, http.StatusNotFound, []dto.ErrorObj{{
+		Code:   constants.Error_ROUTE_NOT_FOUND,
+		Entity: "request",
+		Cause:  fmt.Sprintf("no route for %s", c.Request.URL.Path),
+	}})
+}
+
+// MethodNotAllowed answers requests for a route with a method it does not
+// accept; the Allow header lists the accepted ones.
+func MethodNotAllowed(c *gin.Context) {
+	problem.Render(c, http.StatusMethodNotAllowed, []dto.ErrorObj{{
+		Code:   constants.Error_METHOD_NOT_ALLOWED,
+		Entity: "request",
+		Cause:  fmt.Sprintf("%s is not allowed for %s", c.Request.Method, c.Request.URL.Path),
+	}})
+}
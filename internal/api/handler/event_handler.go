@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"catalog-service/internal/api/problem"
+	"catalog-service/internal/constants"
+	"catalog-service/internal/dto"
+	"catalog-service/internal/events"
+	"catalog-service/internal/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+const eventGap = "service.gap"
+
+type EventHandler struct {
+	broker    *events.Broker
+	heartbeat time.Duration
+}
+
+func NewEventHandler(broker *events.Broker, heartbeat time.Duration) *EventHandler {
+	return &EventHandler{broker: broker, heartbeat: heartbeat}
+}
+
+func (h *EventHandler) Stream(c *gin.Context) {
+	ctx := c.Request.Context()
+	log := logger.NewContextLogger(ctx, "EventHandler/Stream")
+
+	var lastEventID int64
+	if raw := strings.TrimSpace(c.GetHeader("Last-Event-ID")); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || id < 0 {
+			problem.Render(c, http.StatusBadRequest, []dto.ErrorObj{
+				{
+					Code:   constants.Error_MALFORMED_DATA,
+					Entity: "Last-Event-ID",
+					Cause:  "must be a non-negative integer",
+				},
+			})
+			return
+		}
+		lastEventID = id
+	}
+
+	sub, err := h.broker.Subscribe(ctx, lastEventID)
+	if err != nil {
+		log.Errorf(err, "failed to subscribe to events")
+		status, errs := internalError(err, "event", "failed to open event stream")
+		problem.Render(c, status, errs)
+		return
+	}
+	defer sub.Close()
+	log.Infof("streaming events after id=%d", lastEventID)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	if sub.Gap {
+		// Some events after Last-Event-ID were trimmed from the log: tell the
+		// client to refetch instead of trusting the stream to be complete.
+		fmt.Fprintf(c.Writer, "event: %s\ndata: {\"last_event_id\":%d}\n\n", eventGap, lastEventID)
+	}
+	c.Writer.Flush()
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fmt.Fprint(c.Writer, ": heartbeat\n\n")
+			c.Writer.Flush()
+		case event, ok := <-sub.Events:
+			if !ok {
+				log.Infof("event stream closed by the broker")
+				return
+			}
+			data, _ := json.Marshal(event)
+			fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+			c.Writer.Flush()
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"catalog-service/internal/gql"
+	"catalog-service/internal/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+type GraphQLHandler struct {
+	executor *gql.Executor
+}
+
+func NewGraphQLHandler(executor *gql.Executor) *GraphQLHandler {
+	return &GraphQLHandler{executor: executor}
+}
+
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	req := gql.Request{
+		Query:         c.Query("query"),
+		OperationName: c.Query("operationName"),
+	}
+	if vars := c.Query("variables"); vars != "" {
+		if err := json.Unmarshal([]byte(vars), &req.Variables); err != nil {
+			buildGraphQLError(c, "variables must be a JSON object")
+			return
+		}
+	}
+	h.execute(c, req, true)
+}
+
+func (h *GraphQLHandler) Execute(c *gin.Context) {
+	var req gql.Request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, &graphql.Result{
+				Errors: []gqlerrors.FormattedError{{Message: fmt.Sprintf("request body is larger than %d bytes", tooLarge.Limit)}},
+			})
+			return
+		}
+		buildGraphQLError(c, "invalid JSON body")
+		return
+	}
+	h.execute(c, req, false)
+}
+
+func (h *GraphQLHandler) execute(c *gin.Context, req gql.Request, readOnly bool) {
+	ctx := c.Request.Context()
+	log := logger.NewContextLogger(ctx, "GraphQLHandler/Execute")
+
+	if req.Query == "" {
+		buildGraphQLError(c, "query is required")
+		return
+	}
+
+	result, ok := h.executor.Execute(ctx, req, readOnly)
+	if !ok {
+		log.Infof("rejected GraphQL request: %v", result.Errors)
+		c.JSON(http.StatusBadRequest, result)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func buildGraphQLError(c *gin.Context, message string) {
+	c.JSON(http.StatusBadRequest, &graphql.Result{
+		Errors: []gqlerrors.FormattedError{{Message: message}},
+	})
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"catalog-service/internal/dto"
+	"catalog-service/internal/health"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HealthHandler struct {
+	readiness *health.Readiness
+}
+
+func NewHealthHandler(readiness *health.Readiness) *HealthHandler {
+	return &HealthHandler{readiness: readiness}
+}
+
+// Live reports that the process is serving requests. It checks no
+// dependencies, so an unavailable OpenSearch does not get the API restarted.
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, &dto.HealthReport{Status: health.StatusUp, CheckedAt: time.Now()})
+}
+
+func (h *HealthHandler) Ready(c *gin.Context) {
+	report := h.readiness.Report(c.Request.Context())
+	status := http.StatusOK
+	if report.Status != health.StatusUp {
+		status = http.StatusServiceUnavailable
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(status, report)
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"catalog-service/internal/constants"
+	"catalog-service/internal/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+var errTrailingData = errors.New("request body must hold a single JSON value")
+
+// bindJSON decodes the request body into v. Unlike c.ShouldBindJSON it
+// rejects fields v does not have, naming them, and anything after the JSON
+// value.
+func bindJSON(c *gin.Context, v any, entity string) (int, []dto.ErrorObj) {
+	dec := json.NewDecoder(c.Request.Body)
+	dec.DisallowUnknownFields()
+	err := dec.Decode(v)
+	if err == nil && dec.Decode(&json.RawMessage{}) != io.EOF {
+		err = errTrailingData
+	}
+	if err == nil {
+		return 0, nil
+	}
+	return bodyError(err, entity)
+}
+
+// readBody reads the whole request body.
+func readBody(c *gin.Context, entity string) ([]byte, int, []dto.ErrorObj) {
+	content, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		status, errs := bodyError(err, entity)
+		return nil, status, errs
+	}
+	return content, 0, nil
+}
+
+func bodyError(err error, entity string) (int, []dto.ErrorObj) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return http.StatusRequestEntityTooLarge, []dto.ErrorObj{{
+			Code:   constants.Error_PAYLOAD_TOO_LARGE,
+			Entity: entity,
+			Cause:  fmt.Sprintf("request body is larger than %d bytes", tooLarge.Limit),
+		}}
+	}
+	return http.StatusBadRequest, []dto.ErrorObj{{
+		Code:   constants.Error_MALFORMED_DATA,
+		Entity: entity,
+		Cause:  decodeCause(err),
+	}}
+}
+
+func decodeCause(err error) string {
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		return fmt.Sprintf("field %q cannot be a %s", typeErr.Field, typeErr.Value)
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		return strings.TrimPrefix(err.Error(), "json: ")
+	case errors.Is(err, io.EOF):
+		return "request body is empty"
+	case errors.Is(err, errTrailingData):
+		return err.Error()
+	}
+	return "invalid request body"
+}
@@ -1,29 +1,38 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
+	"catalog-service/internal/api/problem"
 	"catalog-service/internal/api/validator"
 	"catalog-service/internal/constants"
 	"catalog-service/internal/dto"
+	"catalog-service/internal/ingest"
 	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	"catalog-service/internal/spec"
 	"catalog-service/internal/usecase"
 
 	"github.com/gin-gonic/gin"
 )
 
 const (
-	defaultPage  = "1"
-	defaultLimit = "10"
+	defaultPage         = "1"
+	defaultLimit        = "10"
+	defaultExportFormat = "jsonl"
 )
 
 type ServiceHandler struct {
-	usecase usecase.ServiceUsecase
+	usecase      usecase.ServiceUsecase
+	cacheControl CacheControl
 }
 
-func NewServiceHandler(usecase usecase.ServiceUsecase) *ServiceHandler {
-	return &ServiceHandler{usecase: usecase}
+func NewServiceHandler(usecase usecase.ServiceUsecase, cacheControl CacheControl) *ServiceHandler {
+	return &ServiceHandler{usecase: usecase, cacheControl: cacheControl}
 }
 
 func (h *ServiceHandler) Search(c *gin.Context) {
@@ -38,24 +47,34 @@ func (h *ServiceHandler) Search(c *gin.Context) {
 
 	page, limit, errs, httpCode := validator.ValidateSearchRequest(pageStr, limitStr)
 	if len(errs) > 0 {
-		buildErrorListResponse(c, httpCode, errs)
+		problem.Render(c, httpCode, errs)
 		return
 	}
 
 	services, total, err := h.usecase.Search(ctx, query, page, limit)
 	if err != nil {
 		log.Errorf(err, "failed to search services")
-		buildErrorListResponse(c, http.StatusInternalServerError, []dto.ErrorObj{
-			{
-				Code:   constants.Error_GENERIC_SERVICE_ERROR,
-				Entity: "service",
-				Cause:  "search failed",
-			},
-		})
+		status, errs := internalError(err, "service", "search failed")
+		problem.Render(c, status, errs)
 		return
 	}
 
-	buildSuccessListResponse(c, services, total, query, page, limit)
+	// A deleted service does not move the newest updated_at, so searches are
+	// only revalidated with If-None-Match.
+	var lastModified time.Time
+	for _, service := range services {
+		if updatedAt := parseUpdatedAt(service.UpdatedAt); updatedAt.After(lastModified) {
+			lastModified = updatedAt
+		}
+	}
+	respondCacheable(c, dto.ServiceListResponse{
+		Success: true,
+		Data: &dto.ServiceListData{
+			Count:    total,
+			Services: services,
+			Next:     buildNextURL(c, query, page, limit, total),
+		},
+	}, lastModified, h.cacheControl.Search, false)
 }
 
 func (h *ServiceHandler) GetByID(c *gin.Context) {
@@ -65,26 +84,21 @@ func (h *ServiceHandler) GetByID(c *gin.Context) {
 	log.Infof("fetching service by id='%s'", id)
 
 	if errs, httpCode := validator.ValidateID(id); len(errs) > 0 {
-		c.JSON(httpCode, dto.ServiceDetailResponse{
-			Success: false,
-			Errors:  errs,
-		})
+		problem.Render(c, httpCode, errs)
 		return
 	}
 
 	service, err := h.usecase.FindByID(ctx, id)
 	if err != nil {
-		buildErrorDetailResponse(c, http.StatusNotFound, []dto.ErrorObj{
-			{
-				Code:   constants.Error_SERVICE_NOT_FOUND,
-				Entity: "service",
-				Cause:  "service not found",
-			},
-		})
+		status, errs := serviceError(err, "failed to fetch service")
+		problem.Render(c, status, errs)
 		return
 	}
 
-	buildSuccessDetailResponse(c, service)
+	respondCacheable(c, dto.ServiceDetailResponse{
+		Success: true,
+		Data:    service,
+	}, parseUpdatedAt(service.UpdatedAt), h.cacheControl.Detail, true)
 }
 
 func (h *ServiceHandler) Create(c *gin.Context) {
@@ -92,38 +106,22 @@ func (h *ServiceHandler) Create(c *gin.Context) {
 	log := logger.NewContextLogger(ctx, "ServiceHandler/Create")
 
 	var req dto.ServiceDTO
-	if err := c.ShouldBindJSON(&req); err != nil {
-		log.Errorf(err, "invalid request body")
-		c.JSON(http.StatusBadRequest, dto.ServiceDetailResponse{
-			Success: false,
-			Errors: []dto.ErrorObj{{
-				Code:   constants.Error_MALFORMED_DATA,
-				Entity: "service",
-				Cause:  "invalid request body",
-			}},
-		})
+	if status, errs := bindJSON(c, &req, "service"); errs != nil {
+		log.Infof("rejected request body: %s", errs[0].Cause)
+		problem.Render(c, status, errs)
 		return
 	}
 
 	if errs, httpCode := validator.ValidateCreateRequest(&req); len(errs) > 0 {
-		c.JSON(httpCode, dto.ServiceDetailResponse{
-			Success: false,
-			Errors:  errs,
-		})
+		problem.Render(c, httpCode, errs)
 		return
 	}
 
 	service, err := h.usecase.Create(ctx, &req)
 	if err != nil {
 		log.Errorf(err, "failed to create service")
-		c.JSON(http.StatusInternalServerError, dto.ServiceDetailResponse{
-			Success: false,
-			Errors: []dto.ErrorObj{{
-				Code:   "900",
-				Entity: "service",
-				Cause:  "failed to create service",
-			}},
-		})
+		status, errs := serviceError(err, "failed to create service")
+		problem.Render(c, status, errs)
 		return
 	}
 
@@ -140,22 +138,14 @@ func (h *ServiceHandler) Delete(c *gin.Context) {
 	log.Infof("deleting service by id='%s'", id)
 
 	if errs, httpCode := validator.ValidateID(id); len(errs) > 0 {
-		c.JSON(httpCode, dto.ServiceDetailResponse{
-			Success: false,
-			Errors:  errs,
-		})
+		problem.Render(c, httpCode, errs)
 		return
 	}
 
 	err := h.usecase.Delete(ctx, id)
 	if err != nil {
-		buildErrorDetailResponse(c, http.StatusNotFound, []dto.ErrorObj{
-			{
-				Code:   constants.Error_SERVICE_NOT_FOUND,
-				Entity: "service",
-				Cause:  "service not found",
-			},
-		})
+		status, errs := serviceError(err, "failed to delete service")
+		problem.Render(c, status, errs)
 		return
 	}
 
@@ -171,60 +161,122 @@ func (h *ServiceHandler) Update(c *gin.Context) {
 	log.Infof("updating service by id='%s'", id)
 
 	if errs, httpCode := validator.ValidateID(id); len(errs) > 0 {
-		c.JSON(httpCode, dto.ServiceDetailResponse{
-			Success: false,
-			Errors:  errs,
-		})
+		problem.Render(c, httpCode, errs)
 		return
 	}
 
 	var req dto.ServiceDTO
-	if err := c.ShouldBindJSON(&req); err != nil {
-		log.Errorf(err, "invalid request body")
-		c.JSON(http.StatusBadRequest, dto.ServiceDetailResponse{
-			Success: false,
-			Errors: []dto.ErrorObj{{
-				Code:   constants.Error_MALFORMED_DATA,
-				Entity: "service",
-				Cause:  "invalid request body",
-			}},
-		})
+	if status, errs := bindJSON(c, &req, "service"); errs != nil {
+		log.Infof("rejected request body: %s", errs[0].Cause)
+		problem.Render(c, status, errs)
 		return
 	}
 
 	if errs, httpCode := validator.ValidateUpdateRequest(&req); len(errs) > 0 {
-		c.JSON(httpCode, dto.ServiceDetailResponse{
-			Success: false,
-			Errors:  errs,
-		})
+		problem.Render(c, httpCode, errs)
 		return
 	}
 
 	service, err := h.usecase.Update(ctx, id, &req)
 	if err != nil {
 		log.Errorf(err, "failed to update service")
-		buildErrorDetailResponse(c, http.StatusNotFound, []dto.ErrorObj{
-			{
-				Code:   constants.Error_SERVICE_NOT_FOUND,
-				Entity: "service",
-				Cause:  "service not found",
-			},
-		})
+		status, errs := serviceError(err, "failed to update service")
+		problem.Render(c, status, errs)
 		return
 	}
 
 	buildSuccessDetailResponse(c, service)
 }
 
-func buildSuccessListResponse(c *gin.Context, services []*dto.ServiceDTO, total int, query string, page, limit int) {
-	c.JSON(http.StatusOK, dto.ServiceListResponse{
-		Success: true,
-		Data: &dto.ServiceListData{
-			Count:    total,
-			Services: services,
-			Next:     buildNextURL(c, query, page, limit, total),
-		},
+func (h *ServiceHandler) Export(c *gin.Context) {
+	ctx := c.Request.Context()
+	log := logger.NewContextLogger(ctx, "ServiceHandler/Export")
+
+	query := c.Query("q")
+	format, errs, httpCode := validator.ValidateExportRequest(c.DefaultQuery("format", defaultExportFormat))
+	if len(errs) > 0 {
+		problem.Render(c, httpCode, errs)
+		return
+	}
+	filter, errs, httpCode := validator.ValidateServiceFilter(c.Request.URL.Query())
+	if len(errs) > 0 {
+		problem.Render(c, httpCode, errs)
+		return
+	}
+	log.Infof("exporting services: query='%s', format='%s', filter=%+v", query, format, filter)
+
+	enc, _ := ingest.NewEncoder(format, c.Writer)
+	started, exported := false, 0
+	start := func() {
+		if started {
+			return
+		}
+		started = true
+		c.Header("Content-Type", ingest.ContentType(format))
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=services%s", ingest.Extension(format)))
+		// The status is sent with the first service, so the outcome of the
+		// export follows the body in trailers.
+		c.Header("Trailer", "Export-Status, Export-Count, Export-Error")
+		c.Status(http.StatusOK)
+	}
+	finish := func(status string) {
+		c.Writer.Header().Set("Export-Status", status)
+		c.Writer.Header().Set("Export-Count", strconv.Itoa(exported))
+	}
+
+	err := h.usecase.Export(ctx, query, filter, func(service *models.Service) error {
+		start()
+		if err := enc.Encode(service); err != nil {
+			return err
+		}
+		exported++
+		c.Writer.Flush()
+		return nil
 	})
+	if err != nil && !started {
+		log.Errorf(err, "failed to export services")
+		status, errs := internalError(err, "service", "export failed")
+		problem.Render(c, status, errs)
+		return
+	}
+	if err != nil {
+		log.Errorf(err, "export aborted after %d services", exported)
+		_, errs := internalError(err, "service", "export failed")
+		finish("failed")
+		c.Writer.Header().Set("Export-Error", errs[0].Code+" "+errs[0].Cause)
+		return
+	}
+
+	start()
+	if err := enc.Close(); err != nil {
+		log.Errorf(err, "failed to finish export")
+		finish("failed")
+		return
+	}
+	finish("complete")
+	log.Infof("exported %d services", exported)
+}
+
+// serviceError answers 404 for a missing service; other errors are internal.
+func serviceError(err error, cause string) (int, []dto.ErrorObj) {
+	if errors.Is(err, usecase.ErrServiceNotFound) {
+		return http.StatusNotFound, []dto.ErrorObj{{
+			Code:   constants.Error_SERVICE_NOT_FOUND,
+			Entity: "service",
+			Cause:  "service not found",
+		}}
+	}
+	if errors.Is(err, spec.ErrInvalidSpec) {
+		return http.StatusBadRequest, []dto.ErrorObj{{
+			Code:   constants.Error_MALFORMED_DATA,
+			Entity: "specs",
+			Cause:  err.Error(),
+		}}
+	}
+	if errors.Is(err, usecase.ErrBreakingChange) {
+		return http.StatusConflict, breakingChangeErrors(err)
+	}
+	return internalError(err, "service", cause)
 }
 
 func buildSuccessDetailResponse(c *gin.Context, service *dto.ServiceDTO) {
@@ -234,18 +286,6 @@ func buildSuccessDetailResponse(c *gin.Context, service *dto.ServiceDTO) {
 	})
 }
 
-func buildErrorListResponse(c *gin.Context, httpCode int, errs []dto.ErrorObj) {
-	c.JSON(httpCode, dto.ServiceListResponse{
-		Errors: errs,
-	})
-}
-
-func buildErrorDetailResponse(c *gin.Context, httpCode int, errs []dto.ErrorObj) {
-	c.JSON(httpCode, dto.ServiceDetailResponse{
-		Errors: errs,
-	})
-}
-
 func buildNextURL(c *gin.Context, query string, page, limit, total int) *string {
 	if (page * limit) >= total {
 		return nil
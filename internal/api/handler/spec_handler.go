@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"catalog-service/internal/api/problem"
+	"catalog-service/internal/api/validator"
+	"catalog-service/internal/constants"
+	"catalog-service/internal/dto"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/spec"
+	"catalog-service/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SpecHandler struct {
+	usecase usecase.SpecUsecase
+}
+
+func NewSpecHandler(usecase usecase.SpecUsecase) *SpecHandler {
+	return &SpecHandler{usecase: usecase}
+}
+
+func (h *SpecHandler) PutSpec(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, version := c.Param("id"), c.Param("version")
+	log := logger.NewContextLogger(ctx, "SpecHandler/PutSpec")
+	log.Infof("storing spec for service id='%s' version='%s'", id, version)
+
+	if errs, httpCode := validator.ValidateID(id); len(errs) > 0 {
+		problem.Render(c, httpCode, errs)
+		return
+	}
+
+	content, status, errs := readBody(c, "spec")
+	if errs != nil {
+		log.Infof("rejected request body: %s", errs[0].Cause)
+		problem.Render(c, status, errs)
+		return
+	}
+
+	format := spec.Format(c.Query("format"))
+	if format == "" {
+		format = spec.FormatForContentType(c.ContentType())
+	}
+
+	result, err := h.usecase.PutSpec(ctx, id, version, format, content)
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, dto.SpecDetailResponse{
+			Success: true,
+			Data:    result,
+		})
+	case errors.Is(err, spec.ErrInvalidSpec):
+		log.Errorf(err, "invalid spec document")
+		problem.Render(c, http.StatusBadRequest, []dto.ErrorObj{{
+			Code:   constants.Error_MALFORMED_DATA,
+			Entity: "spec",
+			Cause:  err.Error(),
+		}})
+	case errors.Is(err, usecase.ErrServiceNotFound):
+		problem.Render(c, http.StatusNotFound, []dto.ErrorObj{{
+			Code:   constants.Error_SERVICE_NOT_FOUND,
+			Entity: "service",
+			Cause:  "service not found",
+		}})
+	case errors.Is(err, usecase.ErrVersionNotFound):
+		problem.Render(c, http.StatusNotFound, []dto.ErrorObj{{
+			Code:   constants.Error_VERSION_NOT_FOUND,
+			Entity: "version",
+			Cause:  "version not found",
+		}})
+	case errors.Is(err, usecase.ErrBreakingChange):
+		log.Warnf("rejected spec: %v", err)
+		problem.Render(c, http.StatusConflict, breakingChangeErrors(err))
+	default:
+		log.Errorf(err, "failed to store spec")
+		status, errs := internalError(err, "spec", "failed to store specification")
+		problem.Render(c, status, errs)
+	}
+}
+
+func (h *SpecHandler) GetSpec(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, version := c.Param("id"), c.Param("version")
+	log := logger.NewContextLogger(ctx, "SpecHandler/GetSpec")
+	log.Infof("fetching spec for service id='%s' version='%s'", id, version)
+
+	if errs, httpCode := validator.ValidateID(id); len(errs) > 0 {
+		problem.Render(c, httpCode, errs)
+		return
+	}
+
+	record, err := h.usecase.GetSpec(ctx, id, version)
+	if errors.Is(err, usecase.ErrSpecNotFound) {
+		problem.Render(c, http.StatusNotFound, []dto.ErrorObj{{
+			Code:   constants.Error_SPEC_NOT_FOUND,
+			Entity: "spec",
+			Cause:  "spec not found",
+		}})
+		return
+	}
+	if err != nil {
+		log.Errorf(err, "failed to fetch spec")
+		status, errs := internalError(err, "spec", "failed to fetch specification")
+		problem.Render(c, status, errs)
+		return
+	}
+
+	c.Data(http.StatusOK, record.ContentType, []byte(record.Content))
+}
+
+func (h *SpecHandler) ImportOpenAPI(c *gin.Context) {
+	ctx := c.Request.Context()
+	log := logger.NewContextLogger(ctx, "SpecHandler/ImportOpenAPI")
+
+	content, status, errs := readBody(c, "spec")
+	if errs != nil {
+		log.Infof("rejected request body: %s", errs[0].Cause)
+		problem.Render(c, status, errs)
+		return
+	}
+
+	service, created, err := h.usecase.ImportOpenAPI(ctx, content, c.Query("note"))
+	if errors.Is(err, spec.ErrInvalidSpec) {
+		log.Errorf(err, "invalid openapi document")
+		problem.Render(c, http.StatusBadRequest, []dto.ErrorObj{{
+			Code:   constants.Error_MALFORMED_DATA,
+			Entity: "spec",
+			Cause:  err.Error(),
+		}})
+		return
+	}
+	if errors.Is(err, usecase.ErrBreakingChange) {
+		log.Warnf("rejected openapi document: %v", err)
+		problem.Render(c, http.StatusConflict, breakingChangeErrors(err))
+		return
+	}
+	if err != nil {
+		log.Errorf(err, "failed to import openapi document")
+		status, errs := internalError(err, "spec", "failed to import specification")
+		problem.Render(c, status, errs)
+		return
+	}
+
+	status = http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, dto.ServiceDetailResponse{
+		Success: true,
+		Data:    service,
+	})
+}
+
+func (h *SpecHandler) Compatibility(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, version := c.Param("id"), c.Param("version")
+	log := logger.NewContextLogger(ctx, "SpecHandler/Compatibility")
+	log.Infof("checking compatibility for service id='%s' version='%s'", id, version)
+
+	if errs, httpCode := validator.ValidateID(id); len(errs) > 0 {
+		problem.Render(c, httpCode, errs)
+		return
+	}
+
+	result, err := h.usecase.Compatibility(ctx, id, version)
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, dto.CompatibilityResponse{
+			Success: true,
+			Data:    result,
+		})
+	case errors.Is(err, usecase.ErrServiceNotFound):
+		problem.Render(c, http.StatusNotFound, []dto.ErrorObj{{
+			Code:   constants.Error_SERVICE_NOT_FOUND,
+			Entity: "service",
+			Cause:  "service not found",
+		}})
+	case errors.Is(err, usecase.ErrVersionNotFound):
+		problem.Render(c, http.StatusNotFound, []dto.ErrorObj{{
+			Code:   constants.Error_VERSION_NOT_FOUND,
+			Entity: "version",
+			Cause:  "version not found",
+		}})
+	case errors.Is(err, usecase.ErrSpecNotFound):
+		problem.Render(c, http.StatusNotFound, []dto.ErrorObj{{
+			Code:   constants.Error_SPEC_NOT_FOUND,
+			Entity: "spec",
+			Cause:  "spec not found",
+		}})
+	case errors.Is(err, usecase.ErrNotComparable):
+		problem.Render(c, http.StatusUnprocessableEntity, []dto.ErrorObj{{
+			Code:   constants.Error_SPEC_NOT_COMPARABLE,
+			Entity: "spec",
+			Cause:  err.Error(),
+		}})
+	default:
+		log.Errorf(err, "failed to check compatibility")
+		status, errs := internalError(err, "spec", "failed to check compatibility")
+		problem.Render(c, status, errs)
+	}
+}
+
+func breakingChangeErrors(err error) []dto.ErrorObj {
+	var breaking *usecase.BreakingChangeError
+	if !errors.As(err, &breaking) {
+		return []dto.ErrorObj{{Code: constants.Error_BREAKING_CHANGE, Entity: "spec", Cause: err.Error()}}
+	}
+	errs := make([]dto.ErrorObj, 0, len(breaking.Changes))
+	for _, change := range breaking.Changes {
+		errs = append(errs, dto.ErrorObj{
+			Code:   constants.Error_BREAKING_CHANGE,
+			Entity: "spec",
+			Cause:  fmt.Sprintf("%s %s: %s (requires a major version bump from %s)", change.Method, change.Path, change.Detail, breaking.Previous),
+		})
+	}
+	return errs
+}
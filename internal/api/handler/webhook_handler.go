@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"catalog-service/internal/api/problem"
+	"catalog-service/internal/api/validator"
+	"catalog-service/internal/constants"
+	"catalog-service/internal/dto"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	"catalog-service/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	usecase usecase.WebhookUsecase
+}
+
+func NewWebhookHandler(usecase usecase.WebhookUsecase) *WebhookHandler {
+	return &WebhookHandler{usecase: usecase}
+}
+
+func (h *WebhookHandler) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+	log := logger.NewContextLogger(ctx, "WebhookHandler/Create")
+
+	var req dto.WebhookDTO
+	if status, errs := bindJSON(c, &req, "webhook"); errs != nil {
+		log.Infof("rejected request body: %s", errs[0].Cause)
+		problem.Render(c, status, errs)
+		return
+	}
+	if errs, httpCode := validator.ValidateWebhookRequest(&req); len(errs) > 0 {
+		problem.Render(c, httpCode, errs)
+		return
+	}
+
+	webhook, err := h.usecase.Create(ctx, &req)
+	if err != nil {
+		log.Errorf(err, "failed to create webhook")
+		status, errs := internalError(err, "webhook", "failed to create webhook")
+		problem.Render(c, status, errs)
+		return
+	}
+	log.Infof("created webhook id='%s'", webhook.ID)
+	c.JSON(http.StatusCreated, dto.WebhookResponse{Success: true, Data: webhook})
+}
+
+func (h *WebhookHandler) List(c *gin.Context) {
+	ctx := c.Request.Context()
+	log := logger.NewContextLogger(ctx, "WebhookHandler/List")
+
+	filter := models.WebhookFilter{
+		ServiceID: c.Query("service_id"),
+		Label:     c.Query("label"),
+		EventType: models.EventType(c.Query("event_type")),
+	}
+	if errs, httpCode := validator.ValidateWebhookFilter(string(filter.EventType)); len(errs) > 0 {
+		problem.Render(c, httpCode, errs)
+		return
+	}
+
+	webhooks, err := h.usecase.List(ctx, filter)
+	if err != nil {
+		log.Errorf(err, "failed to list webhooks")
+		status, errs := internalError(err, "webhook", "failed to list webhooks")
+		problem.Render(c, status, errs)
+		return
+	}
+	c.JSON(http.StatusOK, dto.WebhookListResponse{Success: true, Data: webhooks})
+}
+
+func (h *WebhookHandler) GetByID(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	log := logger.NewContextLogger(ctx, "WebhookHandler/GetByID")
+
+	webhook, err := h.usecase.FindByID(ctx, id)
+	if err != nil {
+		log.Errorf(err, "failed to get webhook id='%s'", id)
+		status, errs := webhookError(err, "failed to get webhook")
+		problem.Render(c, status, errs)
+		return
+	}
+	c.JSON(http.StatusOK, dto.WebhookResponse{Success: true, Data: webhook})
+}
+
+func (h *WebhookHandler) Update(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	log := logger.NewContextLogger(ctx, "WebhookHandler/Update")
+
+	var req dto.WebhookDTO
+	if status, errs := bindJSON(c, &req, "webhook"); errs != nil {
+		log.Infof("rejected request body: %s", errs[0].Cause)
+		problem.Render(c, status, errs)
+		return
+	}
+	if errs, httpCode := validator.ValidateWebhookRequest(&req); len(errs) > 0 {
+		problem.Render(c, httpCode, errs)
+		return
+	}
+
+	webhook, err := h.usecase.Update(ctx, id, &req)
+	if err != nil {
+		log.Errorf(err, "failed to update webhook id='%s'", id)
+		status, errs := webhookError(err, "failed to update webhook")
+		problem.Render(c, status, errs)
+		return
+	}
+	c.JSON(http.StatusOK, dto.WebhookResponse{Success: true, Data: webhook})
+}
+
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	log := logger.NewContextLogger(ctx, "WebhookHandler/Delete")
+
+	if err := h.usecase.Delete(ctx, id); err != nil {
+		log.Errorf(err, "failed to delete webhook id='%s'", id)
+		status, errs := webhookError(err, "failed to delete webhook")
+		problem.Render(c, status, errs)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *WebhookHandler) Deliveries(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	log := logger.NewContextLogger(ctx, "WebhookHandler/Deliveries")
+
+	deliveries, err := h.usecase.Deliveries(ctx, id)
+	if err != nil {
+		log.Errorf(err, "failed to list deliveries of webhook id='%s'", id)
+		status, errs := webhookError(err, "failed to list deliveries")
+		problem.Render(c, status, errs)
+		return
+	}
+	c.JSON(http.StatusOK, dto.DeliveryListResponse{Success: true, Data: deliveries})
+}
+
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, deliveryID := c.Param("id"), c.Param("delivery_id")
+	log := logger.NewContextLogger(ctx, "WebhookHandler/Redeliver")
+
+	delivery, err := h.usecase.Redeliver(ctx, id, deliveryID)
+	if err != nil {
+		log.Errorf(err, "failed to redeliver delivery id='%s' of webhook id='%s'", deliveryID, id)
+		status, errs := webhookError(err, "failed to redeliver")
+		problem.Render(c, status, errs)
+		return
+	}
+	log.Infof("redelivering delivery id='%s' as id='%s'", deliveryID, delivery.ID)
+	c.JSON(http.StatusAccepted, dto.DeliveryResponse{Success: true, Data: delivery})
+}
+
+func webhookError(err error, cause string) (int, []dto.ErrorObj) {
+	switch {
+	case errors.Is(err, usecase.ErrWebhookNotFound):
+		return http.StatusNotFound, []dto.ErrorObj{{
+			Code:   constants.Error_WEBHOOK_NOT_FOUND,
+			Entity: "webhook",
+			Cause:  "webhook not found",
+		}}
+	case errors.Is(err, usecase.ErrDeliveryNotFound):
+		return http.StatusNotFound, []dto.ErrorObj{{
+			Code:   constants.Error_DELIVERY_NOT_FOUND,
+			Entity: "delivery",
+			Cause:  "delivery not found",
+		}}
+	}
+	return internalError(err, "webhook", cause)
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"catalog-service/internal/config"
+	"catalog-service/internal/dto"
+	"catalog-service/internal/events"
+	"catalog-service/internal/health"
+	"catalog-service/internal/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type HealthTestSuite struct {
+	suite.Suite
+	err       error
+	readiness *health.Readiness
+	router    *gin.Engine
+}
+
+func TestHealthSuite(t *testing.T) {
+	suite.Run(t, new(HealthTestSuite))
+}
+
+func (s *HealthTestSuite) SetupTest() {
+	config.Load()
+	logger.Setup("INFO", "json")
+	s.err = nil
+	s.readiness = health.NewReadiness(health.Options{}, health.Check{Name: "opensearch", Run: func(context.Context) (string, error) {
+		return "cluster is green", s.err
+	}})
+	s.router = NewRouter(Dependencies{Events: events.NewBroker(nil), Readiness: s.readiness})
+}
+
+func (s *HealthTestSuite) get(path string) (int, *dto.HealthReport) {
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	var report dto.HealthReport
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &report))
+	return w.Code, &report
+}
+
+func (s *HealthTestSuite) Test_Liveness_DoesNotCheckDependencies() {
+	s.err = errors.New("connection refused")
+
+	code, report := s.get("/healthz")
+
+	s.Equal(http.StatusOK, code)
+	s.Equal(health.StatusUp, report.Status)
+	s.Empty(report.Checks)
+}
+
+func (s *HealthTestSuite) Test_Readiness_ReportsChecks() {
+	code, report := s.get("/readyz")
+	s.Equal(http.StatusOK, code)
+	s.Equal(health.StatusUp, report.Status)
+	s.Require().Len(report.Checks, 1)
+	s.Equal("cluster is green", report.Checks[0].Detail)
+
+	s.err = errors.New("connection refused")
+	code, report = s.get("/readyz")
+	s.Equal(http.StatusServiceUnavailable, code)
+	s.Equal(health.StatusDown, report.Status)
+	s.Equal("connection refused", report.Checks[0].Error)
+}
+
+func (s *HealthTestSuite) Test_Readiness_FailsWhileDraining() {
+	s.readiness.Drain()
+
+	code, report := s.get("/readyz")
+
+	s.Equal(http.StatusServiceUnavailable, code)
+	s.Equal(health.StatusDraining, report.Status)
+}
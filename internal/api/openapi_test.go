@@ -0,0 +1,193 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"catalog-service/internal/api/docs"
+	"catalog-service/internal/config"
+	"catalog-service/internal/dto"
+	"catalog-service/internal/events"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	"catalog-service/internal/spec"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+var documentedTypes = map[string]reflect.Type{
+	"ErrorObj":                reflect.TypeOf(dto.ErrorObj{}),
+	"Version":                 reflect.TypeOf(models.Version{}),
+	"Service":                 reflect.TypeOf(models.Service{}),
+	"ServiceDTO":              reflect.TypeOf(dto.ServiceDTO{}),
+	"ServiceListData":         reflect.TypeOf(dto.ServiceListData{}),
+	"ServiceListResponse":     reflect.TypeOf(dto.ServiceListResponse{}),
+	"ServiceDetailResponse":   reflect.TypeOf(dto.ServiceDetailResponse{}),
+	"SpecDTO":                 reflect.TypeOf(dto.SpecDTO{}),
+	"SpecDetailResponse":      reflect.TypeOf(dto.SpecDetailResponse{}),
+	"BreakingChange":          reflect.TypeOf(spec.BreakingChange{}),
+	"CompatibilityDTO":        reflect.TypeOf(dto.CompatibilityDTO{}),
+	"CompatibilityResponse":   reflect.TypeOf(dto.CompatibilityResponse{}),
+	"ServiceEvent":            reflect.TypeOf(models.ServiceEvent{}),
+	"WebhookDTO":              reflect.TypeOf(dto.WebhookDTO{}),
+	"DeliveryDTO":             reflect.TypeOf(dto.DeliveryDTO{}),
+	"WebhookResponse":         reflect.TypeOf(dto.WebhookResponse{}),
+	"WebhookListResponse":     reflect.TypeOf(dto.WebhookListResponse{}),
+	"DeliveryResponse":        reflect.TypeOf(dto.DeliveryResponse{}),
+	"DeliveryListResponse":    reflect.TypeOf(dto.DeliveryListResponse{}),
+	"HealthCheckDTO":          reflect.TypeOf(dto.HealthCheckDTO{}),
+	"HealthReport":            reflect.TypeOf(dto.HealthReport{}),
+	"ProblemDetails":          reflect.TypeOf(dto.ProblemDetails{}),
+	"ErrorDefinitionDTO":      reflect.TypeOf(dto.ErrorDefinitionDTO{}),
+	"ErrorCatalogResponse":    reflect.TypeOf(dto.ErrorCatalogResponse{}),
+	"ErrorDefinitionResponse": reflect.TypeOf(dto.ErrorDefinitionResponse{}),
+}
+
+var ginParam = regexp.MustCompile(`:([^/]+)`)
+
+type schema struct {
+	Ref        string             `json:"$ref"`
+	Type       string             `json:"type"`
+	Required   []string           `json:"required"`
+	Properties map[string]*schema `json:"properties"`
+	Items      *schema            `json:"items"`
+	// AdditionalProperties is the schema of the values of a map.
+	AdditionalProperties *schema `json:"additionalProperties"`
+}
+
+type document struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]*schema `json:"schemas"`
+	} `json:"components"`
+}
+
+type OpenAPITestSuite struct {
+	suite.Suite
+	doc    document
+	router *gin.Engine
+}
+
+func TestOpenAPISuite(t *testing.T) {
+	suite.Run(t, new(OpenAPITestSuite))
+}
+
+func (s *OpenAPITestSuite) SetupSuite() {
+	config.Load()
+	logger.Setup("INFO", "json")
+	s.Require().NoError(json.Unmarshal(docs.OpenAPI, &s.doc))
+	s.router = NewRouter(Dependencies{Events: events.NewBroker(nil)})
+}
+
+func (s *OpenAPITestSuite) Test_EveryRouteIsDocumented() {
+	var registered []string
+	for _, route := range s.router.Routes() {
+		registered = append(registered, route.Method+" "+ginParam.ReplaceAllString(route.Path, "{$1}"))
+	}
+	var documented []string
+	for path, item := range s.doc.Paths {
+		for method := range item {
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(registered)
+	sort.Strings(documented)
+	s.Equal(registered, documented, "routes in NewRouter and internal/api/docs/openapi.json differ")
+}
+
+func (s *OpenAPITestSuite) Test_SchemasMatchTypes() {
+	for name := range s.doc.Components.Schemas {
+		s.Contains(documentedTypes, name, "schema %s has no Go type in documentedTypes", name)
+	}
+	for name, typ := range documentedTypes {
+		sch, ok := s.doc.Components.Schemas[name]
+		if !s.True(ok, "type %s is not documented", name) {
+			continue
+		}
+		s.checkStruct(name, typ, sch)
+	}
+}
+
+func (s *OpenAPITestSuite) Test_ServesDocumentAndUI() {
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("application/json", w.Header().Get("Content-Type"))
+	s.JSONEq(string(docs.OpenAPI), w.Body.String())
+
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/docs", nil))
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "/api/openapi.json")
+}
+
+func (s *OpenAPITestSuite) checkStruct(name string, typ reflect.Type, sch *schema) {
+	var fields, required []string
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := strings.Split(field.Tag.Get("json"), ",")
+		if tag[0] == "-" || !field.IsExported() {
+			continue
+		}
+		fields = append(fields, tag[0])
+		if len(tag) == 1 || tag[1] != "omitempty" {
+			required = append(required, tag[0])
+		}
+		prop, ok := sch.Properties[tag[0]]
+		if s.True(ok, "%s.%s is not documented", name, tag[0]) {
+			s.checkType(name+"."+tag[0], field.Type, prop)
+		}
+	}
+	s.ElementsMatch(fields, keys(sch.Properties), "properties of %s", name)
+	s.ElementsMatch(required, sch.Required, "required properties of %s", name)
+}
+
+func (s *OpenAPITestSuite) checkType(name string, typ reflect.Type, sch *schema) {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if sch.Ref != "" {
+		target := strings.TrimPrefix(sch.Ref, "#/components/schemas/")
+		s.Equal(documentedTypes[target], typ, "%s refers to %s", name, target)
+		return
+	}
+	switch typ.Kind() {
+	case reflect.String:
+		s.Equal("string", sch.Type, name)
+	case reflect.Bool:
+		s.Equal("boolean", sch.Type, name)
+	case reflect.Int, reflect.Int64:
+		s.Equal("integer", sch.Type, name)
+	case reflect.Struct:
+		if typ.String() == "time.Time" {
+			s.Equal("string", sch.Type, name)
+			return
+		}
+		s.Fail("inline object schema", "%s should reference a component schema", name)
+	case reflect.Slice:
+		if s.Equal("array", sch.Type, name) && s.NotNil(sch.Items, name) {
+			s.checkType(name+"[]", typ.Elem(), sch.Items)
+		}
+	case reflect.Map:
+		if s.Equal("object", sch.Type, name) && s.NotNil(sch.AdditionalProperties, name) {
+			s.checkType(name+"{}", typ.Elem(), sch.AdditionalProperties)
+		}
+	default:
+		s.Fail("unsupported type", "%s has unsupported kind %s", name, typ.Kind())
+	}
+}
+
+func keys(m map[string]*schema) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
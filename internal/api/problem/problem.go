@@ -0,0 +1,95 @@
+package problem
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"catalog-service/internal/constants"
+	"catalog-service/internal/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+const ContentType = "application/problem+json"
+
+// Render answers the request with errs. Clients that accept
+// application/problem+json at least as much as application/json get an RFC
+// 7807 problem; others get {"success": false, "errors": [...]}.
+//
+// The status is the catalogued status of the first error's code, so a code is
+// always answered with the same status; status is only used for codes the
+// catalog does not list.
+func Render(c *gin.Context, status int, errs []dto.ErrorObj) {
+	status = Status(status, errs)
+	if !prefersProblem(c.GetHeader("Accept")) {
+		c.JSON(status, dto.ErrorResponse{Errors: errs})
+		return
+	}
+	c.Header("Content-Type", ContentType)
+	c.JSON(status, New(status, c.Request.URL.Path, errs))
+}
+
+// Abort renders errs and stops the remaining handlers.
+func Abort(c *gin.Context, status int, errs []dto.ErrorObj) {
+	c.Abort()
+	Render(c, status, errs)
+}
+
+// Status returns the catalogued status of the first error in errs, or status
+// when errs is empty or its code is not catalogued.
+func Status(status int, errs []dto.ErrorObj) int {
+	if len(errs) == 0 {
+		return status
+	}
+	if def, ok := constants.LookupError(errs[0].Code); ok {
+		return def.Status
+	}
+	return status
+}
+
+// New builds the problem for errs, typed by the catalog entry of the first
+// error.
+func New(status int, instance string, errs []dto.ErrorObj) *dto.ProblemDetails {
+	p := &dto.ProblemDetails{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Instance: instance,
+		Errors:   errs,
+	}
+	if len(errs) == 0 {
+		return p
+	}
+	p.Code = errs[0].Code
+	p.Detail = errs[0].Cause
+	if def, ok := constants.LookupError(p.Code); ok {
+		p.Type = def.Type()
+		p.Title = def.Title
+	}
+	return p
+}
+
+func prefersProblem(accept string) bool {
+	problemQ, jsonQ := 0.0, 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(part)
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		switch mediaType {
+		case ContentType:
+			problemQ = max(problemQ, q)
+		case "application/json":
+			jsonQ = max(jsonQ, q)
+		}
+	}
+	return problemQ > 0 && problemQ >= jsonQ
+}
@@ -0,0 +1,118 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"catalog-service/internal/constants"
+	"catalog-service/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProblemSuite struct {
+	suite.Suite
+	router *gin.Engine
+}
+
+func TestProblemSuite(t *testing.T) {
+	suite.Run(t, new(ProblemSuite))
+}
+
+func (suite *ProblemSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+	suite.router.GET("/services/:id", func(c *gin.Context) {
+		Abort(c, http.StatusNotFound, []dto.ErrorObj{{
+			Code:   constants.Error_SERVICE_NOT_FOUND,
+			Entity: "service",
+			Cause:  "service not found",
+		}})
+	})
+}
+
+func (suite *ProblemSuite) get(accept string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/services/svc-1", nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	rec := httptest.NewRecorder()
+	suite.router.ServeHTTP(rec, req)
+	return rec
+}
+
+func (suite *ProblemSuite) Test_TakesStatusFromCatalog() {
+	suite.router.GET("/mismatched", func(c *gin.Context) {
+		Render(c, http.StatusInternalServerError, []dto.ErrorObj{{Code: constants.Error_SERVICE_NOT_FOUND, Entity: "service"}})
+	})
+	suite.router.GET("/uncatalogued", func(c *gin.Context) {
+		Render(c, http.StatusTeapot, []dto.ErrorObj{{Code: "999", Entity: "service"}})
+	})
+
+	for path, want := range map[string]int{"/mismatched": http.StatusNotFound, "/uncatalogued": http.StatusTeapot} {
+		for _, accept := range []string{"application/json", ContentType} {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			req.Header.Set("Accept", accept)
+			rec := httptest.NewRecorder()
+			suite.router.ServeHTTP(rec, req)
+
+			suite.Equal(want, rec.Code, path, accept)
+			if accept == ContentType {
+				var body dto.ProblemDetails
+				suite.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &body))
+				suite.Equal(want, body.Status, path)
+			}
+		}
+	}
+}
+
+func (suite *ProblemSuite) Test_RendersEnvelopeByDefault() {
+	for _, accept := range []string{"", "*/*", "application/json", "application/json, application/problem+json;q=0.5"} {
+		rec := suite.get(accept)
+
+		suite.Equal(http.StatusNotFound, rec.Code, accept)
+		suite.Equal("application/json; charset=utf-8", rec.Header().Get("Content-Type"), accept)
+		suite.JSONEq(`{"success":false,"errors":[{"code":"102","entity":"service","cause":"service not found"}]}`, rec.Body.String(), accept)
+	}
+}
+
+func (suite *ProblemSuite) Test_RendersProblemWhenAccepted() {
+	for _, accept := range []string{"application/problem+json", "application/json;q=0.9, application/problem+json", "application/problem+json, application/json"} {
+		rec := suite.get(accept)
+
+		suite.Equal(http.StatusNotFound, rec.Code, accept)
+		suite.Equal(ContentType, rec.Header().Get("Content-Type"), accept)
+		var body dto.ProblemDetails
+		suite.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &body))
+		suite.Equal(dto.ProblemDetails{
+			Type:     "/api/errors/102",
+			Title:    "Service not found",
+			Status:   http.StatusNotFound,
+			Detail:   "service not found",
+			Instance: "/services/svc-1",
+			Code:     constants.Error_SERVICE_NOT_FOUND,
+			Errors:   []dto.ErrorObj{{Code: "102", Entity: "service", Cause: "service not found"}},
+		}, body, accept)
+	}
+}
+
+func (suite *ProblemSuite) Test_UnknownCodesHaveBlankType() {
+	p := New(http.StatusTeapot, "/tea", []dto.ErrorObj{{Code: "999", Cause: "short and stout"}})
+
+	suite.Equal("about:blank", p.Type)
+	suite.Equal("I'm a teapot", p.Title)
+	suite.Equal("999", p.Code)
+}
+
+func (suite *ProblemSuite) Test_CatalogCodesAreUnique() {
+	seen := map[string]bool{}
+	for _, d := range constants.ErrorCatalog {
+		suite.False(seen[d.Code], "code %s is listed twice", d.Code)
+		seen[d.Code] = true
+		suite.NotEmpty(http.StatusText(d.Status), d.Code)
+		suite.NotEmpty(d.Title, d.Code)
+	}
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"catalog-service/internal/config"
+	"catalog-service/internal/events"
+	"catalog-service/internal/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type RateLimitTestSuite struct {
+	suite.Suite
+	router *gin.Engine
+}
+
+func TestRateLimitSuite(t *testing.T) {
+	suite.Run(t, new(RateLimitTestSuite))
+}
+
+func (s *RateLimitTestSuite) SetupTest() {
+	s.T().Setenv("RATE_LIMIT_READ_BURST", "1")
+	config.Load()
+	logger.Setup("INFO", "json")
+	s.router = NewRouter(Dependencies{Events: events.NewBroker(nil)})
+}
+
+func (s *RateLimitTestSuite) get(path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return w
+}
+
+func (s *RateLimitTestSuite) Test_LimitsAPIRoutesButNotProbes() {
+	s.Equal(http.StatusOK, s.get("/api/openapi.json").Code)
+	w := s.get("/api/docs")
+	s.Equal(http.StatusTooManyRequests, w.Code, "read routes share a bucket")
+	s.NotEmpty(w.Header().Get("Retry-After"))
+
+	for range 3 {
+		s.Equal(http.StatusOK, s.get("/healthz").Code)
+	}
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"catalog-service/internal/config"
+	"catalog-service/internal/constants"
+	"catalog-service/internal/dto"
+	"catalog-service/internal/events"
+	"catalog-service/internal/logger"
+	mockrepo "catalog-service/test/mocks/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type RequestLimitsTestSuite struct {
+	suite.Suite
+	repo   *mockrepo.ServiceRepository
+	router *gin.Engine
+}
+
+func TestRequestLimitsSuite(t *testing.T) {
+	suite.Run(t, new(RequestLimitsTestSuite))
+}
+
+func (s *RequestLimitsTestSuite) SetupTest() {
+	s.T().Setenv("REQUEST_MAX_BODY_BYTES", "64")
+	config.Load()
+	logger.Setup("INFO", "json")
+	s.repo = new(mockrepo.ServiceRepository)
+	s.router = NewRouter(Dependencies{Services: s.repo, Events: events.NewBroker(nil)})
+}
+
+func (s *RequestLimitsTestSuite) create(contentType, body string) (int, dto.ErrorObj) {
+	req := httptest.NewRequest(http.MethodPost, "/api/services", strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	var resp dto.ServiceDetailResponse
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	s.Require().Len(resp.Errors, 1)
+	return w.Code, resp.Errors[0]
+}
+
+func (s *RequestLimitsTestSuite) Test_RejectsUnknownFields() {
+	code, err := s.create("application/json", `{"name":"a","version":"1.0"}`)
+
+	s.Equal(http.StatusBadRequest, code)
+	s.Equal(constants.Error_MALFORMED_DATA, err.Code)
+	s.Equal(`unknown field "version"`, err.Cause)
+}
+
+func (s *RequestLimitsTestSuite) Test_RejectsWrongTypesAndTrailingData() {
+	code, err := s.create("application/json", `{"name":1}`)
+	s.Equal(http.StatusBadRequest, code)
+	s.Equal(`field "name" cannot be a number`, err.Cause)
+
+	code, err = s.create("application/json", `{"name":"a"} {"name":"b"}`)
+	s.Equal(http.StatusBadRequest, code)
+	s.Equal("request body must hold a single JSON value", err.Cause)
+}
+
+func (s *RequestLimitsTestSuite) Test_EnforcesContentTypeAndSize() {
+	code, err := s.create("text/plain", `{"name":"a"}`)
+	s.Equal(http.StatusUnsupportedMediaType, code)
+	s.Equal(constants.Error_UNSUPPORTED_MEDIA, err.Code)
+
+	code, err = s.create("application/json", `{"name":"`+strings.Repeat("a", 64)+`"}`)
+	s.Equal(http.StatusRequestEntityTooLarge, code)
+	s.Equal(constants.Error_PAYLOAD_TOO_LARGE, err.Code)
+}
@@ -7,14 +7,33 @@ import (
 
 	"catalog-service/internal/api/handler"
 	"catalog-service/internal/config"
+	"catalog-service/internal/events"
+	"catalog-service/internal/gql"
+	"catalog-service/internal/health"
 	"catalog-service/internal/middleware"
+	"catalog-service/internal/ratelimit"
 	"catalog-service/internal/repository"
 	"catalog-service/internal/usecase"
 )
 
 var allowedEnvs = []string{"dev", "test", "uat", "production"}
 
-func NewRouter(repo repository.ServiceRepository) *gin.Engine {
+// Dependencies are the stores and background components the API is built on.
+type Dependencies struct {
+	Services   repository.ServiceRepository
+	Specs      repository.SpecRepository
+	Events     *events.Broker
+	Webhooks   repository.WebhookRepository
+	Deliveries usecase.DeliveryQueue
+	// Readiness decides the /readyz response; without it the API is always
+	// ready.
+	Readiness *health.Readiness
+	// RateLimits holds the rate limit buckets; without it they are kept in
+	// process.
+	RateLimits ratelimit.Store
+}
+
+func NewRouter(deps Dependencies) *gin.Engine {
 	env := config.AppEnv()
 	if !isAllowedEnv(env) {
 		panic("invalid APP_ENV: must be one of dev, test, uat, production")
@@ -24,24 +43,114 @@ func NewRouter(repo repository.ServiceRepository) *gin.Engine {
 	}
 
 	r := gin.Default()
+	if err := r.SetTrustedProxies(config.TrustedProxies()); err != nil {
+		panic("invalid TRUSTED_PROXIES: " + err.Error())
+	}
+	proxies, err := middleware.ParseTrustedProxies(config.TrustedProxies())
+	if err != nil {
+		panic("invalid TRUSTED_PROXIES: " + err.Error())
+	}
+	r.HandleMethodNotAllowed = true
+	r.NoRoute(handler.NotFound)
+	r.NoMethod(handler.MethodNotAllowed)
+	r.Use(middleware.MetricsMiddleware())
+	r.Use(middleware.TracingMiddleware())
 	r.Use(middleware.PanicRecoveryMiddleware()) // <-- Add panic recovery middleware
 	r.Use(middleware.CorrelationIDMiddleware())
 
-	serviceUsecase := usecase.NewServiceUsecase(repo)
-	serviceHandler := handler.NewServiceHandler(serviceUsecase)
+	serviceUsecase := usecase.NewServiceUsecase(deps.Services, deps.Specs)
+	serviceHandler := handler.NewServiceHandler(serviceUsecase, handler.CacheControl{
+		Detail: config.ServiceCacheControl(),
+		Search: config.ServiceSearchCacheControl(),
+	})
+	specUsecase := usecase.NewSpecUsecase(deps.Services, deps.Specs)
+	specHandler := handler.NewSpecHandler(specUsecase)
+	docsHandler := handler.NewDocsHandler()
+	readiness := deps.Readiness
+	if readiness == nil {
+		readiness = health.NewReadiness(health.Options{})
+	}
+	healthHandler := handler.NewHealthHandler(readiness)
+	eventHandler := handler.NewEventHandler(deps.Events, config.EventHeartbeat())
+	webhookHandler := handler.NewWebhookHandler(usecase.NewWebhookUsecase(deps.Webhooks, deps.Deliveries))
+	schema, err := gql.NewSchema(serviceUsecase)
+	if err != nil {
+		panic("invalid GraphQL schema: " + err.Error())
+	}
+	graphQLHandler := handler.NewGraphQLHandler(gql.NewExecutor(schema, gql.Limits{
+		MaxDepth:      config.GraphQLMaxDepth(),
+		MaxComplexity: config.GraphQLMaxComplexity(),
+	}))
+
+	rateLimits := deps.RateLimits
+	if rateLimits == nil {
+		rateLimits = ratelimit.NewMemoryStore(config.RateLimitMaxKeys())
+	}
+	read, write, export := rateLimit(rateLimits, "read", proxies), rateLimit(rateLimits, "write", proxies), rateLimit(rateLimits, "export", proxies)
+
+	r.GET("/healthz", healthHandler.Live)
+	r.GET("/readyz", healthHandler.Ready)
 
 	api := r.Group("/api")
+	api.Use(requestLimits())
 	{
-		api.GET("/services", serviceHandler.Search)
-		api.GET("/services/:id", serviceHandler.GetByID)
-		api.POST("/services", serviceHandler.Create)
-		api.DELETE("/services/:id", serviceHandler.Delete)
-		api.PUT("/services/:id", serviceHandler.Update)
+		api.GET("/services", read, serviceHandler.Search)
+		api.GET("/services/export", export, serviceHandler.Export)
+		api.GET("/services/events", read, eventHandler.Stream)
+		api.GET("/services/:id", read, serviceHandler.GetByID)
+		api.POST("/services", write, serviceHandler.Create)
+		api.POST("/services/import/openapi", write, specHandler.ImportOpenAPI)
+		api.DELETE("/services/:id", write, serviceHandler.Delete)
+		api.PUT("/services/:id", write, serviceHandler.Update)
+		api.PUT("/services/:id/versions/:version/spec", write, specHandler.PutSpec)
+		api.GET("/services/:id/versions/:version/spec", read, specHandler.GetSpec)
+		api.GET("/services/:id/versions/:version/compatibility", read, specHandler.Compatibility)
+		api.POST("/webhooks", write, webhookHandler.Create)
+		api.GET("/webhooks", read, webhookHandler.List)
+		api.GET("/webhooks/:id", read, webhookHandler.GetByID)
+		api.PUT("/webhooks/:id", write, webhookHandler.Update)
+		api.DELETE("/webhooks/:id", write, webhookHandler.Delete)
+		api.GET("/webhooks/:id/deliveries", read, webhookHandler.Deliveries)
+		api.POST("/webhooks/:id/deliveries/:delivery_id/redeliver", write, webhookHandler.Redeliver)
+		api.GET("/openapi.json", read, docsHandler.OpenAPI)
+		api.GET("/docs", read, docsHandler.UI)
+		api.GET("/errors", read, docsHandler.Errors)
+		api.GET("/errors/:code", read, docsHandler.Error)
+		api.GET("/graphql", read, graphQLHandler.Query)
+		// POST can run mutations.
+		api.POST("/graphql", write, graphQLHandler.Execute)
 	}
 
 	return r
 }
 
+// requestLimits accepts JSON bodies up to REQUEST_MAX_BODY_BYTES and bounds
+// requests by REQUEST_TIMEOUT_MS. Specification uploads accept any media type
+// and are bounded separately; streams have no timeout.
+func requestLimits() gin.HandlerFunc {
+	spec := middleware.RouteLimits{
+		MaxBodyBytes: config.SpecMaxBodyBytes(),
+		Timeout:      config.SpecRequestTimeout(),
+	}
+	return middleware.RequestLimitsMiddleware(middleware.RouteLimits{
+		MaxBodyBytes: config.RequestMaxBodyBytes(),
+		Timeout:      config.RequestTimeout(),
+		ContentTypes: []string{"application/json"},
+	}, map[string]middleware.RouteLimits{
+		"PUT /api/services/:id/versions/:version/spec": spec,
+		"POST /api/services/import/openapi":            spec,
+		"GET /api/services/export":                     {},
+		"GET /api/services/events":                     {},
+	})
+}
+
+func rateLimit(store ratelimit.Store, class string, proxies middleware.TrustedProxies) gin.HandlerFunc {
+	return middleware.RateLimitMiddleware(store, class, ratelimit.Limit{
+		PerMinute: config.RateLimitPerMinute(class),
+		Burst:     config.RateLimitBurst(class),
+	}, proxies)
+}
+
 func isAllowedEnv(env string) bool {
 	env = strings.ToLower(env)
 	for _, e := range allowedEnvs {
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"catalog-service/internal/config"
+	"catalog-service/internal/constants"
+	"catalog-service/internal/dto"
+	"catalog-service/internal/events"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/repository"
+	mockrepo "catalog-service/test/mocks/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServiceErrorsTestSuite struct {
+	suite.Suite
+	repo   *mockrepo.ServiceRepository
+	router *gin.Engine
+}
+
+func TestServiceErrorsSuite(t *testing.T) {
+	suite.Run(t, new(ServiceErrorsTestSuite))
+}
+
+func (s *ServiceErrorsTestSuite) SetupTest() {
+	config.Load()
+	logger.Setup("INFO", "json")
+	s.repo = new(mockrepo.ServiceRepository)
+	s.router = NewRouter(Dependencies{Services: s.repo, Events: events.NewBroker(nil)})
+}
+
+func (s *ServiceErrorsTestSuite) do(method, path string) (int, *dto.ServiceDetailResponse) {
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	var body dto.ServiceDetailResponse
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &body))
+	return w.Code, &body
+}
+
+func (s *ServiceErrorsTestSuite) Test_UnavailableStorageAnswers503() {
+	s.repo.On("FindByID", mock.Anything, "svc-1").Return(nil, fmt.Errorf("%w: 503 Service Unavailable after 4 attempt(s)", repository.ErrUnavailable))
+	s.repo.On("Delete", mock.Anything, "svc-1", mock.Anything).Return(fmt.Errorf("%w: circuit breaker is open", repository.ErrUnavailable))
+
+	for _, method := range []string{http.MethodGet, http.MethodDelete} {
+		code, body := s.do(method, "/api/services/svc-1")
+		s.Equal(http.StatusServiceUnavailable, code, method)
+		s.Require().Len(body.Errors, 1)
+		s.Equal(constants.Error_SERVICE_UNAVAILABLE, body.Errors[0].Code)
+	}
+}
+
+func (s *ServiceErrorsTestSuite) Test_OnlyMissingServicesAnswer404() {
+	s.repo.On("FindByID", mock.Anything, "missing").Return(nil, fmt.Errorf("%w: missing", repository.ErrNotFound))
+	s.repo.On("FindByID", mock.Anything, "broken").Return(nil, assert.AnError)
+
+	code, _ := s.do(http.MethodGet, "/api/services/missing")
+	s.Equal(http.StatusNotFound, code)
+
+	code, body := s.do(http.MethodGet, "/api/services/broken")
+	s.Equal(http.StatusInternalServerError, code)
+	s.Equal(constants.Error_GENERIC_SERVICE_ERROR, body.Errors[0].Code)
+}
@@ -1,11 +1,18 @@
 package validator
 
 import (
+	"maps"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"catalog-service/internal/constants"
 	"catalog-service/internal/dto"
+	"catalog-service/internal/ingest"
+	"catalog-service/internal/models"
 )
 
 func ValidateSearchRequest(pageStr, limitStr string) (page int, limit int, errs []dto.ErrorObj, httpCode int) {
@@ -62,6 +69,40 @@ func ValidateID(id string) ([]dto.ErrorObj, int) {
 	return nil, http.StatusOK
 }
 
+// ValidateServiceFilter reads the filter of the GraphQL search from query
+// parameters: name, version, operation, updated_after and updated_before.
+func ValidateServiceFilter(values url.Values) (models.ServiceFilter, []dto.ErrorObj, int) {
+	filter := models.ServiceFilter{
+		Name:      strings.TrimSpace(values.Get("name")),
+		Version:   strings.TrimSpace(values.Get("version")),
+		Operation: strings.TrimSpace(values.Get("operation")),
+	}
+	var errs []dto.ErrorObj
+	for _, param := range []struct {
+		key  string
+		dest *time.Time
+	}{{"updated_after", &filter.UpdatedAfter}, {"updated_before", &filter.UpdatedBefore}} {
+		value := strings.TrimSpace(values.Get(param.key))
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			errs = append(errs, dto.ErrorObj{
+				Code:   constants.Error_MALFORMED_DATA,
+				Entity: param.key,
+				Cause:  "must be an RFC 3339 timestamp",
+			})
+			continue
+		}
+		*param.dest = t
+	}
+	if len(errs) > 0 {
+		return models.ServiceFilter{}, errs, http.StatusBadRequest
+	}
+	return filter, nil, http.StatusOK
+}
+
 func ValidateCreateRequest(req *dto.ServiceDTO) ([]dto.ErrorObj, int) {
 	var errs []dto.ErrorObj
 	if req.Name == "" {
@@ -87,6 +128,8 @@ func ValidateCreateRequest(req *dto.ServiceDTO) ([]dto.ErrorObj, int) {
 			})
 		}
 	}
+	errs = append(errs, validateLabels(req.Labels)...)
+	errs = append(errs, validateSpecs(req)...)
 	if len(errs) > 0 {
 		return errs, http.StatusBadRequest
 	}
@@ -118,8 +161,51 @@ func ValidateUpdateRequest(req *dto.ServiceDTO) ([]dto.ErrorObj, int) {
 			})
 		}
 	}
+	errs = append(errs, validateLabels(req.Labels)...)
+	errs = append(errs, validateSpecs(req)...)
 	if len(errs) > 0 {
 		return errs, http.StatusBadRequest
 	}
 	return nil, http.StatusOK
 }
+
+// validateSpecs requires each spec to belong to a version in the request.
+func validateSpecs(req *dto.ServiceDTO) []dto.ErrorObj {
+	var errs []dto.ErrorObj
+	for _, version := range slices.Sorted(maps.Keys(req.Specs)) {
+		if !slices.ContainsFunc(req.Versions, func(v models.Version) bool { return v.VersionNumber == version }) {
+			errs = append(errs, dto.ErrorObj{
+				Code:   constants.Error_MALFORMED_DATA,
+				Entity: "specs",
+				Cause:  "spec for version " + version + " has no matching version in versions",
+			})
+		}
+	}
+	return errs
+}
+
+func validateLabels(labels []string) []dto.ErrorObj {
+	var errs []dto.ErrorObj
+	for i, label := range labels {
+		if strings.TrimSpace(label) == "" {
+			errs = append(errs, dto.ErrorObj{
+				Code:   constants.Error_MALFORMED_DATA,
+				Entity: "labels",
+				Cause:  "label at index " + strconv.Itoa(i) + " is empty",
+			})
+		}
+	}
+	return errs
+}
+
+func ValidateExportRequest(formatStr string) (ingest.Format, []dto.ErrorObj, int) {
+	format := ingest.Format(strings.ToLower(formatStr))
+	if ingest.ContentType(format) == "" {
+		return "", []dto.ErrorObj{{
+			Code:   constants.Error_MALFORMED_DATA,
+			Entity: "format",
+			Cause:  "format must be one of jsonl, json, csv, yaml",
+		}}, http.StatusBadRequest
+	}
+	return format, nil, http.StatusOK
+}
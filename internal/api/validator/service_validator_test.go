@@ -152,6 +152,18 @@ func (suite *ServiceValidatorSuite) Test_ValidateUpdateRequest_MissingVersionNum
 	suite.Contains(errs[0].Cause, "version_number is required")
 }
 
+func (suite *ServiceValidatorSuite) Test_ValidateUpdateRequest_SpecWithoutVersion() {
+	req := &dto.ServiceDTO{
+		Versions: []models.Version{{VersionNumber: "2.0"}},
+		Specs:    map[string]string{"2.0": "openapi: 3.0.3", "3.0": "openapi: 3.0.3"},
+	}
+	errs, code := ValidateUpdateRequest(req)
+	suite.Len(errs, 1)
+	suite.Equal(400, code)
+	suite.Equal("specs", errs[0].Entity)
+	suite.Contains(errs[0].Cause, "version 3.0")
+}
+
 func (suite *ServiceValidatorSuite) Test_ValidateUpdateRequest_EmptyDescription() {
 	req := &dto.ServiceDTO{
 		Description: "",
@@ -0,0 +1,67 @@
+package validator
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"catalog-service/internal/constants"
+	"catalog-service/internal/dto"
+	"catalog-service/internal/models"
+)
+
+const minSecretLength = 16
+
+func ValidateWebhookRequest(req *dto.WebhookDTO) ([]dto.ErrorObj, int) {
+	var errs []dto.ErrorObj
+	if u, err := url.Parse(req.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, dto.ErrorObj{
+			Code:   constants.Error_MALFORMED_DATA,
+			Entity: "url",
+			Cause:  "url must be an absolute http or https URL",
+		})
+	}
+	if req.Secret != "" && len(req.Secret) < minSecretLength {
+		errs = append(errs, dto.ErrorObj{
+			Code:   constants.Error_MALFORMED_DATA,
+			Entity: "secret",
+			Cause:  "secret must be at least " + strconv.Itoa(minSecretLength) + " characters",
+		})
+	}
+	for i, t := range req.EventTypes {
+		if !slices.Contains(models.EventTypes, models.EventType(t)) {
+			errs = append(errs, dto.ErrorObj{
+				Code:   constants.Error_MALFORMED_DATA,
+				Entity: "event_types",
+				Cause:  "unknown event type at index " + strconv.Itoa(i) + ": " + t,
+			})
+		}
+	}
+	for i, id := range req.ServiceIDs {
+		if strings.TrimSpace(id) == "" {
+			errs = append(errs, dto.ErrorObj{
+				Code:   constants.Error_MALFORMED_DATA,
+				Entity: "service_ids",
+				Cause:  "service id at index " + strconv.Itoa(i) + " is empty",
+			})
+		}
+	}
+	errs = append(errs, validateLabels(req.Labels)...)
+	if len(errs) > 0 {
+		return errs, http.StatusBadRequest
+	}
+	return nil, http.StatusOK
+}
+
+func ValidateWebhookFilter(eventType string) ([]dto.ErrorObj, int) {
+	if eventType != "" && !slices.Contains(models.EventTypes, models.EventType(eventType)) {
+		return []dto.ErrorObj{{
+			Code:   constants.Error_MALFORMED_DATA,
+			Entity: "event_type",
+			Cause:  "unknown event type: " + eventType,
+		}}, http.StatusBadRequest
+	}
+	return nil, http.StatusOK
+}
@@ -0,0 +1,61 @@
+package validator
+
+import (
+	"catalog-service/internal/dto"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookValidatorSuite struct {
+	suite.Suite
+}
+
+func TestWebhookValidatorSuite(t *testing.T) {
+	suite.Run(t, new(WebhookValidatorSuite))
+}
+
+func (suite *WebhookValidatorSuite) Test_ValidateWebhookRequest_Valid() {
+	errs, code := ValidateWebhookRequest(&dto.WebhookDTO{
+		URL:        "https://example.com/hooks/catalog",
+		Secret:     "0123456789abcdef",
+		EventTypes: []string{"service.created", "version.deprecated"},
+		ServiceIDs: []string{"svc-1"},
+		Labels:     []string{"payments"},
+	})
+	suite.Empty(errs)
+	suite.Equal(http.StatusOK, code)
+}
+
+func (suite *WebhookValidatorSuite) Test_ValidateWebhookRequest_Invalid() {
+	errs, code := ValidateWebhookRequest(&dto.WebhookDTO{
+		URL:        "ftp://example.com",
+		Secret:     "short",
+		EventTypes: []string{"service.renamed"},
+		ServiceIDs: []string{" "},
+	})
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Require().Len(errs, 4)
+	suite.Equal("url", errs[0].Entity)
+	suite.Equal("secret", errs[1].Entity)
+	suite.Equal("event_types", errs[2].Entity)
+	suite.Equal("service_ids", errs[3].Entity)
+}
+
+func (suite *WebhookValidatorSuite) Test_ValidateWebhookRequest_RelativeURL() {
+	errs, code := ValidateWebhookRequest(&dto.WebhookDTO{URL: "/hooks"})
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Len(errs, 1)
+}
+
+func (suite *WebhookValidatorSuite) Test_ValidateWebhookFilter() {
+	errs, code := ValidateWebhookFilter("")
+	suite.Empty(errs)
+	suite.Equal(http.StatusOK, code)
+
+	errs, code = ValidateWebhookFilter("nope")
+	suite.Len(errs, 1)
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Equal("event_type", errs[0].Entity)
+}
@@ -2,17 +2,25 @@ package appcontext
 
 import (
 	"context"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 type key string
 
 const (
 	CorrelationIDKey = key("CorrelationID")
+	TraceIDKey       = key("TraceID")
+	SpanIDKey        = key("SpanID")
 )
 
 func LogFields(ctx context.Context) map[string]interface{} {
 	fields := make(map[string]interface{})
 	fields[string(CorrelationIDKey)] = Value(ctx, CorrelationIDKey)
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		fields[string(TraceIDKey)] = span.TraceID().String()
+		fields[string(SpanIDKey)] = span.SpanID().String()
+	}
 	return fields
 }
 
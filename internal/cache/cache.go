@@ -0,0 +1,19 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Store keeps serialized values for a limited time. LRU keeps them in process;
+// a distributed backend such as Redis implements Store to share cached values
+// and invalidations between instances. Errors are reported to the caller,
+// which treats them as misses.
+type Store interface {
+	// Get reports whether key has a value that has not expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value under key. A ttl of zero keeps it until it is deleted
+	// or evicted.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
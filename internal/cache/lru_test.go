@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LRUTestSuite struct {
+	suite.Suite
+	ctx context.Context
+	now time.Time
+	lru *LRU
+}
+
+func TestLRUSuite(t *testing.T) {
+	suite.Run(t, new(LRUTestSuite))
+}
+
+func (suite *LRUTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.now = time.Now()
+	suite.lru = NewLRU(2)
+	suite.lru.now = func() time.Time { return suite.now }
+}
+
+func (suite *LRUTestSuite) get(key string) (string, bool) {
+	value, ok, err := suite.lru.Get(suite.ctx, key)
+	suite.Require().NoError(err)
+	return string(value), ok
+}
+
+func (suite *LRUTestSuite) Test_EvictsLeastRecentlyUsed() {
+	suite.Require().NoError(suite.lru.Set(suite.ctx, "a", []byte("1"), 0))
+	suite.Require().NoError(suite.lru.Set(suite.ctx, "b", []byte("2"), 0))
+	_, _ = suite.get("a")
+	suite.Require().NoError(suite.lru.Set(suite.ctx, "c", []byte("3"), 0))
+
+	_, ok := suite.get("b")
+	suite.False(ok)
+	value, ok := suite.get("a")
+	suite.True(ok)
+	suite.Equal("1", value)
+	suite.Equal(2, suite.lru.Len())
+}
+
+func (suite *LRUTestSuite) Test_ExpiresEntriesAfterTTL() {
+	suite.Require().NoError(suite.lru.Set(suite.ctx, "a", []byte("1"), time.Second))
+	suite.Require().NoError(suite.lru.Set(suite.ctx, "b", []byte("2"), 0))
+
+	suite.now = suite.now.Add(time.Second)
+
+	_, ok := suite.get("a")
+	suite.False(ok)
+	_, ok = suite.get("b")
+	suite.True(ok, "entries without ttl do not expire")
+	suite.Equal(1, suite.lru.Len())
+}
+
+func (suite *LRUTestSuite) Test_SetReplacesAndDeleteRemoves() {
+	suite.Require().NoError(suite.lru.Set(suite.ctx, "a", []byte("1"), 0))
+	suite.Require().NoError(suite.lru.Set(suite.ctx, "a", []byte("2"), 0))
+	value, _ := suite.get("a")
+	suite.Equal("2", value)
+
+	suite.Require().NoError(suite.lru.Delete(suite.ctx, "a", "missing"))
+	_, ok := suite.get("a")
+	suite.False(ok)
+	suite.Equal(0, suite.lru.Len())
+}
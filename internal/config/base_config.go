@@ -77,6 +77,16 @@ func (b BaseConfig) GetOptionalIntValue(key string, defaultValue int) int {
 	return config[key].(int)
 }
 
+func (b BaseConfig) Lookup(key string) (string, bool) {
+	if value, ok := config[key]; ok {
+		return fmt.Sprint(value), true
+	}
+	if !viper.IsSet(key) {
+		return "", false
+	}
+	return viper.GetString(key), true
+}
+
 func checkKey(key string) {
 	if !viper.IsSet(key) {
 		panic(fmt.Errorf("%s key is not set", key))
@@ -1,6 +1,9 @@
 package config
 
 import (
+	"strings"
+	"time"
+
 	"github.com/spf13/viper"
 )
 
@@ -30,6 +33,52 @@ func Port() int {
 	return cfg.GetOptionalIntValue("PORT", 4000)
 }
 
+func GRPCPort() int {
+	return cfg.GetOptionalIntValue("GRPC_PORT", 4001)
+}
+
+func MetricsPort() int {
+	return cfg.GetOptionalIntValue("METRICS_PORT", 9090)
+}
+
+func TracingExporter() string {
+	return cfg.GetOptionalValue("TRACING_EXPORTER", "none")
+}
+
+func TracingOTLPEndpoint() string {
+	return cfg.GetOptionalValue("TRACING_OTLP_ENDPOINT", "localhost:4318")
+}
+
+func TracingOTLPInsecure() bool {
+	return cfg.GetOptionalValue("TRACING_OTLP_INSECURE", "true") == "true"
+}
+
+func TracingSamplePercent() int {
+	return cfg.GetOptionalIntValue("TRACING_SAMPLE_PERCENT", 100)
+}
+
+func ShutdownTimeout() time.Duration {
+	return time.Duration(cfg.GetOptionalIntValue("SHUTDOWN_TIMEOUT_MS", 5000)) * time.Millisecond
+}
+
+// ShutdownDrainDelay is how long /readyz reports draining before the servers
+// stop accepting connections.
+func ShutdownDrainDelay() time.Duration {
+	return time.Duration(cfg.GetOptionalIntValue("SHUTDOWN_DRAIN_DELAY_MS", 0)) * time.Millisecond
+}
+
+func HealthCacheTTL() time.Duration {
+	return time.Duration(cfg.GetOptionalIntValue("HEALTH_CACHE_TTL_MS", 5000)) * time.Millisecond
+}
+
+func HealthCheckTimeout() time.Duration {
+	return time.Duration(cfg.GetOptionalIntValue("HEALTH_CHECK_TIMEOUT_MS", 2000)) * time.Millisecond
+}
+
+func HealthMinMigrationVersion() int {
+	return cfg.GetOptionalIntValue("HEALTH_MIN_MIGRATION_VERSION", 1)
+}
+
 func AppName() string {
 	return cfg.GetOptionalValue("APP_NAME", "catalog-service")
 }
@@ -49,3 +98,152 @@ func LogFormat() string {
 func OpenSearch() *OpenSearchConfig {
 	return cfg.openSearchConfig
 }
+
+func SpecRejectBreakingChanges() bool {
+	return cfg.GetOptionalValue("SPEC_REJECT_BREAKING_CHANGES", "false") == "true"
+}
+
+func EventLogSize() int {
+	return cfg.GetOptionalIntValue("EVENT_LOG_SIZE", 10000)
+}
+
+func EventHeartbeat() time.Duration {
+	return time.Duration(cfg.GetOptionalIntValue("EVENT_HEARTBEAT_MS", 15000)) * time.Millisecond
+}
+
+func WebhookWorkers() int {
+	return cfg.GetOptionalIntValue("WEBHOOK_WORKERS", 4)
+}
+
+func WebhookMaxAttempts() int {
+	return cfg.GetOptionalIntValue("WEBHOOK_MAX_ATTEMPTS", 6)
+}
+
+func WebhookInitialBackoff() time.Duration {
+	return time.Duration(cfg.GetOptionalIntValue("WEBHOOK_INITIAL_BACKOFF_MS", 1000)) * time.Millisecond
+}
+
+func WebhookMaxBackoff() time.Duration {
+	return time.Duration(cfg.GetOptionalIntValue("WEBHOOK_MAX_BACKOFF_MS", 300000)) * time.Millisecond
+}
+
+func WebhookTimeout() time.Duration {
+	return time.Duration(cfg.GetOptionalIntValue("WEBHOOK_TIMEOUT_MS", 10000)) * time.Millisecond
+}
+
+func OutboxPollInterval() time.Duration {
+	return time.Duration(cfg.GetOptionalIntValue("OUTBOX_POLL_INTERVAL_MS", 500)) * time.Millisecond
+}
+
+func OutboxBatchSize() int {
+	return cfg.GetOptionalIntValue("OUTBOX_BATCH_SIZE", 100)
+}
+
+// OutboxSink is "file", "http" or empty. Messages are always published to the
+// event stream and webhooks; the sink receives them too.
+func OutboxSink() string {
+	return cfg.GetOptionalValue("OUTBOX_SINK", "")
+}
+
+func OutboxFilePath() string {
+	return cfg.GetOptionalValue("OUTBOX_FILE_PATH", "outbox.jsonl")
+}
+
+func OutboxHTTPURL() string {
+	return cfg.GetOptionalValue("OUTBOX_HTTP_URL", "")
+}
+
+func OutboxHTTPTimeout() time.Duration {
+	return time.Duration(cfg.GetOptionalIntValue("OUTBOX_HTTP_TIMEOUT_MS", 10000)) * time.Millisecond
+}
+
+func GraphQLMaxDepth() int {
+	return cfg.GetOptionalIntValue("GRAPHQL_MAX_DEPTH", 8)
+}
+
+func GraphQLMaxComplexity() int {
+	return cfg.GetOptionalIntValue("GRAPHQL_MAX_COMPLEXITY", 1000)
+}
+
+// ServiceCacheSize is the number of entries kept by the in-process service
+// cache; 0 disables the cache.
+func ServiceCacheSize() int {
+	return cfg.GetOptionalIntValue("SERVICE_CACHE_SIZE", 10000)
+}
+
+func ServiceCacheFindByIDTTL() time.Duration {
+	return time.Duration(cfg.GetOptionalIntValue("SERVICE_CACHE_FIND_BY_ID_TTL_MS", 30000)) * time.Millisecond
+}
+
+func ServiceCacheSearchTTL() time.Duration {
+	return time.Duration(cfg.GetOptionalIntValue("SERVICE_CACHE_SEARCH_TTL_MS", 5000)) * time.Millisecond
+}
+
+// ServiceCacheControl is the Cache-Control header of GET /api/services/:id.
+func ServiceCacheControl() string {
+	return cfg.GetOptionalValue("HTTP_CACHE_CONTROL_SERVICE", "public, max-age=0, must-revalidate")
+}
+
+// ServiceSearchCacheControl is the Cache-Control header of GET /api/services.
+func ServiceSearchCacheControl() string {
+	return cfg.GetOptionalValue("HTTP_CACHE_CONTROL_SEARCH", "public, max-age=0, must-revalidate")
+}
+
+var rateLimitDefaults = map[string][2]int{
+	"read":   {600, 100},
+	"write":  {60, 20},
+	"export": {5, 2},
+}
+
+// RateLimitPerMinute returns RATE_LIMIT_<CLASS>_PER_MINUTE, the number of
+// requests a consumer may make a minute in a class of routes; 0 disables the
+// limit.
+func RateLimitPerMinute(class string) int {
+	return cfg.GetOptionalIntValue("RATE_LIMIT_"+strings.ToUpper(class)+"_PER_MINUTE", rateLimitDefaults[class][0])
+}
+
+// RateLimitBurst returns RATE_LIMIT_<CLASS>_BURST, the number of requests a
+// consumer may make at once in a class of routes.
+func RateLimitBurst(class string) int {
+	return cfg.GetOptionalIntValue("RATE_LIMIT_"+strings.ToUpper(class)+"_BURST", rateLimitDefaults[class][1])
+}
+
+// RateLimitMaxKeys returns RATE_LIMIT_MAX_KEYS, the number of buckets kept in
+// process; the least recently used bucket is dropped beyond it.
+func RateLimitMaxKeys() int {
+	return cfg.GetOptionalIntValue("RATE_LIMIT_MAX_KEYS", 100000)
+}
+
+// TrustedProxies returns TRUSTED_PROXIES, the comma-separated addresses and
+// CIDRs of the proxies, such as Kong, whose forwarding and consumer headers
+// are trusted. None are trusted by default.
+func TrustedProxies() []string {
+	var proxies []string
+	for _, p := range strings.Split(cfg.GetOptionalValue("TRUSTED_PROXIES", ""), ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			proxies = append(proxies, p)
+		}
+	}
+	return proxies
+}
+
+// RequestMaxBodyBytes caps JSON request bodies; 0 disables the cap.
+func RequestMaxBodyBytes() int64 {
+	return int64(cfg.GetOptionalIntValue("REQUEST_MAX_BODY_BYTES", 1<<20))
+}
+
+// RequestTimeout bounds API requests; 0 disables the timeout.
+func RequestTimeout() time.Duration {
+	return time.Duration(cfg.GetOptionalIntValue("REQUEST_TIMEOUT_MS", 10000)) * time.Millisecond
+}
+
+// SpecMaxBodyBytes caps specification uploads.
+func SpecMaxBodyBytes() int64 {
+	return int64(cfg.GetOptionalIntValue("SPEC_MAX_BODY_BYTES", 10<<20))
+}
+
+// SpecRequestTimeout bounds specification uploads, which are parsed and
+// compared with the stored versions.
+func SpecRequestTimeout() time.Duration {
+	return time.Duration(cfg.GetOptionalIntValue("SPEC_REQUEST_TIMEOUT_MS", 30000)) * time.Millisecond
+}
@@ -13,6 +13,15 @@ type OpenSearchConfig struct {
 	dialTimeout         time.Duration
 	keepAlive           time.Duration
 	tlsHandshakeTimeout time.Duration
+	scrollSize          int
+	scrollKeepAlive     time.Duration
+	maxRetries          int
+	retryInitialBackoff time.Duration
+	retryMaxBackoff     time.Duration
+	requestTimeout      time.Duration
+	breakerFailures     int
+	breakerCooldown     time.Duration
+	cfg                 *AppConfig
 }
 
 func NewOpenSearchConfig(cfg *AppConfig) *OpenSearchConfig {
@@ -24,6 +33,15 @@ func NewOpenSearchConfig(cfg *AppConfig) *OpenSearchConfig {
 		dialTimeout:         time.Duration(cfg.GetOptionalIntValue("OPENSEARCH_DIAL_TIMEOUT_MS", 30000)) * time.Millisecond,
 		keepAlive:           time.Duration(cfg.GetOptionalIntValue("OPENSEARCH_KEEP_ALIVE_MS", 30000)) * time.Millisecond,
 		tlsHandshakeTimeout: time.Duration(cfg.GetOptionalIntValue("OPENSEARCH_TLS_HANDSHAKE_TIMEOUT_MS", 10000)) * time.Millisecond,
+		scrollSize:          cfg.GetOptionalIntValue("OPENSEARCH_SCROLL_SIZE", 500),
+		scrollKeepAlive:     time.Duration(cfg.GetOptionalIntValue("OPENSEARCH_SCROLL_KEEP_ALIVE_MS", 60000)) * time.Millisecond,
+		maxRetries:          cfg.GetOptionalIntValue("OPENSEARCH_MAX_RETRIES", 3),
+		retryInitialBackoff: time.Duration(cfg.GetOptionalIntValue("OPENSEARCH_RETRY_INITIAL_BACKOFF_MS", 100)) * time.Millisecond,
+		retryMaxBackoff:     time.Duration(cfg.GetOptionalIntValue("OPENSEARCH_RETRY_MAX_BACKOFF_MS", 5000)) * time.Millisecond,
+		requestTimeout:      time.Duration(cfg.GetOptionalIntValue("OPENSEARCH_REQUEST_TIMEOUT_MS", 10000)) * time.Millisecond,
+		breakerFailures:     cfg.GetOptionalIntValue("OPENSEARCH_BREAKER_FAILURES", 5),
+		breakerCooldown:     time.Duration(cfg.GetOptionalIntValue("OPENSEARCH_BREAKER_COOLDOWN_MS", 10000)) * time.Millisecond,
+		cfg:                 cfg,
 	}
 }
 
@@ -48,3 +66,32 @@ func (c *OpenSearchConfig) KeepAlive() time.Duration {
 func (c *OpenSearchConfig) TLSHandshakeTimeout() time.Duration {
 	return c.tlsHandshakeTimeout
 }
+func (c *OpenSearchConfig) ScrollSize() int {
+	return c.scrollSize
+}
+func (c *OpenSearchConfig) ScrollKeepAlive() time.Duration {
+	return c.scrollKeepAlive
+}
+func (c *OpenSearchConfig) MaxRetries() int {
+	return c.maxRetries
+}
+func (c *OpenSearchConfig) RetryInitialBackoff() time.Duration {
+	return c.retryInitialBackoff
+}
+func (c *OpenSearchConfig) RetryMaxBackoff() time.Duration {
+	return c.retryMaxBackoff
+}
+
+// RequestTimeout bounds each attempt of an OpenSearch operation, e.g. "search"
+// or "bulk". OPENSEARCH_<OPERATION>_TIMEOUT_MS overrides
+// OPENSEARCH_REQUEST_TIMEOUT_MS for one operation.
+func (c *OpenSearchConfig) RequestTimeout(operation string) time.Duration {
+	key := "OPENSEARCH_" + strings.ToUpper(operation) + "_TIMEOUT_MS"
+	return time.Duration(c.cfg.GetOptionalIntValue(key, int(c.requestTimeout/time.Millisecond))) * time.Millisecond
+}
+func (c *OpenSearchConfig) BreakerFailures() int {
+	return c.breakerFailures
+}
+func (c *OpenSearchConfig) BreakerCooldown() time.Duration {
+	return c.breakerCooldown
+}
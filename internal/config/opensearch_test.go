@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -21,3 +22,13 @@ func TestOpenSearchConfigShouldReturnValidConfig(t *testing.T) {
 
 	assert.EqualValues(t, []string{"localhost:9200", "localhost:9201"}, config.Host())
 }
+
+func TestOpenSearchConfigShouldReturnPerOperationTimeouts(t *testing.T) {
+	t.Setenv("OPENSEARCH_GET_TIMEOUT_MS", "250")
+	c := Load()
+	config := NewOpenSearchConfig(c)
+
+	assert.Equal(t, 250*time.Millisecond, config.RequestTimeout("get"))
+	assert.Equal(t, 60*time.Second, config.RequestTimeout("bulk"))
+	assert.Equal(t, 10*time.Second, config.RequestTimeout("search"))
+}
@@ -1,7 +1,70 @@
 package constants
 
+import "net/http"
+
 const (
 	Error_GENERIC_SERVICE_ERROR = "900"
 	Error_MALFORMED_DATA        = "101"
 	Error_SERVICE_NOT_FOUND     = "102"
+	Error_VERSION_NOT_FOUND     = "103"
+	Error_SPEC_NOT_FOUND        = "104"
+	Error_BREAKING_CHANGE       = "105"
+	Error_SPEC_NOT_COMPARABLE   = "106"
+	Error_WEBHOOK_NOT_FOUND     = "107"
+	Error_DELIVERY_NOT_FOUND    = "108"
+	Error_SERVICE_UNAVAILABLE   = "109"
+	Error_RATE_LIMITED          = "110"
+	Error_PAYLOAD_TOO_LARGE     = "111"
+	Error_UNSUPPORTED_MEDIA     = "112"
+	Error_REQUEST_TIMEOUT       = "113"
+	Error_ROUTE_NOT_FOUND       = "114"
+	Error_METHOD_NOT_ALLOWED    = "115"
 )
+
+// ErrorDocsPath is where the API documents each error code, as
+// ErrorDocsPath + code.
+const ErrorDocsPath = "/api/errors/"
+
+// ErrorDefinition describes an error code. Codes and their statuses are
+// stable; clients may rely on them.
+type ErrorDefinition struct {
+	Code   string
+	Status int
+	Title  string
+}
+
+// Type is the URI reference documenting the error, used as the problem type.
+func (d ErrorDefinition) Type() string {
+	return ErrorDocsPath + d.Code
+}
+
+// ErrorCatalog lists every error code the API answers with.
+var ErrorCatalog = []ErrorDefinition{
+	{Error_MALFORMED_DATA, http.StatusBadRequest, "Malformed request"},
+	{Error_SERVICE_NOT_FOUND, http.StatusNotFound, "Service not found"},
+	{Error_VERSION_NOT_FOUND, http.StatusNotFound, "Version not found"},
+	{Error_SPEC_NOT_FOUND, http.StatusNotFound, "Specification not found"},
+	{Error_BREAKING_CHANGE, http.StatusConflict, "Breaking change"},
+	{Error_SPEC_NOT_COMPARABLE, http.StatusUnprocessableEntity, "Specifications not comparable"},
+	{Error_WEBHOOK_NOT_FOUND, http.StatusNotFound, "Webhook not found"},
+	{Error_DELIVERY_NOT_FOUND, http.StatusNotFound, "Delivery not found"},
+	{Error_SERVICE_UNAVAILABLE, http.StatusServiceUnavailable, "Storage unavailable"},
+	{Error_RATE_LIMITED, http.StatusTooManyRequests, "Rate limit exceeded"},
+	{Error_PAYLOAD_TOO_LARGE, http.StatusRequestEntityTooLarge, "Request body too large"},
+	{Error_UNSUPPORTED_MEDIA, http.StatusUnsupportedMediaType, "Unsupported content type"},
+	{Error_REQUEST_TIMEOUT, http.StatusRequestTimeout, "Request timeout"},
+	{Error_ROUTE_NOT_FOUND, http.StatusNotFound, "Route not found"},
+	{Error_METHOD_NOT_ALLOWED, http.StatusMethodNotAllowed, "Method not allowed"},
+	{Error_GENERIC_SERVICE_ERROR, http.StatusInternalServerError, "Internal error"},
+}
+
+// LookupError returns the definition of code, or false when the catalog does
+// not list it.
+func LookupError(code string) (ErrorDefinition, bool) {
+	for _, d := range ErrorCatalog {
+		if d.Code == code {
+			return d, true
+		}
+	}
+	return ErrorDefinition{}, false
+}
@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+type HealthCheckDTO struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Detail     string `json:"detail,omitempty"`
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+type HealthReport struct {
+	Status    string           `json:"status"`
+	Checks    []HealthCheckDTO `json:"checks,omitempty"`
+	CheckedAt time.Time        `json:"checked_at"`
+}
@@ -6,6 +6,45 @@ type ErrorObj struct {
 	Cause  string `json:"cause"`
 }
 
+// ErrorResponse is the body of every error response; the typed responses
+// below have the same shape when they carry errors.
+type ErrorResponse struct {
+	Success bool       `json:"success"`
+	Errors  []ErrorObj `json:"errors"`
+}
+
+// ProblemDetails is an RFC 7807 problem, sent instead of ErrorResponse to
+// clients that accept application/problem+json. Type, Title and Code describe
+// the first error.
+type ProblemDetails struct {
+	Type     string     `json:"type"`
+	Title    string     `json:"title"`
+	Status   int        `json:"status"`
+	Detail   string     `json:"detail,omitempty"`
+	Instance string     `json:"instance,omitempty"`
+	Code     string     `json:"code"`
+	Errors   []ErrorObj `json:"errors"`
+}
+
+type ErrorDefinitionDTO struct {
+	Code   string `json:"code"`
+	Status int    `json:"status"`
+	Title  string `json:"title"`
+	Type   string `json:"type"`
+}
+
+type ErrorCatalogResponse struct {
+	Success bool                  `json:"success"`
+	Data    []*ErrorDefinitionDTO `json:"data,omitempty"`
+	Errors  []ErrorObj            `json:"errors,omitempty"`
+}
+
+type ErrorDefinitionResponse struct {
+	Success bool                `json:"success"`
+	Data    *ErrorDefinitionDTO `json:"data,omitempty"`
+	Errors  []ErrorObj          `json:"errors,omitempty"`
+}
+
 type ServiceListResponse struct {
 	Success bool             `json:"success"`
 	Data    *ServiceListData `json:"data,omitempty"`
@@ -7,8 +7,13 @@ type ServiceDTO struct {
 	Name        string           `json:"name"`
 	Description string           `json:"description"`
 	Versions    []models.Version `json:"versions"`
-	CreatedAt   string           `json:"created_at"`
-	UpdatedAt   string           `json:"updated_at"`
+	Operations  []string         `json:"operations,omitempty"`
+	Labels      []string         `json:"labels,omitempty"`
+	// Specs are OpenAPI documents for versions in a create or update request,
+	// keyed by version number. They are never returned.
+	Specs     map[string]string `json:"specs,omitempty"`
+	CreatedAt string            `json:"created_at"`
+	UpdatedAt string            `json:"updated_at"`
 }
 
 type ServiceListData struct {
@@ -0,0 +1,33 @@
+package dto
+
+import "catalog-service/internal/spec"
+
+type SpecDTO struct {
+	ServiceID   string   `json:"service_id"`
+	Version     string   `json:"version"`
+	Format      string   `json:"format"`
+	ContentType string   `json:"content_type"`
+	Operations  []string `json:"operations"`
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at"`
+}
+
+type SpecDetailResponse struct {
+	Success bool       `json:"success"`
+	Data    *SpecDTO   `json:"data,omitempty"`
+	Errors  []ErrorObj `json:"errors,omitempty"`
+}
+
+type CompatibilityDTO struct {
+	ServiceID       string                `json:"service_id"`
+	Version         string                `json:"version"`
+	PreviousVersion string                `json:"previous_version,omitempty"`
+	Compatible      bool                  `json:"compatible"`
+	BreakingChanges []spec.BreakingChange `json:"breaking_changes"`
+}
+
+type CompatibilityResponse struct {
+	Success bool              `json:"success"`
+	Data    *CompatibilityDTO `json:"data,omitempty"`
+	Errors  []ErrorObj        `json:"errors,omitempty"`
+}
@@ -0,0 +1,53 @@
+package dto
+
+import "catalog-service/internal/models"
+
+type WebhookDTO struct {
+	ID         string   `json:"id"`
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret,omitempty"`
+	EventTypes []string `json:"event_types"`
+	ServiceIDs []string `json:"service_ids"`
+	Labels     []string `json:"labels"`
+	Active     *bool    `json:"active"`
+	CreatedAt  string   `json:"created_at"`
+	UpdatedAt  string   `json:"updated_at"`
+}
+
+type DeliveryDTO struct {
+	ID           string              `json:"id"`
+	WebhookID    string              `json:"webhook_id"`
+	Event        models.ServiceEvent `json:"event"`
+	Status       string              `json:"status"`
+	Attempts     int                 `json:"attempts"`
+	ResponseCode int                 `json:"response_code,omitempty"`
+	Error        string              `json:"error,omitempty"`
+	Redelivery   bool                `json:"redelivery"`
+	NextRetryAt  string              `json:"next_retry_at,omitempty"`
+	CreatedAt    string              `json:"created_at"`
+	UpdatedAt    string              `json:"updated_at"`
+}
+
+type WebhookResponse struct {
+	Success bool        `json:"success"`
+	Data    *WebhookDTO `json:"data,omitempty"`
+	Errors  []ErrorObj  `json:"errors,omitempty"`
+}
+
+type WebhookListResponse struct {
+	Success bool          `json:"success"`
+	Data    []*WebhookDTO `json:"data,omitempty"`
+	Errors  []ErrorObj    `json:"errors,omitempty"`
+}
+
+type DeliveryResponse struct {
+	Success bool         `json:"success"`
+	Data    *DeliveryDTO `json:"data,omitempty"`
+	Errors  []ErrorObj   `json:"errors,omitempty"`
+}
+
+type DeliveryListResponse struct {
+	Success bool           `json:"success"`
+	Data    []*DeliveryDTO `json:"data,omitempty"`
+	Errors  []ErrorObj     `json:"errors,omitempty"`
+}
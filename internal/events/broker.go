@@ -0,0 +1,181 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	"catalog-service/internal/repository"
+)
+
+const (
+	subscriberBuffer = 64
+	replayPageSize   = 500
+)
+
+// Broker assigns event ids, appends events to the persisted log and fans them
+// out to live subscribers. Ids are assigned in process, continuing from the
+// last id in the log, so a single instance must publish for a given log.
+type Broker struct {
+	store repository.EventRepository
+
+	mu          sync.Mutex
+	loaded      bool
+	lastID      int64
+	subscribers map[*Subscription]struct{}
+}
+
+type Subscription struct {
+	// Events delivers events in id order. It is closed when the subscription
+	// is cancelled or falls too far behind; resubscribe with the last id seen.
+	Events <-chan *models.ServiceEvent
+	// Gap is true when events after the requested id are no longer in the log.
+	Gap bool
+
+	events chan *models.ServiceEvent
+	broker *Broker
+	once   sync.Once
+}
+
+func NewBroker(store repository.EventRepository) *Broker {
+	return &Broker{store: store, subscribers: map[*Subscription]struct{}{}}
+}
+
+func (b *Broker) Publish(ctx context.Context, event *models.ServiceEvent) error {
+	log := logger.NewContextLogger(ctx, "Broker/Publish")
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.load(ctx); err != nil {
+		return err
+	}
+
+	b.lastID++
+	event.ID = b.lastID
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+	if err := b.store.Append(ctx, event); err != nil {
+		// Live subscribers still get the event; resuming clients will see a gap.
+		log.Errorf(err, "failed to persist event %d", event.ID)
+	}
+
+	for sub := range b.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			log.Warnf("dropping slow subscriber at event %d", event.ID)
+			b.remove(sub)
+		}
+	}
+	return nil
+}
+
+// Subscribe streams events after lastEventID: first those already in the log,
+// then live ones. A lastEventID of 0 or less starts with live events only.
+func (b *Broker) Subscribe(ctx context.Context, lastEventID int64) (*Subscription, error) {
+	b.mu.Lock()
+	if err := b.load(ctx); err != nil {
+		b.mu.Unlock()
+		return nil, err
+	}
+	live := make(chan *models.ServiceEvent, subscriberBuffer)
+	sub := &Subscription{events: live, broker: b}
+	b.subscribers[sub] = struct{}{}
+	head := b.lastID
+	b.mu.Unlock()
+
+	if lastEventID <= 0 || lastEventID >= head {
+		sub.Events = live
+		return sub, nil
+	}
+
+	backlog, err := b.replay(ctx, lastEventID, head)
+	if err != nil {
+		sub.Close()
+		return nil, err
+	}
+	if len(backlog) == 0 || backlog[0].ID != lastEventID+1 {
+		sub.Gap = true
+	}
+
+	out := make(chan *models.ServiceEvent, subscriberBuffer)
+	sub.Events = out
+	go func() {
+		defer close(out)
+		for _, event := range backlog {
+			select {
+			case out <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+		for event := range live {
+			select {
+			case out <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
+
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.remove(s)
+}
+
+// Close ends every subscription, so streams finish and the HTTP server can shut
+// down. Clients reconnect with their last event id.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subscribers {
+		b.remove(sub)
+	}
+}
+
+func (b *Broker) remove(sub *Subscription) {
+	sub.once.Do(func() {
+		delete(b.subscribers, sub)
+		close(sub.events)
+	})
+}
+
+func (b *Broker) replay(ctx context.Context, after, head int64) ([]*models.ServiceEvent, error) {
+	var backlog []*models.ServiceEvent
+	for after < head {
+		page, err := b.store.ListAfter(ctx, after, replayPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to replay events: %w", err)
+		}
+		for _, event := range page {
+			if event.ID > head {
+				return backlog, nil
+			}
+			backlog = append(backlog, event)
+			after = event.ID
+		}
+		if len(page) < replayPageSize {
+			break
+		}
+	}
+	return backlog, nil
+}
+
+func (b *Broker) load(ctx context.Context) error {
+	if b.loaded {
+		return nil
+	}
+	lastID, err := b.store.LastID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load last event id: %w", err)
+	}
+	b.lastID, b.loaded = lastID, true
+	return nil
+}
@@ -0,0 +1,184 @@
+package events
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"catalog-service/internal/config"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	mockrepo "catalog-service/test/mocks/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// memoryLog mimics the bounded OpenSearch log: it keeps the last capacity
+// events.
+type memoryLog struct {
+	mu       sync.Mutex
+	capacity int
+	events   []*models.ServiceEvent
+}
+
+func (l *memoryLog) Append(_ context.Context, event *models.ServiceEvent) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event)
+	if len(l.events) > l.capacity {
+		l.events = l.events[len(l.events)-l.capacity:]
+	}
+	return nil
+}
+
+func (l *memoryLog) ListAfter(_ context.Context, afterID int64, limit int) ([]*models.ServiceEvent, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var out []*models.ServiceEvent
+	for _, e := range l.events {
+		if e.ID > afterID && len(out) < limit {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (l *memoryLog) LastID(context.Context) (int64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.events) == 0 {
+		return 0, nil
+	}
+	return l.events[len(l.events)-1].ID, nil
+}
+
+type BrokerSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func TestBrokerSuite(t *testing.T) {
+	suite.Run(t, new(BrokerSuite))
+}
+
+func (suite *BrokerSuite) SetupTest() {
+	config.Load()
+	logger.Setup("INFO", "json")
+	suite.ctx = context.Background()
+}
+
+func (suite *BrokerSuite) publish(b *Broker, serviceID string) *models.ServiceEvent {
+	event := &models.ServiceEvent{Type: models.EventServiceUpdated, ServiceID: serviceID}
+	suite.Require().NoError(b.Publish(suite.ctx, event))
+	return event
+}
+
+func (suite *BrokerSuite) next(sub *Subscription) *models.ServiceEvent {
+	select {
+	case event, ok := <-sub.Events:
+		suite.Require().True(ok, "subscription closed")
+		return event
+	case <-time.After(time.Second):
+		suite.FailNow("no event received")
+		return nil
+	}
+}
+
+func (suite *BrokerSuite) Test_Publish_ContinuesIDsFromLog() {
+	store := new(mockrepo.EventRepository)
+	store.On("LastID", mock.Anything).Return(int64(41), nil).Once()
+	store.On("Append", mock.Anything, mock.Anything).Return(nil)
+
+	b := NewBroker(store)
+	first := suite.publish(b, "svc-1")
+	second := suite.publish(b, "svc-2")
+
+	suite.Equal(int64(42), first.ID)
+	suite.Equal(int64(43), second.ID)
+	suite.False(first.OccurredAt.IsZero())
+	store.AssertExpectations(suite.T())
+}
+
+func (suite *BrokerSuite) Test_Publish_DeliversLiveWhenPersistenceFails() {
+	store := new(mockrepo.EventRepository)
+	store.On("LastID", mock.Anything).Return(int64(0), nil)
+	store.On("Append", mock.Anything, mock.Anything).Return(assert.AnError)
+
+	b := NewBroker(store)
+	sub, err := b.Subscribe(suite.ctx, 0)
+	suite.Require().NoError(err)
+	defer sub.Close()
+
+	suite.publish(b, "svc-1")
+	suite.Equal("svc-1", suite.next(sub).ServiceID)
+}
+
+func (suite *BrokerSuite) Test_Subscribe_ResumesFromLastEventID() {
+	b := NewBroker(&memoryLog{capacity: 100})
+	for _, id := range []string{"a", "b", "c"} {
+		suite.publish(b, id)
+	}
+
+	sub, err := b.Subscribe(suite.ctx, 1)
+	suite.Require().NoError(err)
+	defer sub.Close()
+	suite.publish(b, "d")
+
+	suite.False(sub.Gap)
+	for _, want := range []int64{2, 3, 4} {
+		suite.Equal(want, suite.next(sub).ID)
+	}
+}
+
+func (suite *BrokerSuite) Test_Subscribe_ReportsTrimmedEvents() {
+	b := NewBroker(&memoryLog{capacity: 2})
+	for _, id := range []string{"a", "b", "c", "d"} {
+		suite.publish(b, id)
+	}
+
+	sub, err := b.Subscribe(suite.ctx, 1)
+	suite.Require().NoError(err)
+	defer sub.Close()
+
+	suite.True(sub.Gap)
+	suite.Equal(int64(3), suite.next(sub).ID)
+	suite.Equal(int64(4), suite.next(sub).ID)
+}
+
+func (suite *BrokerSuite) Test_SlowSubscriberIsDropped() {
+	b := NewBroker(&memoryLog{capacity: 1000})
+	sub, err := b.Subscribe(suite.ctx, 0)
+	suite.Require().NoError(err)
+
+	for i := 0; i <= subscriberBuffer; i++ {
+		suite.publish(b, "svc")
+	}
+
+	received := 0
+	for range sub.Events {
+		received++
+	}
+	suite.Equal(subscriberBuffer, received)
+	sub.Close()
+}
+
+func (suite *BrokerSuite) Test_Close_EndsSubscriptions() {
+	b := NewBroker(&memoryLog{capacity: 10})
+	suite.publish(b, "a")
+	suite.publish(b, "b")
+	live, err := b.Subscribe(suite.ctx, 0)
+	suite.Require().NoError(err)
+	resumed, err := b.Subscribe(suite.ctx, 1)
+	suite.Require().NoError(err)
+
+	b.Close()
+
+	_, ok := <-live.Events
+	suite.False(ok)
+	suite.Equal(int64(2), suite.next(resumed).ID)
+	_, ok = <-resumed.Events
+	suite.False(ok)
+}
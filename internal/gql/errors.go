@@ -0,0 +1,36 @@
+package gql
+
+import (
+	"strings"
+
+	"catalog-service/internal/constants"
+	"catalog-service/internal/dto"
+)
+
+// Error carries catalog error objects to the client in the extensions of a
+// GraphQL error.
+type Error struct {
+	Errors []dto.ErrorObj
+}
+
+func (e *Error) Error() string {
+	causes := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		causes = append(causes, err.Cause)
+	}
+	return strings.Join(causes, "; ")
+}
+
+func (e *Error) Extensions() map[string]interface{} {
+	ext := map[string]interface{}{"errors": e.Errors}
+	if len(e.Errors) > 0 {
+		ext["code"] = e.Errors[0].Code
+	}
+	return ext
+}
+
+var errMutationOverGET = &Error{Errors: []dto.ErrorObj{{
+	Code:   constants.Error_MALFORMED_DATA,
+	Entity: "operation",
+	Cause:  "only queries can be sent with GET",
+}}}
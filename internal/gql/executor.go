@@ -0,0 +1,46 @@
+package gql
+
+import (
+	"context"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type Executor struct {
+	schema graphql.Schema
+	limits Limits
+}
+
+func NewExecutor(schema graphql.Schema, limits Limits) *Executor {
+	return &Executor{schema: schema, limits: limits}
+}
+
+// Execute runs req unless it fails to parse or exceeds the limits. readOnly
+// rejects mutations, for requests made over GET.
+func (e *Executor) Execute(ctx context.Context, req Request, readOnly bool) (*graphql.Result, bool) {
+	result, err := analyze(req.Query, req.OperationName, req.Variables)
+	if err == nil {
+		err = e.limits.check(result)
+	}
+	if err == nil && readOnly && result.operation != "query" {
+		err = errMutationOverGET
+	}
+	if err != nil {
+		return &graphql.Result{Errors: []gqlerrors.FormattedError{gqlerrors.FormatError(err)}}, false
+	}
+
+	return graphql.Do(graphql.Params{
+		Schema:         e.schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        ctx,
+	}), true
+}
@@ -0,0 +1,203 @@
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"catalog-service/internal/config"
+	"catalog-service/internal/constants"
+	"catalog-service/internal/dto"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	"catalog-service/internal/usecase"
+	mockusecase "catalog-service/test/mocks/usecase"
+
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExecutorSuite struct {
+	suite.Suite
+	usecase  *mockusecase.ServiceUsecase
+	executor *Executor
+}
+
+func TestExecutorSuite(t *testing.T) {
+	suite.Run(t, new(ExecutorSuite))
+}
+
+func (suite *ExecutorSuite) SetupTest() {
+	config.Load()
+	logger.Setup("INFO", "json")
+
+	suite.usecase = new(mockusecase.ServiceUsecase)
+	schema, err := NewSchema(suite.usecase)
+	suite.Require().NoError(err)
+	suite.executor = NewExecutor(schema, Limits{MaxDepth: 4, MaxComplexity: 100})
+}
+
+func (suite *ExecutorSuite) run(query string, variables map[string]interface{}, readOnly bool) (map[string]interface{}, *graphql.Result, bool) {
+	result, ok := suite.executor.Execute(context.Background(), Request{Query: query, Variables: variables}, readOnly)
+	raw, err := json.Marshal(result)
+	suite.Require().NoError(err)
+	var body map[string]interface{}
+	suite.Require().NoError(json.Unmarshal(raw, &body))
+	return body, result, ok
+}
+
+func (suite *ExecutorSuite) Test_Services_PassesFiltersAndPagination() {
+	after := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	suite.usecase.On("SearchWithFilter", mock.Anything, "billing", models.ServiceFilter{
+		Version:      "2.0.0",
+		Operation:    "GET /invoices",
+		UpdatedAfter: after,
+	}, 2, 5).Return([]*dto.ServiceDTO{{
+		ID:         "svc-1",
+		Name:       "billing",
+		Versions:   []models.Version{{VersionNumber: "1.0.0"}, {VersionNumber: "2.0.0", Details: "v2"}},
+		Operations: []string{"GET /invoices"},
+	}}, 11, nil)
+
+	body, result, ok := suite.run(`query($after: String) {
+		services(query: "billing", page: 2, limit: 5, filter: {version: "2.0.0", operation: "GET /invoices", updatedAfter: $after}) {
+			totalCount page limit hasNextPage
+			nodes { id name operations latestVersion { versionNumber details } versions(numbers: ["1.0.0"]) { versionNumber } }
+		}
+	}`, map[string]interface{}{"after": "2024-06-01T00:00:00Z"}, true)
+
+	suite.True(ok)
+	suite.Empty(result.Errors)
+	suite.Equal(map[string]interface{}{
+		"services": map[string]interface{}{
+			"totalCount":  float64(11),
+			"page":        float64(2),
+			"limit":       float64(5),
+			"hasNextPage": true,
+			"nodes": []interface{}{map[string]interface{}{
+				"id":            "svc-1",
+				"name":          "billing",
+				"operations":    []interface{}{"GET /invoices"},
+				"latestVersion": map[string]interface{}{"versionNumber": "2.0.0", "details": "v2"},
+				"versions":      []interface{}{map[string]interface{}{"versionNumber": "1.0.0"}},
+			}},
+		},
+	}, body["data"])
+}
+
+func (suite *ExecutorSuite) Test_Services_RejectsInvalidPaginationAndFilter() {
+	_, result, ok := suite.run(`{ services(limit: 0, filter: {updatedBefore: "yesterday"}) { totalCount } }`, nil, true)
+
+	suite.True(ok)
+	suite.Require().Len(result.Errors, 1)
+	suite.Equal(constants.Error_MALFORMED_DATA, result.Errors[0].Extensions["code"])
+	suite.usecase.AssertNotCalled(suite.T(), "SearchWithFilter", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ExecutorSuite) Test_Service_ReturnsNullWhenMissing() {
+	suite.usecase.On("FindByID", mock.Anything, "missing").Return(nil, fmt.Errorf("%w: missing", usecase.ErrServiceNotFound))
+
+	body, result, ok := suite.run(`{ service(id: "missing") { id } }`, nil, true)
+
+	suite.True(ok)
+	suite.Empty(result.Errors)
+	suite.Equal(map[string]interface{}{"service": nil}, body["data"])
+}
+
+func (suite *ExecutorSuite) Test_CreateService_ReusesUsecase() {
+	suite.usecase.On("Create", mock.Anything, &dto.ServiceDTO{
+		Name:     "billing",
+		Versions: []models.Version{{VersionNumber: "1.0.0", Details: "initial"}},
+	}).Return(&dto.ServiceDTO{ID: "svc-1", Name: "billing"}, nil)
+
+	body, result, ok := suite.run(`mutation { createService(input: {name: "billing", versions: [{versionNumber: "1.0.0", details: "initial"}]}) { id name } }`, nil, false)
+
+	suite.True(ok)
+	suite.Empty(result.Errors)
+	suite.Equal(map[string]interface{}{"createService": map[string]interface{}{"id": "svc-1", "name": "billing"}}, body["data"])
+}
+
+func (suite *ExecutorSuite) Test_CreateService_ValidatesInput() {
+	_, result, ok := suite.run(`mutation { createService(input: {name: "", versions: []}) { id } }`, nil, false)
+
+	suite.True(ok)
+	suite.Require().Len(result.Errors, 1)
+	suite.Equal(constants.Error_MALFORMED_DATA, result.Errors[0].Extensions["code"])
+	suite.usecase.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *ExecutorSuite) Test_UpdateAndDelete_MapNotFound() {
+	notFound := fmt.Errorf("%w: svc-9", usecase.ErrServiceNotFound)
+	suite.usecase.On("Update", mock.Anything, "svc-9", &dto.ServiceDTO{Description: "new", Versions: []models.Version{}}).Return(nil, notFound)
+	suite.usecase.On("Delete", mock.Anything, "svc-9").Return(notFound)
+
+	_, result, _ := suite.run(`mutation { updateService(id: "svc-9", input: {description: "new"}) { id } }`, nil, false)
+	suite.Require().Len(result.Errors, 1)
+	suite.Equal(constants.Error_SERVICE_NOT_FOUND, result.Errors[0].Extensions["code"])
+
+	_, result, _ = suite.run(`mutation { deleteService(id: "svc-9") }`, nil, false)
+	suite.Require().Len(result.Errors, 1)
+	suite.Equal(constants.Error_SERVICE_NOT_FOUND, result.Errors[0].Extensions["code"])
+}
+
+func (suite *ExecutorSuite) Test_DeleteService_HidesInternalErrors() {
+	suite.usecase.On("Delete", mock.Anything, "svc-1").Return(errors.New("connection refused"))
+
+	_, result, _ := suite.run(`mutation { deleteService(id: "svc-1") }`, nil, false)
+
+	suite.Require().Len(result.Errors, 1)
+	suite.Equal(constants.Error_GENERIC_SERVICE_ERROR, result.Errors[0].Extensions["code"])
+	suite.NotContains(result.Errors[0].Message, "connection refused")
+}
+
+func (suite *ExecutorSuite) Test_RejectsMutationWhenReadOnly() {
+	_, result, ok := suite.run(`mutation { deleteService(id: "svc-1") }`, nil, true)
+
+	suite.False(ok)
+	suite.Require().Len(result.Errors, 1)
+	suite.usecase.AssertNotCalled(suite.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (suite *ExecutorSuite) Test_RejectsQueriesOverDepthLimit() {
+	suite.usecase.On("SearchWithFilter", mock.Anything, "", models.ServiceFilter{}, 1, 10).Return([]*dto.ServiceDTO{}, 0, nil)
+
+	_, result, ok := suite.run(`{ services { nodes { latestVersion { versionNumber } versions { versionNumber } } } }`, nil, true)
+	suite.True(ok)
+	suite.Empty(result.Errors)
+
+	_, result, ok = suite.run(`
+		query { ...A }
+		fragment A on Query { services { nodes { ...B } } }
+		fragment B on Service { latestVersion { ...C } }
+		fragment C on Version { versionNumber ... on Version { details } }
+	`, nil, true)
+	suite.True(ok)
+	suite.Empty(result.Errors)
+
+	suite.executor.limits.MaxDepth = 3
+	_, result, ok = suite.run(`{ services { nodes { latestVersion { versionNumber } } } }`, nil, true)
+	suite.False(ok)
+	suite.Require().Len(result.Errors, 1)
+	suite.Contains(result.Errors[0].Message, "depth 4 exceeds the limit of 3")
+}
+
+func (suite *ExecutorSuite) Test_RejectsQueriesOverComplexityLimit() {
+	// services + 50 pages' worth of nodes { id name }
+	_, result, ok := suite.run(`query($n: Int) { services(limit: $n) { nodes { id name } } }`, map[string]interface{}{"n": float64(50)}, true)
+
+	suite.False(ok)
+	suite.Require().Len(result.Errors, 1)
+	suite.Contains(result.Errors[0].Message, "complexity 151 exceeds the limit of 100")
+	suite.usecase.AssertNotCalled(suite.T(), "SearchWithFilter", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ExecutorSuite) Test_RejectsUnparsableQuery() {
+	_, result, ok := suite.run(`{ services { `, nil, true)
+
+	suite.False(ok)
+	suite.NotEmpty(result.Errors)
+}
@@ -0,0 +1,139 @@
+package gql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+type Limits struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+// listMultipliers gives the default page size of list fields whose children
+// are resolved once per item, used when the query does not pass a limit.
+var listMultipliers = map[string]int{
+	"services": defaultLimit,
+}
+
+type analysis struct {
+	operation string
+	depth     int
+	cost      int
+}
+
+type analyzer struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	visiting  map[string]bool
+}
+
+func analyze(query, operationName string, variables map[string]interface{}) (*analysis, error) {
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return nil, err
+	}
+
+	a := &analyzer{
+		fragments: map[string]*ast.FragmentDefinition{},
+		variables: variables,
+		visiting:  map[string]bool{},
+	}
+	var operations []*ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		switch d := def.(type) {
+		case *ast.FragmentDefinition:
+			a.fragments[d.Name.Value] = d
+		case *ast.OperationDefinition:
+			if operationName == "" || (d.Name != nil && d.Name.Value == operationName) {
+				operations = append(operations, d)
+			}
+		}
+	}
+	if len(operations) != 1 {
+		if operationName != "" {
+			return nil, fmt.Errorf("unknown operation %q", operationName)
+		}
+		return nil, fmt.Errorf("operationName is required when the document has several operations")
+	}
+
+	op := operations[0]
+	depth, cost := a.selectionSet(op.SelectionSet)
+	return &analysis{operation: op.Operation, depth: depth, cost: cost}, nil
+}
+
+func (a *analyzer) selectionSet(set *ast.SelectionSet) (depth, cost int) {
+	if set == nil {
+		return 0, 0
+	}
+	for _, sel := range set.Selections {
+		var d, c int
+		switch s := sel.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name.Value, "__") {
+				continue
+			}
+			childDepth, childCost := a.selectionSet(s.SelectionSet)
+			d, c = childDepth+1, 1+childCost*a.multiplier(s)
+		case *ast.InlineFragment:
+			d, c = a.selectionSet(s.SelectionSet)
+		case *ast.FragmentSpread:
+			frag, ok := a.fragments[s.Name.Value]
+			if !ok || a.visiting[s.Name.Value] {
+				continue
+			}
+			a.visiting[s.Name.Value] = true
+			d, c = a.selectionSet(frag.SelectionSet)
+			a.visiting[s.Name.Value] = false
+		}
+		if d > depth {
+			depth = d
+		}
+		cost += c
+	}
+	return depth, cost
+}
+
+func (a *analyzer) multiplier(field *ast.Field) int {
+	def, ok := listMultipliers[field.Name.Value]
+	if !ok {
+		return 1
+	}
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "limit" {
+			continue
+		}
+		switch v := arg.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(v.Value); err == nil && n > 0 {
+				return n
+			}
+		case *ast.Variable:
+			switch n := a.variables[v.Name.Value].(type) {
+			case float64:
+				if n > 0 {
+					return int(n)
+				}
+			case int:
+				if n > 0 {
+					return n
+				}
+			}
+		}
+	}
+	return def
+}
+
+func (l Limits) check(result *analysis) error {
+	if l.MaxDepth > 0 && result.depth > l.MaxDepth {
+		return fmt.Errorf("query depth %d exceeds the limit of %d", result.depth, l.MaxDepth)
+	}
+	if l.MaxComplexity > 0 && result.cost > l.MaxComplexity {
+		return fmt.Errorf("query complexity %d exceeds the limit of %d", result.cost, l.MaxComplexity)
+	}
+	return nil
+}
@@ -0,0 +1,422 @@
+package gql
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"catalog-service/internal/api/validator"
+	"catalog-service/internal/constants"
+	"catalog-service/internal/dto"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	"catalog-service/internal/usecase"
+
+	"github.com/graphql-go/graphql"
+)
+
+const (
+	defaultPage  = 1
+	defaultLimit = 10
+)
+
+type servicePage struct {
+	TotalCount  int               `json:"totalCount"`
+	Page        int               `json:"page"`
+	Limit       int               `json:"limit"`
+	HasNextPage bool              `json:"hasNextPage"`
+	Nodes       []*dto.ServiceDTO `json:"nodes"`
+}
+
+type resolver struct {
+	usecase usecase.ServiceUsecase
+}
+
+func NewSchema(serviceUsecase usecase.ServiceUsecase) (graphql.Schema, error) {
+	r := &resolver{usecase: serviceUsecase}
+
+	versionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Version",
+		Fields: graphql.Fields{
+			"versionNumber": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(models.Version).VersionNumber, nil
+				},
+			},
+			"details": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(models.Version).Details, nil
+				},
+			},
+			"deprecated": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(models.Version).Deprecated, nil
+				},
+			},
+		},
+	})
+
+	serviceType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Service",
+		Fields: graphql.Fields{
+			"id":          serviceField(graphql.NewNonNull(graphql.ID), func(s *dto.ServiceDTO) interface{} { return s.ID }),
+			"name":        serviceField(graphql.NewNonNull(graphql.String), func(s *dto.ServiceDTO) interface{} { return s.Name }),
+			"description": serviceField(graphql.String, func(s *dto.ServiceDTO) interface{} { return s.Description }),
+			"operations": serviceField(graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))), func(s *dto.ServiceDTO) interface{} {
+				if s.Operations == nil {
+					return []string{}
+				}
+				return s.Operations
+			}),
+			"labels": serviceField(graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))), func(s *dto.ServiceDTO) interface{} {
+				if s.Labels == nil {
+					return []string{}
+				}
+				return s.Labels
+			}),
+			"createdAt": serviceField(graphql.NewNonNull(graphql.String), func(s *dto.ServiceDTO) interface{} { return s.CreatedAt }),
+			"updatedAt": serviceField(graphql.NewNonNull(graphql.String), func(s *dto.ServiceDTO) interface{} { return s.UpdatedAt }),
+			"versions": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(versionType))),
+				Description: "Versions in registration order, optionally only the given version numbers or the last N.",
+				Args: graphql.FieldConfigArgument{
+					"numbers": &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+					"last":    &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+				Resolve: resolveVersions,
+			},
+			"latestVersion": serviceField(versionType, func(s *dto.ServiceDTO) interface{} {
+				if len(s.Versions) == 0 {
+					return nil
+				}
+				return s.Versions[len(s.Versions)-1]
+			}),
+		},
+	})
+
+	pageType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ServicePage",
+		Fields: graphql.Fields{
+			"totalCount":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"page":        &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"limit":       &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"hasNextPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"nodes":       &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(serviceType)))},
+		},
+	})
+
+	filterType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "ServiceFilter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"name":          &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "Exact service name"},
+			"version":       &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "Has this version number"},
+			"operation":     &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "Has this operation in one of its specs"},
+			"updatedAfter":  &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "RFC 3339 timestamp, inclusive"},
+			"updatedBefore": &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "RFC 3339 timestamp, exclusive"},
+		},
+	})
+
+	versionInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "VersionInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"versionNumber": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"details":       &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"deprecated":    &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+		},
+	})
+
+	createInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "CreateServiceInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"id":          &graphql.InputObjectFieldConfig{Type: graphql.ID},
+			"name":        &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"description": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"labels":      &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+			"versions":    &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(versionInput)))},
+		},
+	})
+
+	updateInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "UpdateServiceInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"description": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"labels":      &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String)), Description: "Replaces the existing labels"},
+			"versions":    &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(versionInput)), Description: "Added to the existing versions, or merged into the one with the same number"},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"service": &graphql.Field{
+				Type:    serviceType,
+				Args:    graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: r.service,
+			},
+			"services": &graphql.Field{
+				Type: graphql.NewNonNull(pageType),
+				Args: graphql.FieldConfigArgument{
+					"query":  &graphql.ArgumentConfig{Type: graphql.String, Description: "Matches name, description and spec operations"},
+					"filter": &graphql.ArgumentConfig{Type: filterType},
+					"page":   &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultPage},
+					"limit":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultLimit},
+				},
+				Resolve: r.services,
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createService": &graphql.Field{
+				Type:    graphql.NewNonNull(serviceType),
+				Args:    graphql.FieldConfigArgument{"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(createInput)}},
+				Resolve: r.createService,
+			},
+			"updateService": &graphql.Field{
+				Type: graphql.NewNonNull(serviceType),
+				Args: graphql.FieldConfigArgument{
+					"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(updateInput)},
+				},
+				Resolve: r.updateService,
+			},
+			"deleteService": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.Boolean),
+				Args:    graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: r.deleteService,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+func serviceField(typ graphql.Output, get func(*dto.ServiceDTO) interface{}) *graphql.Field {
+	return &graphql.Field{
+		Type: typ,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return get(p.Source.(*dto.ServiceDTO)), nil
+		},
+	}
+}
+
+func resolveVersions(p graphql.ResolveParams) (interface{}, error) {
+	versions := p.Source.(*dto.ServiceDTO).Versions
+	if numbers, ok := p.Args["numbers"].([]interface{}); ok {
+		wanted := make(map[string]bool, len(numbers))
+		for _, n := range numbers {
+			wanted[n.(string)] = true
+		}
+		selected := make([]models.Version, 0, len(numbers))
+		for _, v := range versions {
+			if wanted[v.VersionNumber] {
+				selected = append(selected, v)
+			}
+		}
+		versions = selected
+	}
+	if last, ok := p.Args["last"].(int); ok && last >= 0 && last < len(versions) {
+		versions = versions[len(versions)-last:]
+	}
+	if versions == nil {
+		return []models.Version{}, nil
+	}
+	return versions, nil
+}
+
+func (r *resolver) service(p graphql.ResolveParams) (interface{}, error) {
+	ctx := p.Context
+	id := p.Args["id"].(string)
+	log := logger.NewContextLogger(ctx, "GraphQL/service")
+	log.Infof("fetching service by id='%s'", id)
+
+	svc, err := r.usecase.FindByID(ctx, id)
+	if errors.Is(err, usecase.ErrServiceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Errorf(err, "failed to fetch service")
+		return nil, genericError("service", "failed to fetch service")
+	}
+	return svc, nil
+}
+
+func (r *resolver) services(p graphql.ResolveParams) (interface{}, error) {
+	ctx := p.Context
+	log := logger.NewContextLogger(ctx, "GraphQL/services")
+	query, _ := p.Args["query"].(string)
+	pageNum, _ := p.Args["page"].(int)
+	limitNum, _ := p.Args["limit"].(int)
+
+	page, limit, errs, _ := validator.ValidateSearchRequest(strconv.Itoa(pageNum), strconv.Itoa(limitNum))
+	if len(errs) > 0 {
+		return nil, &Error{Errors: errs}
+	}
+	filter, errs := parseFilter(p.Args["filter"])
+	if len(errs) > 0 {
+		return nil, &Error{Errors: errs}
+	}
+
+	log.Infof("Searching: query='%s', filter=%+v, page='%d', limit='%d'", query, filter, page, limit)
+	services, total, err := r.usecase.SearchWithFilter(ctx, query, filter, page, limit)
+	if err != nil {
+		log.Errorf(err, "failed to search services")
+		return nil, genericError("service", "search failed")
+	}
+	return &servicePage{
+		TotalCount:  total,
+		Page:        page,
+		Limit:       limit,
+		HasNextPage: page*limit < total,
+		Nodes:       services,
+	}, nil
+}
+
+func (r *resolver) createService(p graphql.ResolveParams) (interface{}, error) {
+	ctx := p.Context
+	log := logger.NewContextLogger(ctx, "GraphQL/createService")
+	input := p.Args["input"].(map[string]interface{})
+
+	req := &dto.ServiceDTO{
+		ID:          stringArg(input, "id"),
+		Name:        stringArg(input, "name"),
+		Description: stringArg(input, "description"),
+		Versions:    versionsArg(input),
+		Labels:      labelsArg(input),
+	}
+	if errs, _ := validator.ValidateCreateRequest(req); len(errs) > 0 {
+		return nil, &Error{Errors: errs}
+	}
+
+	svc, err := r.usecase.Create(ctx, req)
+	if err != nil {
+		log.Errorf(err, "failed to create service")
+		return nil, genericError("service", "failed to create service")
+	}
+	return svc, nil
+}
+
+func (r *resolver) updateService(p graphql.ResolveParams) (interface{}, error) {
+	ctx := p.Context
+	id := p.Args["id"].(string)
+	log := logger.NewContextLogger(ctx, "GraphQL/updateService")
+	log.Infof("updating service by id='%s'", id)
+	input := p.Args["input"].(map[string]interface{})
+
+	req := &dto.ServiceDTO{
+		Description: stringArg(input, "description"),
+		Versions:    versionsArg(input),
+		Labels:      labelsArg(input),
+	}
+	if errs, _ := validator.ValidateUpdateRequest(req); len(errs) > 0 {
+		return nil, &Error{Errors: errs}
+	}
+
+	svc, err := r.usecase.Update(ctx, id, req)
+	if err != nil {
+		log.Errorf(err, "failed to update service")
+		return nil, serviceError(err, "failed to update service")
+	}
+	return svc, nil
+}
+
+func (r *resolver) deleteService(p graphql.ResolveParams) (interface{}, error) {
+	ctx := p.Context
+	id := p.Args["id"].(string)
+	log := logger.NewContextLogger(ctx, "GraphQL/deleteService")
+	log.Infof("deleting service by id='%s'", id)
+
+	if err := r.usecase.Delete(ctx, id); err != nil {
+		log.Errorf(err, "failed to delete service")
+		return nil, serviceError(err, "failed to delete service")
+	}
+	return true, nil
+}
+
+func parseFilter(raw interface{}) (models.ServiceFilter, []dto.ErrorObj) {
+	var filter models.ServiceFilter
+	fields, ok := raw.(map[string]interface{})
+	if !ok {
+		return filter, nil
+	}
+	filter.Name = stringArg(fields, "name")
+	filter.Version = stringArg(fields, "version")
+	filter.Operation = stringArg(fields, "operation")
+
+	var errs []dto.ErrorObj
+	for key, dest := range map[string]*time.Time{"updatedAfter": &filter.UpdatedAfter, "updatedBefore": &filter.UpdatedBefore} {
+		value := stringArg(fields, key)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			errs = append(errs, dto.ErrorObj{
+				Code:   constants.Error_MALFORMED_DATA,
+				Entity: "filter." + key,
+				Cause:  "must be an RFC 3339 timestamp",
+			})
+			continue
+		}
+		*dest = t
+	}
+	return filter, errs
+}
+
+func stringArg(args map[string]interface{}, key string) string {
+	s, _ := args[key].(string)
+	return strings.TrimSpace(s)
+}
+
+func versionsArg(args map[string]interface{}) []models.Version {
+	raw, _ := args["versions"].([]interface{})
+	versions := make([]models.Version, 0, len(raw))
+	for _, item := range raw {
+		v, _ := item.(map[string]interface{})
+		deprecated, _ := v["deprecated"].(bool)
+		versions = append(versions, models.Version{
+			VersionNumber: stringArg(v, "versionNumber"),
+			Details:       stringArg(v, "details"),
+			Deprecated:    deprecated,
+		})
+	}
+	return versions
+}
+
+func labelsArg(args map[string]interface{}) []string {
+	raw, ok := args["labels"].([]interface{})
+	if !ok {
+		return nil
+	}
+	labels := make([]string, 0, len(raw))
+	for _, item := range raw {
+		label, _ := item.(string)
+		labels = append(labels, label)
+	}
+	return labels
+}
+
+func serviceError(err error, cause string) error {
+	if errors.Is(err, usecase.ErrServiceNotFound) {
+		return &Error{Errors: []dto.ErrorObj{{
+			Code:   constants.Error_SERVICE_NOT_FOUND,
+			Entity: "service",
+			Cause:  "service not found",
+		}}}
+	}
+	return genericError("service", cause)
+}
+
+func genericError(entity, cause string) error {
+	return &Error{Errors: []dto.ErrorObj{{
+		Code:   constants.Error_GENERIC_SERVICE_ERROR,
+		Entity: entity,
+		Cause:  cause,
+	}}}
+}
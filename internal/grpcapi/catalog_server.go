@@ -0,0 +1,160 @@
+package grpcapi
+
+import (
+	"context"
+	"strconv"
+
+	"catalog-service/internal/api/validator"
+	"catalog-service/internal/dto"
+	"catalog-service/internal/grpcapi/catalogpb"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	"catalog-service/internal/usecase"
+)
+
+const (
+	defaultPage  = 1
+	defaultLimit = 10
+)
+
+type CatalogServer struct {
+	catalogpb.UnimplementedCatalogServiceServer
+	usecase usecase.ServiceUsecase
+}
+
+func NewCatalogServer(usecase usecase.ServiceUsecase) *CatalogServer {
+	return &CatalogServer{usecase: usecase}
+}
+
+func (s *CatalogServer) SearchServices(ctx context.Context, req *catalogpb.SearchServicesRequest) (*catalogpb.SearchServicesResponse, error) {
+	log := logger.NewContextLogger(ctx, "CatalogServer/SearchServices")
+	pageNum, limitNum := int(req.GetPage()), int(req.GetLimit())
+	if pageNum == 0 {
+		pageNum = defaultPage
+	}
+	if limitNum == 0 {
+		limitNum = defaultLimit
+	}
+	log.Infof("Searching: query='%s', page='%d', limit='%d'", req.GetQuery(), pageNum, limitNum)
+
+	page, limit, errs, _ := validator.ValidateSearchRequest(strconv.Itoa(pageNum), strconv.Itoa(limitNum))
+	if len(errs) > 0 {
+		return nil, invalidArgument(errs)
+	}
+
+	services, total, err := s.usecase.Search(ctx, req.GetQuery(), page, limit)
+	if err != nil {
+		log.Errorf(err, "failed to search services")
+		return nil, toStatus(err, "search failed")
+	}
+
+	resp := &catalogpb.SearchServicesResponse{
+		Count:    int32(total),
+		Services: make([]*catalogpb.Service, 0, len(services)),
+	}
+	for _, svc := range services {
+		resp.Services = append(resp.Services, toProto(svc))
+	}
+	if page*limit < total {
+		resp.NextPage = int32(page + 1)
+	}
+	return resp, nil
+}
+
+func (s *CatalogServer) GetService(ctx context.Context, req *catalogpb.GetServiceRequest) (*catalogpb.Service, error) {
+	log := logger.NewContextLogger(ctx, "CatalogServer/GetService")
+	log.Infof("fetching service by id='%s'", req.GetId())
+
+	if errs, _ := validator.ValidateID(req.GetId()); len(errs) > 0 {
+		return nil, invalidArgument(errs)
+	}
+
+	service, err := s.usecase.FindByID(ctx, req.GetId())
+	if err != nil {
+		log.Errorf(err, "failed to fetch service")
+		return nil, toStatus(err, "failed to fetch service")
+	}
+	return toProto(service), nil
+}
+
+func (s *CatalogServer) CreateService(ctx context.Context, req *catalogpb.CreateServiceRequest) (*catalogpb.Service, error) {
+	log := logger.NewContextLogger(ctx, "CatalogServer/CreateService")
+
+	body := &dto.ServiceDTO{
+		ID:          req.GetId(),
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		Versions:    fromProtoVersions(req.GetVersions()),
+	}
+	if errs, _ := validator.ValidateCreateRequest(body); len(errs) > 0 {
+		return nil, invalidArgument(errs)
+	}
+
+	service, err := s.usecase.Create(ctx, body)
+	if err != nil {
+		log.Errorf(err, "failed to create service")
+		return nil, toStatus(err, "failed to create service")
+	}
+	return toProto(service), nil
+}
+
+func (s *CatalogServer) UpdateService(ctx context.Context, req *catalogpb.UpdateServiceRequest) (*catalogpb.Service, error) {
+	log := logger.NewContextLogger(ctx, "CatalogServer/UpdateService")
+	log.Infof("updating service by id='%s'", req.GetId())
+
+	if errs, _ := validator.ValidateID(req.GetId()); len(errs) > 0 {
+		return nil, invalidArgument(errs)
+	}
+	body := &dto.ServiceDTO{
+		Description: req.GetDescription(),
+		Versions:    fromProtoVersions(req.GetVersions()),
+	}
+	if errs, _ := validator.ValidateUpdateRequest(body); len(errs) > 0 {
+		return nil, invalidArgument(errs)
+	}
+
+	service, err := s.usecase.Update(ctx, req.GetId(), body)
+	if err != nil {
+		log.Errorf(err, "failed to update service")
+		return nil, toStatus(err, "failed to update service")
+	}
+	return toProto(service), nil
+}
+
+func (s *CatalogServer) DeleteService(ctx context.Context, req *catalogpb.DeleteServiceRequest) (*catalogpb.DeleteServiceResponse, error) {
+	log := logger.NewContextLogger(ctx, "CatalogServer/DeleteService")
+	log.Infof("deleting service by id='%s'", req.GetId())
+
+	if errs, _ := validator.ValidateID(req.GetId()); len(errs) > 0 {
+		return nil, invalidArgument(errs)
+	}
+
+	if err := s.usecase.Delete(ctx, req.GetId()); err != nil {
+		log.Errorf(err, "failed to delete service")
+		return nil, toStatus(err, "failed to delete service")
+	}
+	return &catalogpb.DeleteServiceResponse{}, nil
+}
+
+func toProto(svc *dto.ServiceDTO) *catalogpb.Service {
+	versions := make([]*catalogpb.Version, 0, len(svc.Versions))
+	for _, v := range svc.Versions {
+		versions = append(versions, &catalogpb.Version{VersionNumber: v.VersionNumber, Details: v.Details})
+	}
+	return &catalogpb.Service{
+		Id:          svc.ID,
+		Name:        svc.Name,
+		Description: svc.Description,
+		Versions:    versions,
+		CreatedAt:   svc.CreatedAt,
+		UpdatedAt:   svc.UpdatedAt,
+	}
+}
+
+func fromProtoVersions(versions []*catalogpb.Version) []models.Version {
+	out := make([]models.Version, 0, len(versions))
+	for _, v := range versions {
+		out = append(out, models.Version{VersionNumber: v.GetVersionNumber(), Details: v.GetDetails()})
+	}
+	return out
+}
@@ -0,0 +1,171 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+
+	"catalog-service/internal/appcontext"
+	"catalog-service/internal/config"
+	"catalog-service/internal/dto"
+	"catalog-service/internal/grpcapi/catalogpb"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	"catalog-service/internal/usecase"
+	mockusecase "catalog-service/test/mocks/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type CatalogServerSuite struct {
+	suite.Suite
+	usecase *mockusecase.ServiceUsecase
+	server  *grpc.Server
+	conn    *grpc.ClientConn
+	client  catalogpb.CatalogServiceClient
+}
+
+func TestCatalogServerSuite(t *testing.T) {
+	suite.Run(t, new(CatalogServerSuite))
+}
+
+func (suite *CatalogServerSuite) SetupTest() {
+	config.Load()
+	logger.Setup("INFO", "json")
+
+	suite.usecase = new(mockusecase.ServiceUsecase)
+	suite.server = NewServer(suite.usecase)
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = suite.server.Serve(lis) }()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	suite.Require().NoError(err)
+	suite.conn = conn
+	suite.client = catalogpb.NewCatalogServiceClient(conn)
+}
+
+func (suite *CatalogServerSuite) TearDownTest() {
+	suite.conn.Close()
+	suite.server.Stop()
+}
+
+func (suite *CatalogServerSuite) Test_GetService_PropagatesCorrelationID() {
+	suite.usecase.On("FindByID", mock.MatchedBy(func(ctx context.Context) bool {
+		return appcontext.Value(ctx, appcontext.CorrelationIDKey) == "corr-1"
+	}), "svc-1").Return(&dto.ServiceDTO{
+		ID:        "svc-1",
+		Name:      "billing",
+		Versions:  []models.Version{{VersionNumber: "1.0.0", Details: "initial"}},
+		CreatedAt: "2024-06-01T12:00:00Z",
+	}, nil)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), CorrelationIDMetadataKey, "corr-1")
+	var header metadata.MD
+	got, err := suite.client.GetService(ctx, &catalogpb.GetServiceRequest{Id: "svc-1"}, grpc.Header(&header))
+
+	suite.Require().NoError(err)
+	suite.Equal("billing", got.GetName())
+	suite.Equal("1.0.0", got.GetVersions()[0].GetVersionNumber())
+	suite.Equal("2024-06-01T12:00:00Z", got.GetCreatedAt())
+	suite.Equal([]string{"corr-1"}, header.Get(CorrelationIDMetadataKey))
+}
+
+func (suite *CatalogServerSuite) Test_GeneratesCorrelationIDWhenMissing() {
+	suite.usecase.On("Delete", mock.Anything, "svc-1").Return(nil)
+
+	var header metadata.MD
+	_, err := suite.client.DeleteService(context.Background(), &catalogpb.DeleteServiceRequest{Id: "svc-1"}, grpc.Header(&header))
+
+	suite.Require().NoError(err)
+	suite.Len(header.Get(CorrelationIDMetadataKey), 1)
+	suite.NotEmpty(header.Get(CorrelationIDMetadataKey)[0])
+}
+
+func (suite *CatalogServerSuite) Test_MapsErrorsToStatusCodes() {
+	suite.usecase.On("FindByID", mock.Anything, "missing").Return(nil, fmt.Errorf("%w: missing", usecase.ErrServiceNotFound))
+	suite.usecase.On("Update", mock.Anything, "missing", mock.Anything).Return(nil, fmt.Errorf("%w: missing", usecase.ErrServiceNotFound))
+	suite.usecase.On("Search", mock.Anything, "", 1, 10).Return(nil, 0, assert.AnError)
+	suite.usecase.On("Delete", mock.Anything, "svc-1").Return(fmt.Errorf("%w: circuit breaker is open", usecase.ErrUnavailable))
+
+	_, err := suite.client.GetService(context.Background(), &catalogpb.GetServiceRequest{Id: "missing"})
+	suite.Equal(codes.NotFound, status.Code(err))
+
+	_, err = suite.client.UpdateService(context.Background(), &catalogpb.UpdateServiceRequest{Id: "missing", Description: "new"})
+	suite.Equal(codes.NotFound, status.Code(err))
+
+	_, err = suite.client.SearchServices(context.Background(), &catalogpb.SearchServicesRequest{})
+	suite.Equal(codes.Internal, status.Code(err))
+	suite.Equal("search failed", status.Convert(err).Message())
+
+	_, err = suite.client.DeleteService(context.Background(), &catalogpb.DeleteServiceRequest{Id: "svc-1"})
+	suite.Equal(codes.Unavailable, status.Code(err))
+}
+
+func (suite *CatalogServerSuite) Test_CreateService_ReportsFieldViolations() {
+	_, err := suite.client.CreateService(context.Background(), &catalogpb.CreateServiceRequest{Description: "no name"})
+
+	st := status.Convert(err)
+	suite.Equal(codes.InvalidArgument, st.Code())
+	suite.Require().Len(st.Details(), 1)
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	suite.Require().True(ok)
+	var fields []string
+	for _, v := range badRequest.GetFieldViolations() {
+		fields = append(fields, v.GetField())
+	}
+	suite.Equal([]string{"name", "versions"}, fields)
+	suite.usecase.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *CatalogServerSuite) Test_CreateService_DelegatesToUsecase() {
+	suite.usecase.On("Create", mock.Anything, &dto.ServiceDTO{
+		Name:     "billing",
+		Versions: []models.Version{{VersionNumber: "1.0.0"}},
+	}).Return(&dto.ServiceDTO{ID: "svc-1", Name: "billing", Versions: []models.Version{{VersionNumber: "1.0.0"}}}, nil)
+
+	got, err := suite.client.CreateService(context.Background(), &catalogpb.CreateServiceRequest{
+		Name:     "billing",
+		Versions: []*catalogpb.Version{{VersionNumber: "1.0.0"}},
+	})
+
+	suite.Require().NoError(err)
+	suite.Equal("svc-1", got.GetId())
+	suite.usecase.AssertExpectations(suite.T())
+}
+
+func (suite *CatalogServerSuite) Test_SearchServices_DefaultsAndNextPage() {
+	suite.usecase.On("Search", mock.Anything, "bill", 2, 1).Return([]*dto.ServiceDTO{{ID: "svc-2"}}, 3, nil)
+	suite.usecase.On("Search", mock.Anything, "", 1, 10).Return([]*dto.ServiceDTO{{ID: "svc-1"}}, 1, nil)
+
+	got, err := suite.client.SearchServices(context.Background(), &catalogpb.SearchServicesRequest{Query: "bill", Page: 2, Limit: 1})
+	suite.Require().NoError(err)
+	suite.Equal(int32(3), got.GetCount())
+	suite.Equal(int32(3), got.GetNextPage())
+
+	got, err = suite.client.SearchServices(context.Background(), &catalogpb.SearchServicesRequest{})
+	suite.Require().NoError(err)
+	suite.Equal(int32(0), got.GetNextPage())
+
+	_, err = suite.client.SearchServices(context.Background(), &catalogpb.SearchServicesRequest{Page: -1})
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (suite *CatalogServerSuite) Test_RecoversFromPanics() {
+	suite.usecase.On("FindByID", mock.Anything, "boom").Run(func(mock.Arguments) { panic("boom") })
+
+	_, err := suite.client.GetService(context.Background(), &catalogpb.GetServiceRequest{Id: "boom"})
+	suite.Equal(codes.Internal, status.Code(err))
+}
@@ -0,0 +1,45 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	"catalog-service/internal/dto"
+	"catalog-service/internal/usecase"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func toStatus(err error, message string) error {
+	switch {
+	case errors.Is(err, usecase.ErrServiceNotFound):
+		return status.Error(codes.NotFound, "service not found")
+	case errors.Is(err, usecase.ErrVersionNotFound):
+		return status.Error(codes.NotFound, "version not found")
+	case errors.Is(err, usecase.ErrUnavailable):
+		return status.Error(codes.Unavailable, "storage is temporarily unavailable")
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, message)
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, message)
+	default:
+		return status.Error(codes.Internal, message)
+	}
+}
+
+func invalidArgument(errs []dto.ErrorObj) error {
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(errs))
+	for _, e := range errs {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       e.Entity,
+			Description: e.Cause,
+		})
+	}
+	st := status.New(codes.InvalidArgument, "invalid request")
+	if detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
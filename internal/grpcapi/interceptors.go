@@ -0,0 +1,96 @@
+package grpcapi
+
+import (
+	"context"
+	"strings"
+
+	"catalog-service/internal/appcontext"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/middleware"
+	"catalog-service/internal/tracing"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var CorrelationIDMetadataKey = strings.ToLower(middleware.CorrelationIDKeyHeader)
+
+func CorrelationIDInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		var correlationID string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(CorrelationIDMetadataKey); len(values) > 0 {
+				correlationID = values[0]
+			}
+		}
+		if correlationID == "" {
+			correlationID = uuid.New().String()
+		}
+		ctx = context.WithValue(ctx, appcontext.CorrelationIDKey, correlationID)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(CorrelationIDMetadataKey, correlationID))
+		return handler(ctx, req)
+	}
+}
+
+// TracingInterceptor continues the trace of the incoming traceparent metadata,
+// or starts a new one, in a server span named after the method.
+func TracingInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+		ctx, span := tracing.Tracer().Start(ctx, strings.TrimPrefix(info.FullMethod, "/"),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attribute.String("rpc.system", "grpc"), attribute.String("rpc.method", info.FullMethod)))
+		defer span.End()
+
+		resp, err := handler(ctx, req)
+		code := status.Code(err)
+		span.SetAttributes(attribute.String("rpc.grpc.status_code", code.String()))
+		if code == codes.Internal || code == codes.Unavailable || code == codes.Unknown {
+			span.SetStatus(otelcodes.Error, status.Convert(err).Message())
+		}
+		return resp, err
+	}
+}
+
+// metadataCarrier reads trace context from gRPC metadata.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if values := metadata.MD(c).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func PanicRecoveryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				log := logger.NewContextLogger(ctx, "PanicRecoveryInterceptor")
+				log.Errorf(nil, "panic recovered in %s: %v", info.FullMethod, r)
+				err = status.Error(codes.Internal, "internal server error")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
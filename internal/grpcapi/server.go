@@ -0,0 +1,20 @@
+package grpcapi
+
+import (
+	"catalog-service/internal/grpcapi/catalogpb"
+	"catalog-service/internal/usecase"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+func NewServer(serviceUsecase usecase.ServiceUsecase) *grpc.Server {
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		TracingInterceptor(),
+		CorrelationIDInterceptor(),
+		PanicRecoveryInterceptor(),
+	))
+	catalogpb.RegisterCatalogServiceServer(srv, NewCatalogServer(serviceUsecase))
+	reflection.Register(srv)
+	return srv
+}
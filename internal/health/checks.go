@@ -0,0 +1,54 @@
+package health
+
+import (
+	"context"
+	"fmt"
+
+	"catalog-service/internal/opensearch"
+)
+
+// ClusterHealth is down when the cluster cannot be reached or its status is
+// red. A yellow cluster, e.g. a single node with replicas configured, is up.
+func ClusterHealth(client opensearch.Client) Check {
+	return Check{Name: "opensearch", Run: func(ctx context.Context) (string, error) {
+		health, err := client.ClusterHealth(ctx)
+		if err != nil {
+			return "", err
+		}
+		detail := fmt.Sprintf("cluster %s is %s with %d node(s)", health.ClusterName, health.Status, health.NumberOfNodes)
+		if health.Status == "red" {
+			return detail, fmt.Errorf("cluster status is red")
+		}
+		return detail, nil
+	}}
+}
+
+// IndexExists is down when no index or alias named index exists.
+func IndexExists(client opensearch.Client, index string) Check {
+	return Check{Name: "index:" + index, Run: func(context.Context) (string, error) {
+		exists, err := client.IndexExists(index)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return "", fmt.Errorf("index or alias %s does not exist", index)
+		}
+		return fmt.Sprintf("index or alias %s exists", index), nil
+	}}
+}
+
+// MigrationVersion is down until migration version minimum or later has been
+// applied. current reads the applied version from the migration history.
+func MigrationVersion(current func() (int, error), minimum int) Check {
+	return Check{Name: "migrations", Run: func(context.Context) (string, error) {
+		version, err := current()
+		if err != nil {
+			return "", err
+		}
+		detail := fmt.Sprintf("migration version %d applied, %d required", version, minimum)
+		if version < minimum {
+			return detail, fmt.Errorf("migration version %d is older than required version %d", version, minimum)
+		}
+		return detail, nil
+	}}
+}
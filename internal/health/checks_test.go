@@ -0,0 +1,64 @@
+package health
+
+import (
+	"context"
+	"testing"
+
+	"catalog-service/internal/opensearch"
+	mockos "catalog-service/test/mocks/opensearch"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ChecksSuite struct {
+	suite.Suite
+	client *mockos.Client
+}
+
+func TestChecksSuite(t *testing.T) {
+	suite.Run(t, new(ChecksSuite))
+}
+
+func (suite *ChecksSuite) SetupTest() {
+	suite.client = new(mockos.Client)
+}
+
+func (suite *ChecksSuite) Test_ClusterHealth_DownOnlyWhenRedOrUnreachable() {
+	check := ClusterHealth(suite.client)
+	suite.client.On("ClusterHealth", mock.Anything).Return(&opensearch.ClusterHealth{ClusterName: "catalog", Status: "yellow", NumberOfNodes: 1}, nil).Once()
+	suite.client.On("ClusterHealth", mock.Anything).Return(&opensearch.ClusterHealth{ClusterName: "catalog", Status: "red", NumberOfNodes: 1}, nil).Once()
+	suite.client.On("ClusterHealth", mock.Anything).Return(nil, assert.AnError).Once()
+
+	detail, err := check.Run(context.Background())
+	suite.NoError(err)
+	suite.Equal("cluster catalog is yellow with 1 node(s)", detail)
+
+	_, err = check.Run(context.Background())
+	suite.ErrorContains(err, "red")
+
+	_, err = check.Run(context.Background())
+	suite.ErrorIs(err, assert.AnError)
+}
+
+func (suite *ChecksSuite) Test_IndexExists() {
+	suite.client.On("IndexExists", "services").Return(true, nil)
+	suite.client.On("IndexExists", "missing").Return(false, nil)
+
+	_, err := IndexExists(suite.client, "services").Run(context.Background())
+	suite.NoError(err)
+	_, err = IndexExists(suite.client, "missing").Run(context.Background())
+	suite.ErrorContains(err, "missing")
+}
+
+func (suite *ChecksSuite) Test_MigrationVersion() {
+	version := func(v int) func() (int, error) { return func() (int, error) { return v, nil } }
+
+	_, err := MigrationVersion(version(2), 2).Run(context.Background())
+	suite.NoError(err)
+	_, err = MigrationVersion(version(0), 1).Run(context.Background())
+	suite.ErrorContains(err, "older than required version 1")
+	_, err = MigrationVersion(func() (int, error) { return 0, assert.AnError }, 1).Run(context.Background())
+	suite.ErrorIs(err, assert.AnError)
+}
@@ -0,0 +1,126 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"catalog-service/internal/dto"
+	"catalog-service/internal/logger"
+)
+
+const (
+	StatusUp       = "up"
+	StatusDown     = "down"
+	StatusDraining = "draining"
+)
+
+// Check reports on one dependency. Run returns a short description of what it
+// found, or an error when the dependency is not usable.
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) (string, error)
+}
+
+type Options struct {
+	// CacheTTL is how long a report is served before the checks run again.
+	CacheTTL time.Duration
+	// Timeout bounds each check; a check that does not finish in time is down.
+	Timeout time.Duration
+}
+
+// Readiness runs the checks that decide whether the API can serve traffic.
+// Reports are cached for CacheTTL, so frequent probes do not load OpenSearch,
+// and concurrent probes share one run.
+type Readiness struct {
+	checks []Check
+	opts   Options
+
+	draining atomic.Bool
+
+	mu     sync.Mutex
+	report *dto.HealthReport
+}
+
+func NewReadiness(opts Options, checks ...Check) *Readiness {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 2 * time.Second
+	}
+	return &Readiness{checks: checks, opts: opts}
+}
+
+// Drain marks the API as not ready for the rest of its life. It is called when
+// graceful shutdown starts, so load balancers stop sending new requests.
+func (r *Readiness) Drain() {
+	r.draining.Store(true)
+}
+
+// Report returns the latest report, running the checks when the cached one is
+// older than CacheTTL. Its status is up only when every check is up.
+func (r *Readiness) Report(ctx context.Context) *dto.HealthReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.report == nil || time.Since(r.report.CheckedAt) >= r.opts.CacheTTL {
+		r.report = r.run(context.WithoutCancel(ctx))
+	}
+
+	report := *r.report
+	if r.draining.Load() {
+		report.Status = StatusDraining
+	}
+	return &report
+}
+
+func (r *Readiness) run(ctx context.Context) *dto.HealthReport {
+	results := make([]dto.HealthCheckDTO, len(r.checks))
+	var wg sync.WaitGroup
+	for i, check := range r.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = r.runCheck(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	report := &dto.HealthReport{Status: StatusUp, Checks: results, CheckedAt: time.Now()}
+	for _, result := range results {
+		if result.Status != StatusUp {
+			report.Status = StatusDown
+			logger.NewContextLogger(ctx, "Readiness/Report").Warnf("readiness check %s failed: %s", result.Name, result.Error)
+		}
+	}
+	return report
+}
+
+func (r *Readiness) runCheck(ctx context.Context, check Check) dto.HealthCheckDTO {
+	ctx, cancel := context.WithTimeout(ctx, r.opts.Timeout)
+	defer cancel()
+
+	type outcome struct {
+		detail string
+		err    error
+	}
+	done := make(chan outcome, 1)
+	start := time.Now()
+	go func() {
+		detail, err := check.Run(ctx)
+		done <- outcome{detail: detail, err: err}
+	}()
+
+	result := dto.HealthCheckDTO{Name: check.Name, Status: StatusUp}
+	select {
+	case o := <-done:
+		result.Detail = o.detail
+		if o.err != nil {
+			result.Status = StatusDown
+			result.Error = o.err.Error()
+		}
+	case <-ctx.Done():
+		result.Status = StatusDown
+		result.Error = "check timed out after " + r.opts.Timeout.String()
+	}
+	result.DurationMS = time.Since(start).Milliseconds()
+	return result
+}
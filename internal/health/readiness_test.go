@@ -0,0 +1,89 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"catalog-service/internal/config"
+	"catalog-service/internal/logger"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ReadinessSuite struct {
+	suite.Suite
+}
+
+func TestReadinessSuite(t *testing.T) {
+	suite.Run(t, new(ReadinessSuite))
+}
+
+func (suite *ReadinessSuite) SetupTest() {
+	config.Load()
+	logger.Setup("INFO", "json")
+}
+
+func counting(name string, runs *atomic.Int32, err error) Check {
+	return Check{Name: name, Run: func(context.Context) (string, error) {
+		runs.Add(1)
+		return name + " checked", err
+	}}
+}
+
+func (suite *ReadinessSuite) Test_UpOnlyWhenEveryCheckIsUp() {
+	var runs atomic.Int32
+	readiness := NewReadiness(Options{}, counting("a", &runs, nil), counting("b", &runs, errors.New("unreachable")))
+
+	report := readiness.Report(context.Background())
+
+	suite.Equal(StatusDown, report.Status)
+	suite.Require().Len(report.Checks, 2)
+	suite.Equal("a", report.Checks[0].Name)
+	suite.Equal(StatusUp, report.Checks[0].Status)
+	suite.Equal("a checked", report.Checks[0].Detail)
+	suite.Equal(StatusDown, report.Checks[1].Status)
+	suite.Equal("unreachable", report.Checks[1].Error)
+}
+
+func (suite *ReadinessSuite) Test_CachesReportForTTL() {
+	var runs atomic.Int32
+	readiness := NewReadiness(Options{CacheTTL: time.Hour}, counting("a", &runs, nil))
+
+	first := readiness.Report(context.Background())
+	second := readiness.Report(context.Background())
+
+	suite.Equal(StatusUp, second.Status)
+	suite.Equal(first.CheckedAt, second.CheckedAt)
+	suite.Equal(int32(1), runs.Load())
+}
+
+func (suite *ReadinessSuite) Test_SlowCheckIsDown() {
+	slow := Check{Name: "slow", Run: func(ctx context.Context) (string, error) {
+		<-ctx.Done()
+		time.Sleep(50 * time.Millisecond)
+		return "", nil
+	}}
+	readiness := NewReadiness(Options{Timeout: 10 * time.Millisecond}, slow)
+
+	start := time.Now()
+	report := readiness.Report(context.Background())
+
+	suite.Less(time.Since(start), 50*time.Millisecond)
+	suite.Equal(StatusDown, report.Status)
+	suite.Contains(report.Checks[0].Error, "timed out")
+}
+
+func (suite *ReadinessSuite) Test_DrainingOverridesChecks() {
+	var runs atomic.Int32
+	readiness := NewReadiness(Options{CacheTTL: time.Hour}, counting("a", &runs, nil))
+	suite.Equal(StatusUp, readiness.Report(context.Background()).Status)
+
+	readiness.Drain()
+
+	report := readiness.Report(context.Background())
+	suite.Equal(StatusDraining, report.Status)
+	suite.Equal(StatusUp, report.Checks[0].Status, "check results are still reported")
+}
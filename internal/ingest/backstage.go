@@ -0,0 +1,50 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const backstageAPIVersionPrefix = "backstage.io/"
+
+var backstageKinds = map[string]bool{
+	"component": true,
+	"api":       true,
+}
+
+type backstageDecoder struct {
+	mapping Mapping
+}
+
+func (d *backstageDecoder) Decode(ctx context.Context, r io.Reader, source string, from Position, emit EmitFunc) error {
+	index := 0
+	return decodeYAMLDocuments(ctx, r, func(doc interface{}) error {
+		index++
+		if index <= from.Index {
+			return nil
+		}
+		rec := Record{Source: source, Index: index}
+		rec.Raw, _ = json.Marshal(doc)
+
+		entity, ok := doc.(map[string]interface{})
+		if !ok {
+			rec.Err = fmt.Errorf("expected a Backstage entity, got %T", doc)
+			return emit(rec)
+		}
+		apiVersion, _ := entity["apiVersion"].(string)
+		if !strings.HasPrefix(apiVersion, backstageAPIVersionPrefix) {
+			rec.Err = fmt.Errorf("unsupported apiVersion %q", apiVersion)
+			return emit(rec)
+		}
+		kind, _ := entity["kind"].(string)
+		if !backstageKinds[strings.ToLower(kind)] {
+			return emit(rec)
+		}
+
+		rec.Service, rec.Err = d.mapping.Apply(entity)
+		return emit(rec)
+	})
+}
@@ -0,0 +1,89 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type csvDecoder struct {
+	mapping Mapping
+}
+
+func (d *csvDecoder) Decode(ctx context.Context, r io.Reader, source string, from Position, emit EmitFunc) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff"))
+	}
+
+	for index := 1; ; index++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if index <= from.Index {
+			continue
+		}
+
+		rec := Record{Source: source, Index: index, Offset: reader.InputOffset(), Raw: encodeCSVRow(row)}
+		var parseErr *csv.ParseError
+		switch {
+		case errors.As(err, &parseErr):
+			rec.Err = parseErr
+		case err != nil:
+			return err
+		case !isBlankRow(row):
+			rec.Service, rec.Err = d.mapping.Apply(rowDocument(header, row))
+		}
+		if err := emit(rec); err != nil {
+			return err
+		}
+	}
+}
+
+func rowDocument(header, row []string) map[string]interface{} {
+	doc := make(map[string]interface{}, len(header))
+	for i, column := range header {
+		if i < len(row) && row[i] != "" {
+			doc[column] = row[i]
+		}
+	}
+	return doc
+}
+
+func isBlankRow(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func encodeCSVRow(row []string) []byte {
+	if row == nil {
+		return nil
+	}
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write(row)
+	w.Flush()
+	return bytes.TrimRight(buf.Bytes(), "\n")
+}
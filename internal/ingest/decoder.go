@@ -0,0 +1,136 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"catalog-service/internal/models"
+)
+
+type Format string
+
+const (
+	FormatJSONL     Format = "jsonl"
+	FormatJSON      Format = "json"
+	FormatCSV       Format = "csv"
+	FormatYAML      Format = "yaml"
+	FormatBackstage Format = "backstage"
+	FormatOpenAPI   Format = "openapi"
+
+	StdinSource = "-"
+)
+
+var formatExtensions = map[Format][]string{
+	FormatJSONL:     {".jsonl", ".ndjson"},
+	FormatJSON:      {".json"},
+	FormatCSV:       {".csv"},
+	FormatYAML:      {".yaml", ".yml"},
+	FormatBackstage: {".yaml", ".yml"},
+	FormatOpenAPI:   {".yaml", ".yml", ".json"},
+}
+
+type Position struct {
+	Index  int
+	Offset int64
+}
+
+type Record struct {
+	Source  string
+	Index   int
+	Offset  int64
+	Raw     []byte
+	Service *models.Service
+	Err     error
+}
+
+func (r Record) Skipped() bool {
+	return r.Service == nil && r.Err == nil
+}
+
+type EmitFunc func(Record) error
+
+type Decoder interface {
+	Decode(ctx context.Context, r io.Reader, source string, from Position, emit EmitFunc) error
+}
+
+func NewDecoder(format Format, mapping Mapping) (Decoder, error) {
+	if err := mapping.Validate(); err != nil {
+		return nil, err
+	}
+	switch format {
+	case FormatJSONL:
+		return &jsonlDecoder{mapping: mapping}, nil
+	case FormatJSON:
+		return &jsonDecoder{mapping: mapping}, nil
+	case FormatCSV:
+		return &csvDecoder{mapping: defaultCSVMapping.Merge(mapping)}, nil
+	case FormatYAML:
+		return &yamlDecoder{mapping: mapping}, nil
+	case FormatBackstage:
+		return &backstageDecoder{mapping: defaultBackstageMapping.Merge(mapping)}, nil
+	case FormatOpenAPI:
+		return &openapiDecoder{}, nil
+	}
+	return nil, fmt.Errorf("unsupported format %q", format)
+}
+
+func Sources(path string, format Format) ([]string, error) {
+	if path == StdinSource {
+		return []string{StdinSource}, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var sources []string
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != path && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if matchesFormat(d.Name(), format) {
+			sources = append(sources, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk %s: %w", path, err)
+	}
+	return sources, nil
+}
+
+func Open(source string) (io.ReadCloser, error) {
+	if source == StdinSource {
+		return io.NopCloser(os.Stdin), nil
+	}
+	return os.Open(source)
+}
+
+func matchesFormat(name string, format Format) bool {
+	if format == FormatBackstage {
+		base := strings.TrimSuffix(strings.TrimSuffix(name, ".yaml"), ".yml")
+		return base != name && base == "catalog-info"
+	}
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, e := range formatExtensions[format] {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,208 @@
+package ingest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DecoderTestSuite struct {
+	suite.Suite
+	dir string
+}
+
+func TestDecoderSuite(t *testing.T) {
+	suite.Run(t, new(DecoderTestSuite))
+}
+
+func (s *DecoderTestSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+}
+
+func (s *DecoderTestSuite) writeFile(rel, content string) string {
+	path := filepath.Join(s.dir, rel)
+	s.Require().NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+	s.Require().NoError(os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func (s *DecoderTestSuite) decode(format Format, mapping Mapping, input string, from Position) []Record {
+	dec, err := NewDecoder(format, mapping)
+	s.Require().NoError(err)
+	var records []Record
+	err = dec.Decode(context.Background(), strings.NewReader(input), "input", from, func(rec Record) error {
+		records = append(records, rec)
+		return nil
+	})
+	s.Require().NoError(err)
+	return records
+}
+
+func (s *DecoderTestSuite) Test_JSONL_DecodesLinesAndReportsErrors() {
+	input := "{\"name\":\"a\",\"versions\":[{\"version_number\":\"1.0.0\"}]}\n\nnot json\n{\"name\":\"b\"}\n"
+	records := s.decode(FormatJSONL, nil, input, Position{})
+
+	s.Require().Len(records, 4)
+	s.Equal("a", records[0].Service.Name)
+	s.Equal("1.0.0", records[0].Service.Versions[0].VersionNumber)
+	s.True(records[1].Skipped())
+	s.Error(records[2].Err)
+	s.Equal("not json", string(records[2].Raw))
+	s.Equal(4, records[3].Index)
+	s.Equal(int64(len(input)), records[3].Offset)
+}
+
+func (s *DecoderTestSuite) Test_JSONL_ResumesFromOffset() {
+	path := s.writeFile("data.jsonl", "{\"name\":\"a\"}\n{\"name\":\"b\"}\n")
+	f, err := os.Open(path)
+	s.Require().NoError(err)
+	defer f.Close()
+
+	dec, _ := NewDecoder(FormatJSONL, nil)
+	var names []string
+	err = dec.Decode(context.Background(), f, path, Position{Index: 1, Offset: 13}, func(rec Record) error {
+		names = append(names, rec.Service.Name)
+		s.Equal(2, rec.Index)
+		return nil
+	})
+	s.Require().NoError(err)
+	s.Equal([]string{"b"}, names)
+}
+
+func (s *DecoderTestSuite) Test_JSON_DecodesArrayAndSingleObject() {
+	records := s.decode(FormatJSON, nil, `[{"name":"a"}, 42, {"name":"b"}]`, Position{Index: 0})
+	s.Require().Len(records, 3)
+	s.Equal("a", records[0].Service.Name)
+	s.Error(records[1].Err)
+	s.Equal("b", records[2].Service.Name)
+
+	records = s.decode(FormatJSON, nil, `[{"name":"a"},{"name":"b"}]`, Position{Index: 1})
+	s.Require().Len(records, 1)
+	s.Equal("b", records[0].Service.Name)
+
+	records = s.decode(FormatJSON, nil, ` {"name":"single"}`, Position{})
+	s.Require().Len(records, 1)
+	s.Equal("single", records[0].Service.Name)
+}
+
+func (s *DecoderTestSuite) Test_CSV_UsesHeaderAndMapping() {
+	input := "Service Name,Summary,version_number,details\nbilling,Handles invoices,1.2.0,initial\n,,,\n"
+	records := s.decode(FormatCSV, Mapping{FieldName: "Service Name", FieldDescription: "Summary"}, input, Position{})
+
+	s.Require().Len(records, 2)
+	svc := records[0].Service
+	s.Equal("billing", svc.Name)
+	s.Equal("Handles invoices", svc.Description)
+	s.Require().Len(svc.Versions, 1)
+	s.Equal("1.2.0", svc.Versions[0].VersionNumber)
+	s.Equal("initial", svc.Versions[0].Details)
+	s.Equal("billing,Handles invoices,1.2.0,initial", string(records[0].Raw))
+	s.True(records[1].Skipped())
+}
+
+func (s *DecoderTestSuite) Test_YAML_DecodesDocumentsAndLists() {
+	input := "name: a\ndescription: first\n---\n- name: b\n- name: c\n  versions:\n    - version_number: 2.0.0\n"
+	records := s.decode(FormatYAML, nil, input, Position{})
+
+	s.Require().Len(records, 3)
+	s.Equal("first", records[0].Service.Description)
+	s.Equal("b", records[1].Service.Name)
+	s.Equal("2.0.0", records[2].Service.Versions[0].VersionNumber)
+	s.Equal(3, records[2].Index)
+}
+
+func (s *DecoderTestSuite) Test_YAML_AppliesNestedMapping() {
+	input := "service:\n  title: billing\n  info:\n    summary: Handles invoices\n"
+	records := s.decode(FormatYAML, Mapping{FieldName: "service.title", FieldDescription: "service.info.summary"}, input, Position{})
+
+	s.Require().Len(records, 1)
+	s.Equal("billing", records[0].Service.Name)
+	s.Equal("Handles invoices", records[0].Service.Description)
+}
+
+func (s *DecoderTestSuite) Test_Backstage_MapsComponentsAndSkipsOtherKinds() {
+	input := `apiVersion: backstage.io/v1alpha1
+kind: Component
+metadata:
+  name: billing
+  description: Handles invoices
+  annotations:
+    catalog-service/version: 1.4.0
+spec:
+  lifecycle: production
+---
+apiVersion: backstage.io/v1alpha1
+kind: Group
+metadata:
+  name: team-a
+---
+apiVersion: example.com/v1
+kind: Component
+metadata:
+  name: other
+`
+	records := s.decode(FormatBackstage, nil, input, Position{})
+
+	s.Require().Len(records, 3)
+	svc := records[0].Service
+	s.Equal("billing", svc.Name)
+	s.Equal("Handles invoices", svc.Description)
+	s.Require().Len(svc.Versions, 1)
+	s.Equal("1.4.0", svc.Versions[0].VersionNumber)
+	s.Equal("production", svc.Versions[0].Details)
+	s.True(records[1].Skipped())
+	s.ErrorContains(records[2].Err, "unsupported apiVersion")
+}
+
+func (s *DecoderTestSuite) Test_Sources_WalksDirectoryForFormat() {
+	s.writeFile("a/catalog-info.yaml", "")
+	s.writeFile("b/catalog-info.yml", "")
+	s.writeFile("b/other.yaml", "")
+	s.writeFile(".git/catalog-info.yaml", "")
+
+	sources, err := Sources(s.dir, FormatBackstage)
+	s.Require().NoError(err)
+	s.Equal([]string{
+		filepath.Join(s.dir, "a/catalog-info.yaml"),
+		filepath.Join(s.dir, "b/catalog-info.yml"),
+	}, sources)
+
+	sources, err = Sources(s.dir, FormatYAML)
+	s.Require().NoError(err)
+	s.Len(sources, 3)
+}
+
+func (s *DecoderTestSuite) Test_Mapping_RejectsUnknownTargets() {
+	_, err := ParseMapping("name=Service Name,owner=Team")
+	s.ErrorContains(err, "unknown mapping targets: owner")
+
+	_, err = ParseMapping("name")
+	s.Error(err)
+
+	m, err := ParseMapping("name = Service Name")
+	s.Require().NoError(err)
+	s.Equal(Mapping{FieldName: "Service Name"}, m)
+}
+
+func (s *DecoderTestSuite) Test_OpenAPI_EmitsWholeDocumentAndSkipsOtherFiles() {
+	content := "openapi: 3.0.0\ninfo:\n  title: billing\n  version: 1.0.0\npaths: {}\n"
+	records := s.decode(FormatOpenAPI, nil, content, Position{})
+	s.Require().Len(records, 1)
+	s.Equal(content, string(records[0].Raw))
+	s.Equal("billing", records[0].Service.Name)
+	s.Equal("1.0.0", records[0].Service.Versions[0].VersionNumber)
+
+	records = s.decode(FormatOpenAPI, nil, "kind: Component\n", Position{})
+	s.Require().Len(records, 1)
+	s.True(records[0].Skipped())
+
+	records = s.decode(FormatOpenAPI, nil, "openapi: 3.0.0\ninfo: {}\n", Position{})
+	s.Require().Len(records, 1)
+	s.Error(records[0].Err)
+
+	s.Empty(s.decode(FormatOpenAPI, nil, content, Position{Index: 1}))
+}
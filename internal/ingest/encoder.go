@@ -0,0 +1,153 @@
+package ingest
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"catalog-service/internal/models"
+
+	"gopkg.in/yaml.v3"
+)
+
+var contentTypes = map[Format]string{
+	FormatJSONL: "application/x-ndjson",
+	FormatJSON:  "application/json",
+	FormatCSV:   "text/csv",
+	FormatYAML:  "application/yaml",
+}
+
+var csvColumns = []string{FieldID, FieldName, FieldDescription, FieldCreatedAt, FieldVersionNumber, FieldDetails}
+
+type Encoder interface {
+	Encode(service *models.Service) error
+	Close() error
+}
+
+func NewEncoder(format Format, w io.Writer) (Encoder, error) {
+	switch format {
+	case FormatJSONL:
+		return &jsonlEncoder{enc: json.NewEncoder(w)}, nil
+	case FormatJSON:
+		return &jsonEncoder{w: w}, nil
+	case FormatCSV:
+		return &csvEncoder{w: csv.NewWriter(w)}, nil
+	case FormatYAML:
+		return &yamlEncoder{enc: yaml.NewEncoder(w)}, nil
+	}
+	return nil, fmt.Errorf("unsupported export format %q", format)
+}
+
+func ContentType(format Format) string {
+	return contentTypes[format]
+}
+
+func Extension(format Format) string {
+	if exts := formatExtensions[format]; len(exts) > 0 {
+		return exts[0]
+	}
+	return ""
+}
+
+type jsonlEncoder struct {
+	enc *json.Encoder
+}
+
+func (e *jsonlEncoder) Encode(service *models.Service) error {
+	return e.enc.Encode(service)
+}
+
+func (e *jsonlEncoder) Close() error {
+	return nil
+}
+
+type jsonEncoder struct {
+	w     io.Writer
+	count int
+}
+
+func (e *jsonEncoder) Encode(service *models.Service) error {
+	b, err := json.Marshal(service)
+	if err != nil {
+		return err
+	}
+	sep := ",\n"
+	if e.count == 0 {
+		sep = "[\n"
+	}
+	e.count++
+	_, err = e.w.Write(append([]byte(sep), b...))
+	return err
+}
+
+func (e *jsonEncoder) Close() error {
+	end := "\n]\n"
+	if e.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(e.w, end)
+	return err
+}
+
+type csvEncoder struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func (e *csvEncoder) Encode(service *models.Service) error {
+	if !e.headerWritten {
+		if err := e.w.Write(csvColumns); err != nil {
+			return err
+		}
+		e.headerWritten = true
+	}
+
+	createdAt := ""
+	if !service.CreatedAt.IsZero() {
+		createdAt = service.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+	versions := service.Versions
+	if len(versions) == 0 {
+		versions = []models.Version{{}}
+	}
+	for _, v := range versions {
+		row := []string{service.ID, service.Name, service.Description, createdAt, v.VersionNumber, v.Details}
+		if err := e.w.Write(row); err != nil {
+			return err
+		}
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvEncoder) Close() error {
+	if !e.headerWritten {
+		if err := e.w.Write(csvColumns); err != nil {
+			return err
+		}
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type yamlEncoder struct {
+	enc *yaml.Encoder
+}
+
+func (e *yamlEncoder) Encode(service *models.Service) error {
+	b, err := json.Marshal(service)
+	if err != nil {
+		return err
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return err
+	}
+	return e.enc.Encode(doc)
+}
+
+func (e *yamlEncoder) Close() error {
+	return e.enc.Close()
+}
@@ -0,0 +1,61 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"catalog-service/internal/models"
+)
+
+func (s *DecoderTestSuite) Test_Encoders_RoundTripThroughDecoders() {
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	services := []*models.Service{
+		{ID: "1", Name: "billing", Description: "Handles, invoices", CreatedAt: created, Versions: []models.Version{
+			{VersionNumber: "1.0.0", Details: "initial"},
+			{VersionNumber: "1.1", Details: "patch"},
+		}},
+		{ID: "2", Name: "search", CreatedAt: created},
+	}
+
+	for _, format := range []Format{FormatJSONL, FormatJSON, FormatCSV, FormatYAML} {
+		var buf bytes.Buffer
+		enc, err := NewEncoder(format, &buf)
+		s.Require().NoError(err)
+		for _, svc := range services {
+			s.Require().NoError(enc.Encode(svc))
+		}
+		s.Require().NoError(enc.Close())
+
+		dec, err := NewDecoder(format, nil)
+		s.Require().NoError(err)
+		byName := map[string]*models.Service{}
+		err = dec.Decode(context.Background(), &buf, "export", Position{}, func(rec Record) error {
+			s.Require().NoError(rec.Err, "format %s", format)
+			if rec.Service == nil {
+				return nil
+			}
+			if existing, ok := byName[rec.Service.Name]; ok {
+				existing.MergeVersions(rec.Service.Versions)
+				return nil
+			}
+			byName[rec.Service.Name] = rec.Service
+			return nil
+		})
+		s.Require().NoError(err, "format %s", format)
+
+		s.Require().Len(byName, 2, "format %s", format)
+		billing := byName["billing"]
+		s.Equal("1", billing.ID, "format %s", format)
+		s.Equal("Handles, invoices", billing.Description, "format %s", format)
+		s.True(created.Equal(billing.CreatedAt), "format %s", format)
+		s.Equal(services[0].Versions, billing.Versions, "format %s", format)
+		s.Empty(byName["search"].Versions, "format %s", format)
+	}
+}
+
+func (s *DecoderTestSuite) Test_NewEncoder_RejectsImportOnlyFormats() {
+	_, err := NewEncoder(FormatBackstage, &bytes.Buffer{})
+	s.Error(err)
+	s.Empty(ContentType(FormatBackstage))
+}
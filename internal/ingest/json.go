@@ -0,0 +1,82 @@
+package ingest
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+type jsonDecoder struct {
+	mapping Mapping
+}
+
+func (d *jsonDecoder) Decode(ctx context.Context, r io.Reader, source string, from Position, emit EmitFunc) error {
+	br := bufio.NewReader(r)
+	first, err := peekNonSpace(br)
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(br)
+	if first != '[' {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return fmt.Errorf("invalid JSON document: %w", err)
+		}
+		if from.Index >= 1 {
+			return nil
+		}
+		return emit(d.record(source, 1, dec.InputOffset(), raw))
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("invalid JSON array: %w", err)
+	}
+	for index := 1; dec.More(); index++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return fmt.Errorf("invalid JSON array element %d: %w", index, err)
+		}
+		if index <= from.Index {
+			continue
+		}
+		if err := emit(d.record(source, index, dec.InputOffset(), raw)); err != nil {
+			return err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("invalid JSON array: %w", err)
+	}
+	return nil
+}
+
+func (d *jsonDecoder) record(source string, index int, offset int64, raw json.RawMessage) Record {
+	rec := Record{Source: source, Index: index, Offset: offset, Raw: raw}
+	var doc interface{}
+	if rec.Err = json.Unmarshal(raw, &doc); rec.Err == nil {
+		rec.Service, rec.Err = decodeDocument(doc, d.mapping)
+	}
+	return rec
+}
+
+func peekNonSpace(br *bufio.Reader) (byte, error) {
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		return b, br.UnreadByte()
+	}
+}
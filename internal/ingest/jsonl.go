@@ -0,0 +1,73 @@
+package ingest
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"catalog-service/internal/models"
+)
+
+const maxLineBytes = 1024 * 1024
+
+type jsonlDecoder struct {
+	mapping Mapping
+}
+
+func (d *jsonlDecoder) Decode(ctx context.Context, r io.Reader, source string, from Position, emit EmitFunc) error {
+	offset, index := int64(0), 0
+	if seeker, ok := r.(io.Seeker); ok && from.Offset > 0 {
+		if _, err := seeker.Seek(from.Offset, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to seek to offset %d: %w", from.Offset, err)
+		}
+		offset, index = from.Offset, from.Index
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineBytes)
+	var advance int
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		n, token, err := bufio.ScanLines(data, atEOF)
+		advance = n
+		return n, token, err
+	})
+
+	for scanner.Scan() {
+		index++
+		offset += int64(advance)
+		if index <= from.Index {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		line := append([]byte(nil), scanner.Bytes()...)
+		rec := Record{Source: source, Index: index, Offset: offset, Raw: line}
+		if len(line) > 0 {
+			rec.Service, rec.Err = d.decodeLine(line)
+		}
+		if err := emit(rec); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func (d *jsonlDecoder) decodeLine(line []byte) (*models.Service, error) {
+	if len(d.mapping) == 0 {
+		var service models.Service
+		if err := json.Unmarshal(line, &service); err != nil {
+			return nil, err
+		}
+		return &service, nil
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(line, &doc); err != nil {
+		return nil, err
+	}
+	return decodeDocument(doc, d.mapping)
+}
@@ -0,0 +1,183 @@
+package ingest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"catalog-service/internal/models"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	FieldID            = "id"
+	FieldName          = "name"
+	FieldDescription   = "description"
+	FieldCreatedAt     = "created_at"
+	FieldVersions      = "versions"
+	FieldVersionNumber = "version_number"
+	FieldDetails       = "details"
+)
+
+var mappingTargets = map[string]bool{
+	FieldID:            true,
+	FieldName:          true,
+	FieldDescription:   true,
+	FieldCreatedAt:     true,
+	FieldVersions:      true,
+	FieldVersionNumber: true,
+	FieldDetails:       true,
+}
+
+type Mapping map[string]string
+
+var defaultCSVMapping = Mapping{
+	FieldID:            FieldID,
+	FieldName:          FieldName,
+	FieldDescription:   FieldDescription,
+	FieldCreatedAt:     FieldCreatedAt,
+	FieldVersionNumber: FieldVersionNumber,
+	FieldDetails:       FieldDetails,
+}
+
+var defaultBackstageMapping = Mapping{
+	FieldName:          "metadata.name",
+	FieldDescription:   "metadata.description",
+	FieldVersionNumber: "metadata.annotations.catalog-service/version",
+	FieldDetails:       "spec.lifecycle",
+}
+
+func ParseMapping(spec string) (Mapping, error) {
+	m := Mapping{}
+	if strings.TrimSpace(spec) == "" {
+		return m, nil
+	}
+	for _, pair := range strings.Split(spec, ",") {
+		target, source, ok := strings.Cut(pair, "=")
+		target, source = strings.TrimSpace(target), strings.TrimSpace(source)
+		if !ok || target == "" || source == "" {
+			return nil, fmt.Errorf("invalid mapping %q, expected target=source", pair)
+		}
+		m[target] = source
+	}
+	return m, m.Validate()
+}
+
+func LoadMapping(path string) (Mapping, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping file: %w", err)
+	}
+	m := Mapping{}
+	if err := yaml.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("invalid mapping file %s: %w", path, err)
+	}
+	return m, m.Validate()
+}
+
+func (m Mapping) Validate() error {
+	var unknown []string
+	for target := range m {
+		if !mappingTargets[target] {
+			unknown = append(unknown, target)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown mapping targets: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+func (m Mapping) Merge(override Mapping) Mapping {
+	merged := Mapping{}
+	for k, v := range m {
+		merged[k] = v
+	}
+	for k, v := range override {
+		merged[k] = v
+	}
+	return merged
+}
+
+func (m Mapping) Apply(doc map[string]interface{}) (*models.Service, error) {
+	svc := &models.Service{
+		ID:          m.stringField(doc, FieldID),
+		Name:        m.stringField(doc, FieldName),
+		Description: m.stringField(doc, FieldDescription),
+	}
+
+	if createdAt := m.stringField(doc, FieldCreatedAt); createdAt != "" {
+		t, err := time.Parse(time.RFC3339, createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", FieldCreatedAt, createdAt, err)
+		}
+		svc.CreatedAt = t
+	}
+
+	if path, ok := m[FieldVersions]; ok {
+		if raw, found := lookup(doc, path); found && raw != nil {
+			b, _ := json.Marshal(raw)
+			if err := json.Unmarshal(b, &svc.Versions); err != nil {
+				return nil, fmt.Errorf("invalid %s at %q: %w", FieldVersions, path, err)
+			}
+		}
+	} else if number := m.stringField(doc, FieldVersionNumber); number != "" {
+		svc.Versions = []models.Version{{VersionNumber: number, Details: m.stringField(doc, FieldDetails)}}
+	}
+	return svc, nil
+}
+
+func (m Mapping) stringField(doc map[string]interface{}, target string) string {
+	path, ok := m[target]
+	if !ok {
+		return ""
+	}
+	v, found := lookup(doc, path)
+	if !found || v == nil {
+		return ""
+	}
+	if t, ok := v.(time.Time); ok {
+		return t.UTC().Format(time.RFC3339)
+	}
+	return strings.TrimSpace(fmt.Sprint(v))
+}
+
+func lookup(doc map[string]interface{}, path string) (interface{}, bool) {
+	if v, ok := doc[path]; ok {
+		return v, true
+	}
+	head, rest, ok := strings.Cut(path, ".")
+	if !ok {
+		return nil, false
+	}
+	child, isMap := doc[head].(map[string]interface{})
+	if !isMap {
+		return nil, false
+	}
+	return lookup(child, rest)
+}
+
+func decodeDocument(raw interface{}, mapping Mapping) (*models.Service, error) {
+	doc, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected an object, got %T", raw)
+	}
+	if len(mapping) > 0 {
+		return mapping.Apply(doc)
+	}
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var svc models.Service
+	if err := json.Unmarshal(b, &svc); err != nil {
+		return nil, err
+	}
+	return &svc, nil
+}
@@ -0,0 +1,37 @@
+package ingest
+
+import (
+	"context"
+	"io"
+
+	"catalog-service/internal/models"
+	"catalog-service/internal/spec"
+)
+
+type openapiDecoder struct{}
+
+func (d *openapiDecoder) Decode(ctx context.Context, r io.Reader, source string, from Position, emit EmitFunc) error {
+	if from.Index >= 1 {
+		return nil
+	}
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	rec := Record{Source: source, Index: 1, Offset: int64(len(content)), Raw: content}
+	if !spec.IsOpenAPI(content) {
+		return emit(rec)
+	}
+	doc, err := spec.ParseOpenAPI(content)
+	if err != nil {
+		rec.Err = err
+		return emit(rec)
+	}
+	rec.Service = &models.Service{
+		Name:        string(doc.Info.Title),
+		Description: string(doc.Info.Description),
+		Versions:    []models.Version{{VersionNumber: string(doc.Info.Version)}},
+	}
+	return emit(rec)
+}
@@ -0,0 +1,61 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+type yamlDecoder struct {
+	mapping Mapping
+}
+
+func (d *yamlDecoder) Decode(ctx context.Context, r io.Reader, source string, from Position, emit EmitFunc) error {
+	index := 0
+	return decodeYAMLDocuments(ctx, r, func(doc interface{}) error {
+		items, ok := doc.([]interface{})
+		if !ok {
+			items = []interface{}{doc}
+		}
+		for _, item := range items {
+			index++
+			if index <= from.Index {
+				continue
+			}
+			rec := Record{Source: source, Index: index}
+			rec.Raw, _ = json.Marshal(item)
+			rec.Service, rec.Err = decodeDocument(item, d.mapping)
+			if err := emit(rec); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func decodeYAMLDocuments(ctx context.Context, r io.Reader, fn func(doc interface{}) error) error {
+	dec := yaml.NewDecoder(r)
+	for n := 1; ; n++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		var doc interface{}
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid YAML document %d: %w", n, err)
+		}
+		if doc == nil {
+			continue
+		}
+		if err := fn(doc); err != nil {
+			return err
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type LoggerTestSuite struct {
@@ -310,3 +311,20 @@ func (s *LoggerTestSuite) TestLoggerFormat() {
 		})
 	}
 }
+
+func (s *LoggerTestSuite) TestContextLoggerAddsTraceFields() {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}))
+
+	NewContextLogger(ctx, "test_method").Info("traced")
+
+	var data map[string]interface{}
+	s.Require().NoError(json.Unmarshal(s.buf.Bytes(), &data))
+	s.Equal("4bf92f3577b34da6a3ce929d0e0e4736", data["TraceID"])
+	s.Equal("00f067aa0ba902b7", data["SpanID"])
+
+	s.buf.Reset()
+	NewContextLogger(context.Background(), "test_method").Info("untraced")
+	s.NotContains(s.buf.String(), "TraceID")
+}
@@ -0,0 +1,159 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "catalog"
+
+// Registry holds every catalog metric plus the Go runtime and process
+// collectors.
+var Registry = prometheus.NewRegistry()
+
+var (
+	factory = promauto.With(Registry)
+
+	httpRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route and status.",
+	}, []string{"method", "route", "status"})
+	httpDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method, route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+	httpInFlight = factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "http_requests_in_flight",
+		Help:      "HTTP requests being served.",
+	})
+
+	usecaseDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "usecase_duration_seconds",
+		Help:      "Usecase call latency by operation and outcome.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation", "outcome"})
+
+	openSearchDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "opensearch_request_duration_seconds",
+		Help:      "OpenSearch request latency by operation.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation"})
+	openSearchErrors = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "opensearch_errors_total",
+		Help:      "Failed OpenSearch requests by operation. Missing documents are not counted.",
+	}, []string{"operation"})
+	openSearchRetries = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "opensearch_retries_total",
+		Help:      "OpenSearch requests sent again after a transient failure, by operation.",
+	}, []string{"operation"})
+	openSearchBreakerOpen = factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "opensearch_circuit_open",
+		Help:      "1 while the OpenSearch circuit breaker rejects requests.",
+	})
+
+	rateLimited = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limited_requests_total",
+		Help:      "Requests rejected with 429 by route class.",
+	}, []string{"class"})
+
+	cacheLookups = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_lookups_total",
+		Help:      "Service cache lookups by operation and result.",
+	}, []string{"operation", "result"})
+
+	ingestRecords = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "ingest_records_total",
+		Help:      "Records processed by cmd/ingest by outcome.",
+	}, []string{"outcome"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+func HTTPStarted() {
+	httpInFlight.Inc()
+}
+
+func HTTPFinished(method, route string, status int, elapsed time.Duration) {
+	httpInFlight.Dec()
+	code := strconv.Itoa(status)
+	httpRequests.WithLabelValues(method, route, code).Inc()
+	httpDuration.WithLabelValues(method, route, code).Observe(elapsed.Seconds())
+}
+
+// ObserveUsecase records a usecase call. Errors matching any of notFound are
+// recorded with the not_found outcome.
+func ObserveUsecase(operation string, start time.Time, err error, notFound ...error) {
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+		for _, target := range notFound {
+			if errors.Is(err, target) {
+				outcome = "not_found"
+			}
+		}
+	}
+	usecaseDuration.WithLabelValues(operation, outcome).Observe(time.Since(start).Seconds())
+}
+
+func ObserveOpenSearch(operation string, elapsed time.Duration, failed bool) {
+	openSearchDuration.WithLabelValues(operation).Observe(elapsed.Seconds())
+	if failed {
+		openSearchErrors.WithLabelValues(operation).Inc()
+	}
+}
+
+func OpenSearchRetry(operation string) {
+	openSearchRetries.WithLabelValues(operation).Inc()
+}
+
+func OpenSearchBreakerOpen(open bool) {
+	if open {
+		openSearchBreakerOpen.Set(1)
+	} else {
+		openSearchBreakerOpen.Set(0)
+	}
+}
+
+func RateLimited(class string) {
+	rateLimited.WithLabelValues(class).Inc()
+}
+
+func CacheLookup(operation string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	cacheLookups.WithLabelValues(operation, result).Inc()
+}
+
+func IngestRecord(outcome string) {
+	ingestRecords.WithLabelValues(outcome).Inc()
+}
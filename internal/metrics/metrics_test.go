@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+type MetricsSuite struct {
+	suite.Suite
+}
+
+func TestMetricsSuite(t *testing.T) {
+	suite.Run(t, new(MetricsSuite))
+}
+
+func (suite *MetricsSuite) Test_HTTPFinished_CountsByRouteAndStatus() {
+	counter := httpRequests.WithLabelValues("GET", "/test/:id", "200")
+	before := testutil.ToFloat64(counter)
+
+	HTTPStarted()
+	suite.Equal(float64(1), testutil.ToFloat64(httpInFlight))
+	HTTPFinished("GET", "/test/:id", http.StatusOK, time.Millisecond)
+
+	suite.Equal(before+1, testutil.ToFloat64(counter))
+	suite.Equal(float64(0), testutil.ToFloat64(httpInFlight))
+}
+
+func (suite *MetricsSuite) Test_ObserveUsecase_LabelsOutcome() {
+	notFound := errors.New("not found")
+
+	ObserveUsecase("test.Get", time.Now(), nil, notFound)
+	ObserveUsecase("test.Get", time.Now(), notFound, notFound)
+	ObserveUsecase("test.Get", time.Now(), errors.New("boom"), notFound)
+
+	for _, outcome := range []string{"ok", "not_found", "error"} {
+		suite.Equal(uint64(1), usecaseCount(suite, "test.Get", outcome), outcome)
+	}
+}
+
+func usecaseCount(suite *MetricsSuite, operation, outcome string) uint64 {
+	families, err := Registry.Gather()
+	suite.Require().NoError(err)
+	for _, family := range families {
+		if family.GetName() != "catalog_usecase_duration_seconds" {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range m.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["operation"] == operation && labels["outcome"] == outcome {
+				return m.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+	return 0
+}
+
+func (suite *MetricsSuite) Test_ObserveOpenSearch_CountsOnlyFailures() {
+	ObserveOpenSearch("test_ok", time.Millisecond, false)
+	ObserveOpenSearch("test_failed", time.Millisecond, true)
+
+	suite.Equal(float64(0), testutil.ToFloat64(openSearchErrors.WithLabelValues("test_ok")))
+	suite.Equal(float64(1), testutil.ToFloat64(openSearchErrors.WithLabelValues("test_failed")))
+}
+
+func (suite *MetricsSuite) Test_Handler_ServesPrometheusText() {
+	IngestRecord("ok")
+	rec := httptest.NewRecorder()
+
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	suite.Equal(http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	suite.True(strings.Contains(body, `catalog_ingest_records_total{outcome="ok"}`))
+	suite.True(strings.Contains(body, "go_goroutines"))
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"time"
+
+	"catalog-service/internal/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute labels requests that match no route, so unknown paths do not
+// create a series each.
+const unmatchedRoute = "unmatched"
+
+func MetricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		metrics.HTTPStarted()
+		defer func() {
+			route := c.FullPath()
+			if route == "" {
+				route = unmatchedRoute
+			}
+			metrics.HTTPFinished(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+		}()
+		c.Next()
+	}
+}
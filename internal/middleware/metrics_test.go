@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"catalog-service/internal/metrics"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type MetricsMiddlewareSuite struct {
+	suite.Suite
+	router *gin.Engine
+}
+
+func TestMetricsMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(MetricsMiddlewareSuite))
+}
+
+func (suite *MetricsMiddlewareSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+	suite.router.Use(MetricsMiddleware())
+	suite.router.GET("/things/:id", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+}
+
+func (suite *MetricsMiddlewareSuite) Test_LabelsRequestsWithRouteTemplate() {
+	suite.router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/things/42", nil))
+	suite.router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/nowhere/42", nil))
+
+	body := scrape(suite)
+	suite.Contains(body, `catalog_http_requests_total{method="GET",route="/things/:id",status="204"}`)
+	suite.Contains(body, `catalog_http_requests_total{method="GET",route="unmatched",status="404"}`)
+	suite.NotContains(body, `route="/things/42"`)
+}
+
+func scrape(suite *MetricsMiddlewareSuite) string {
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	suite.Require().Equal(http.StatusOK, rec.Code)
+	return strings.TrimSpace(rec.Body.String())
+}
@@ -1,10 +1,14 @@
 package middleware
 
 import (
+	"fmt"
 	"net/http"
+	"runtime/debug"
 
-	"catalog-service/internal/logger"
+	"catalog-service/internal/api/problem"
 	"catalog-service/internal/constants"
+	"catalog-service/internal/dto"
+	"catalog-service/internal/logger"
 
 	"github.com/gin-gonic/gin"
 )
@@ -14,17 +18,12 @@ func PanicRecoveryMiddleware() gin.HandlerFunc {
 		defer func() {
 			if r := recover(); r != nil {
 				log := logger.NewContextLogger(c.Request.Context(), "PanicRecoveryMiddleware")
-				log.Error("panic recovered: %v", nil)
-				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-					"success": false,
-					"errors": []map[string]string{
-						{
-							"code":   constants.Error_GENERIC_SERVICE_ERROR,
-							"entity": "internal",
-							"cause":  "internal server error",
-						},
-					},
-				})
+				log.ErrorWithFields("panic recovered", map[string]interface{}{"stack": string(debug.Stack())}, fmt.Errorf("%v", r))
+				problem.Abort(c, http.StatusInternalServerError, []dto.ErrorObj{{
+					Code:   constants.Error_GENERIC_SERVICE_ERROR,
+					Entity: "internal",
+					Cause:  "internal server error",
+				}})
 			}
 		}()
 		c.Next()
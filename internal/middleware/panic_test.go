@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"catalog-service/internal/config"
+	"catalog-service/internal/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type PanicRecoveryMiddlewareSuite struct {
+	suite.Suite
+}
+
+func TestPanicRecoveryMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(PanicRecoveryMiddlewareSuite))
+}
+
+func (suite *PanicRecoveryMiddlewareSuite) SetupTest() {
+	config.Load()
+	logger.Setup("INFO", "json")
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *PanicRecoveryMiddlewareSuite) Test_AnswersPanicsWith500() {
+	router := gin.New()
+	router.Use(PanicRecoveryMiddleware())
+	router.GET("/boom", func(c *gin.Context) { panic("boom") })
+
+	rec := httptest.NewRecorder()
+	suite.NotPanics(func() {
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/boom", nil))
+	})
+
+	suite.Equal(http.StatusInternalServerError, rec.Code)
+	suite.JSONEq(`{"success":false,"errors":[{"code":"900","entity":"internal","cause":"internal server error"}]}`, rec.Body.String())
+}
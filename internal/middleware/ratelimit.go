@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"catalog-service/internal/api/problem"
+	"catalog-service/internal/constants"
+	"catalog-service/internal/dto"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/metrics"
+	"catalog-service/internal/ratelimit"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// ConsumerIDHeader is set by Kong to the id of the consumer it
+	// authenticated, replacing any value sent by the client.
+	ConsumerIDHeader = "X-Consumer-ID"
+	APIKeyHeader     = "X-API-Key"
+)
+
+// TrustedProxies are the networks of the proxies whose headers are trusted.
+type TrustedProxies []netip.Prefix
+
+// ParseTrustedProxies accepts addresses and CIDRs, as gin's
+// Engine.SetTrustedProxies does.
+func ParseTrustedProxies(values []string) (TrustedProxies, error) {
+	proxies := make(TrustedProxies, 0, len(values))
+	for _, v := range values {
+		if !strings.Contains(v, "/") {
+			addr, err := netip.ParseAddr(v)
+			if err != nil {
+				return nil, err
+			}
+			proxies = append(proxies, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(v)
+		if err != nil {
+			return nil, err
+		}
+		proxies = append(proxies, prefix.Masked())
+	}
+	return proxies, nil
+}
+
+func (p TrustedProxies) contains(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range p {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// RateLimitMiddleware limits each consumer to limit within class, a group of
+// routes such as "read" or "write" sharing one bucket per consumer. Requests
+// are let through when the store fails.
+func RateLimitMiddleware(store ratelimit.Store, class string, limit ratelimit.Limit, proxies TrustedProxies) gin.HandlerFunc {
+	if !limit.Enabled() {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return func(c *gin.Context) {
+		decision, err := store.Take(c.Request.Context(), class+":"+consumerKey(c, proxies), limit)
+		if err != nil {
+			logger.NewContextLogger(c.Request.Context(), "RateLimitMiddleware").Errorf(err, "rate limit store failed, allowing request")
+			c.Next()
+			return
+		}
+
+		header := c.Writer.Header()
+		header.Set("RateLimit-Limit", strconv.Itoa(decision.Limit))
+		header.Set("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+		header.Set("RateLimit-Reset", ceilSeconds(decision.Reset))
+		if decision.Allowed {
+			c.Next()
+			return
+		}
+
+		metrics.RateLimited(class)
+		retryAfter := ceilSeconds(decision.RetryAfter)
+		header.Set("Retry-After", retryAfter)
+		problem.Abort(c, http.StatusTooManyRequests, []dto.ErrorObj{{
+			Code:   constants.Error_RATE_LIMITED,
+			Entity: "request",
+			Cause:  fmt.Sprintf("rate limit exceeded, retry after %s seconds", retryAfter),
+		}})
+	}
+}
+
+// consumerKey identifies a request Kong authenticated by the subject of its
+// bearer token, then its API key, then its Kong consumer. Kong verified the
+// credentials, so the token is only decoded here. Other requests, including
+// any that do not come from a trusted proxy, are identified by their client
+// IP, which gin only takes from X-Forwarded-For when set by a trusted proxy.
+func consumerKey(c *gin.Context, proxies TrustedProxies) string {
+	consumer := c.GetHeader(ConsumerIDHeader)
+	if consumer == "" || !proxies.contains(c.RemoteIP()) {
+		return "ip:" + c.ClientIP()
+	}
+	if token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok {
+		var claims jwt.RegisteredClaims
+		if _, _, err := jwt.NewParser().ParseUnverified(token, &claims); err == nil && claims.Subject != "" {
+			return "sub:" + claims.Subject
+		}
+	}
+	if key := c.GetHeader(APIKeyHeader); key != "" {
+		sum := sha256.Sum256([]byte(key))
+		return "key:" + hex.EncodeToString(sum[:16])
+	}
+	return "consumer:" + consumer
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
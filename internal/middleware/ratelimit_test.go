@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"catalog-service/internal/config"
+	"catalog-service/internal/constants"
+	"catalog-service/internal/dto"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/ratelimit"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, ratelimit.Limit) (ratelimit.Decision, error) {
+	return ratelimit.Decision{}, assert.AnError
+}
+
+type RateLimitMiddlewareSuite struct {
+	suite.Suite
+	store  ratelimit.Store
+	router *gin.Engine
+}
+
+func TestRateLimitMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(RateLimitMiddlewareSuite))
+}
+
+func (suite *RateLimitMiddlewareSuite) SetupTest() {
+	config.Load()
+	logger.Setup("INFO", "json")
+	gin.SetMode(gin.TestMode)
+	suite.store = ratelimit.NewMemoryStore(100)
+	suite.route()
+}
+
+// kong is the address of the trusted proxy in these tests.
+const kong = "10.0.0.2"
+
+func (suite *RateLimitMiddlewareSuite) route() {
+	suite.router = gin.New()
+	suite.Require().NoError(suite.router.SetTrustedProxies([]string{"10.0.0.0/24"}))
+	proxies, err := ParseTrustedProxies([]string{"10.0.0.0/24"})
+	suite.Require().NoError(err)
+	limit := ratelimit.Limit{PerMinute: 60, Burst: 2}
+	suite.router.GET("/things", RateLimitMiddleware(suite.store, "read", limit, proxies), func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	suite.router.POST("/things", RateLimitMiddleware(suite.store, "write", limit, proxies), func(c *gin.Context) { c.Status(http.StatusCreated) })
+}
+
+func (suite *RateLimitMiddlewareSuite) send(method string, headers ...string) *httptest.ResponseRecorder {
+	return suite.sendFrom("192.0.2.1", method, headers...)
+}
+
+func (suite *RateLimitMiddlewareSuite) sendFrom(peer, method string, headers ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/things", nil)
+	req.RemoteAddr = peer + ":1234"
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	rec := httptest.NewRecorder()
+	suite.router.ServeHTTP(rec, req)
+	return rec
+}
+
+func bearer(subject string) string {
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": subject}).SignedString([]byte("secret"))
+	return "Bearer " + token
+}
+
+func (suite *RateLimitMiddlewareSuite) Test_RejectsWith429AfterBurst() {
+	first := suite.send(http.MethodGet)
+	suite.Equal(http.StatusNoContent, first.Code)
+	suite.Equal("2", first.Header().Get("RateLimit-Limit"))
+	suite.Equal("1", first.Header().Get("RateLimit-Remaining"))
+	suite.Equal("1", first.Header().Get("RateLimit-Reset"))
+	suite.send(http.MethodGet)
+
+	rec := suite.send(http.MethodGet)
+
+	suite.Equal(http.StatusTooManyRequests, rec.Code)
+	suite.Equal("1", rec.Header().Get("Retry-After"))
+	suite.Equal("0", rec.Header().Get("RateLimit-Remaining"))
+	var body dto.ErrorResponse
+	suite.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &body))
+	suite.False(body.Success)
+	suite.Require().Len(body.Errors, 1)
+	suite.Equal(constants.Error_RATE_LIMITED, body.Errors[0].Code)
+	suite.Equal(http.StatusCreated, suite.send(http.MethodPost).Code, "classes have separate buckets")
+}
+
+func (suite *RateLimitMiddlewareSuite) Test_KeysKongRequestsBySubjectThenAPIKeyThenConsumer() {
+	for range 2 {
+		suite.sendFrom(kong, http.MethodGet, ConsumerIDHeader, "app", "Authorization", bearer("alice"))
+		suite.sendFrom(kong, http.MethodGet, ConsumerIDHeader, "app", APIKeyHeader, "key-1")
+		suite.sendFrom(kong, http.MethodGet, ConsumerIDHeader, "app")
+	}
+
+	suite.Equal(http.StatusTooManyRequests, suite.sendFrom(kong, http.MethodGet, ConsumerIDHeader, "app", "Authorization", bearer("alice")).Code)
+	suite.Equal(http.StatusNoContent, suite.sendFrom(kong, http.MethodGet, ConsumerIDHeader, "app", "Authorization", bearer("bob")).Code)
+	suite.Equal(http.StatusTooManyRequests, suite.sendFrom(kong, http.MethodGet, ConsumerIDHeader, "app", APIKeyHeader, "key-1").Code)
+	suite.Equal(http.StatusNoContent, suite.sendFrom(kong, http.MethodGet, ConsumerIDHeader, "app", APIKeyHeader, "key-2").Code)
+	suite.Equal(http.StatusTooManyRequests, suite.sendFrom(kong, http.MethodGet, ConsumerIDHeader, "app").Code)
+	suite.Equal(http.StatusNoContent, suite.sendFrom(kong, http.MethodGet, ConsumerIDHeader, "other").Code)
+	suite.Equal(http.StatusNoContent, suite.sendFrom(kong, http.MethodGet, "Authorization", bearer("alice"), "X-Forwarded-For", "198.51.100.7").Code,
+		"without a Kong consumer the client IP is used")
+}
+
+func (suite *RateLimitMiddlewareSuite) Test_UntrustedCallersCannotChooseTheirKey() {
+	for i := range 2 {
+		suite.Equal(http.StatusNoContent, suite.send(http.MethodGet, ConsumerIDHeader, strconv.Itoa(i)).Code)
+	}
+
+	for i := range 5 {
+		n := strconv.Itoa(i)
+		rec := suite.send(http.MethodGet,
+			ConsumerIDHeader, "consumer-"+n,
+			"X-API-Key", "key-"+n,
+			"X-Forwarded-For", "198.51.100."+n,
+			"X-Real-IP", "198.51.100."+n,
+			"Authorization", "Bearer token-"+n)
+		suite.Equal(http.StatusTooManyRequests, rec.Code, "attempt %d", i)
+	}
+}
+
+func (suite *RateLimitMiddlewareSuite) Test_AllowsRequestsWhenStoreFails() {
+	suite.store = failingStore{}
+	suite.route()
+
+	for range 3 {
+		rec := suite.send(http.MethodGet)
+		suite.Equal(http.StatusNoContent, rec.Code)
+		suite.Empty(rec.Header().Get("RateLimit-Limit"))
+	}
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"catalog-service/internal/api/problem"
+	"catalog-service/internal/constants"
+	"catalog-service/internal/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RouteLimits bound the requests of a route.
+type RouteLimits struct {
+	// MaxBodyBytes caps the request body; 0 disables the cap.
+	MaxBodyBytes int64
+	// Timeout bounds the request context, and with it the OpenSearch calls
+	// made for the request; 0 disables the timeout.
+	Timeout time.Duration
+	// ContentTypes are the media types accepted for a request body; empty
+	// accepts any.
+	ContentTypes []string
+}
+
+// RequestLimitsMiddleware applies the limits of the matched route, looked up
+// by method and route template such as "PUT /api/services/:id", or defaults.
+// It answers 415 for a body of another media type, 413 for a body larger than
+// the cap and 408 when the timeout expires before a response was written.
+// Handlers that read past the cap get an *http.MaxBytesError.
+func RequestLimitsMiddleware(defaults RouteLimits, routes map[string]RouteLimits) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limits, ok := routes[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			limits = defaults
+		}
+
+		if c.Request.ContentLength != 0 {
+			if len(limits.ContentTypes) > 0 && !slices.Contains(limits.ContentTypes, strings.ToLower(c.ContentType())) {
+				abortRequest(c, http.StatusUnsupportedMediaType, constants.Error_UNSUPPORTED_MEDIA,
+					fmt.Sprintf("content type must be %s", strings.Join(limits.ContentTypes, " or ")))
+				return
+			}
+			if limits.MaxBodyBytes > 0 {
+				if c.Request.ContentLength > limits.MaxBodyBytes {
+					abortRequest(c, http.StatusRequestEntityTooLarge, constants.Error_PAYLOAD_TOO_LARGE,
+						fmt.Sprintf("request body is larger than %d bytes", limits.MaxBodyBytes))
+					return
+				}
+				c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limits.MaxBodyBytes)
+			}
+		}
+
+		if limits.Timeout <= 0 {
+			c.Next()
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), limits.Timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			abortRequest(c, http.StatusRequestTimeout, constants.Error_REQUEST_TIMEOUT, "request took too long")
+		}
+	}
+}
+
+func abortRequest(c *gin.Context, status int, code, cause string) {
+	problem.Abort(c, status, []dto.ErrorObj{{
+		Code:   code,
+		Entity: "request",
+		Cause:  cause,
+	}})
+}
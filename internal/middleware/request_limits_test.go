@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"catalog-service/internal/config"
+	"catalog-service/internal/constants"
+	"catalog-service/internal/dto"
+	"catalog-service/internal/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type RequestLimitsMiddlewareSuite struct {
+	suite.Suite
+	router *gin.Engine
+}
+
+func TestRequestLimitsMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(RequestLimitsMiddlewareSuite))
+}
+
+func (suite *RequestLimitsMiddlewareSuite) SetupTest() {
+	config.Load()
+	logger.Setup("INFO", "json")
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+	suite.router.Use(RequestLimitsMiddleware(RouteLimits{
+		MaxBodyBytes: 8,
+		Timeout:      20 * time.Millisecond,
+		ContentTypes: []string{"application/json"},
+	}, map[string]RouteLimits{
+		"PUT /things/:id/raw": {MaxBodyBytes: 32},
+	}))
+	readAll := func(c *gin.Context) {
+		if _, err := io.ReadAll(c.Request.Body); err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				c.Status(http.StatusRequestEntityTooLarge)
+				return
+			}
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+	suite.router.POST("/things", readAll)
+	suite.router.PUT("/things/:id/raw", readAll)
+	suite.router.GET("/slow", func(c *gin.Context) {
+		<-c.Request.Context().Done()
+	})
+}
+
+func (suite *RequestLimitsMiddlewareSuite) send(req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	suite.router.ServeHTTP(rec, req)
+	return rec
+}
+
+func (suite *RequestLimitsMiddlewareSuite) assertError(rec *httptest.ResponseRecorder, status int, code string) {
+	suite.Equal(status, rec.Code)
+	var body dto.ErrorResponse
+	suite.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &body))
+	suite.Require().Len(body.Errors, 1)
+	suite.Equal(code, body.Errors[0].Code)
+	suite.Equal("request", body.Errors[0].Entity)
+}
+
+func (suite *RequestLimitsMiddlewareSuite) Test_AcceptsBodyWithinLimits() {
+	req := httptest.NewRequest(http.MethodPost, "/things", strings.NewReader(`{"a":1}`))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	suite.Equal(http.StatusNoContent, suite.send(req).Code)
+}
+
+func (suite *RequestLimitsMiddlewareSuite) Test_RejectsOtherContentTypesWith415() {
+	req := httptest.NewRequest(http.MethodPost, "/things", strings.NewReader(`a=1`))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	suite.assertError(suite.send(req), http.StatusUnsupportedMediaType, constants.Error_UNSUPPORTED_MEDIA)
+}
+
+func (suite *RequestLimitsMiddlewareSuite) Test_RejectsDeclaredLengthOverCapWith413() {
+	req := httptest.NewRequest(http.MethodPost, "/things", strings.NewReader(`{"a":"too long"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	suite.assertError(suite.send(req), http.StatusRequestEntityTooLarge, constants.Error_PAYLOAD_TOO_LARGE)
+}
+
+func (suite *RequestLimitsMiddlewareSuite) Test_CapsStreamedBodies() {
+	req := httptest.NewRequest(http.MethodPost, "/things", io.NopCloser(strings.NewReader(`{"a":"too long"}`)))
+	req.ContentLength = -1
+	req.Header.Set("Content-Type", "application/json")
+
+	suite.Equal(http.StatusRequestEntityTooLarge, suite.send(req).Code)
+}
+
+func (suite *RequestLimitsMiddlewareSuite) Test_AppliesRouteOverrides() {
+	req := httptest.NewRequest(http.MethodPut, "/things/1/raw", strings.NewReader("openapi: 3.0.0\ninfo: {}"))
+	req.Header.Set("Content-Type", "application/yaml")
+
+	suite.Equal(http.StatusNoContent, suite.send(req).Code)
+}
+
+func (suite *RequestLimitsMiddlewareSuite) Test_AnswersTimeoutWith408() {
+	rec := suite.send(httptest.NewRequest(http.MethodGet, "/slow", nil))
+
+	suite.assertError(rec, http.StatusRequestTimeout, constants.Error_REQUEST_TIMEOUT)
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"catalog-service/internal/tracing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TracingMiddlewareSuite struct {
+	suite.Suite
+	spans  *tracetest.InMemoryExporter
+	router *gin.Engine
+	traced trace.SpanContext
+}
+
+func TestTracingMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(TracingMiddlewareSuite))
+}
+
+func (suite *TracingMiddlewareSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.spans = tracing.Record()
+	suite.router = gin.New()
+	suite.router.Use(TracingMiddleware(), CorrelationIDMiddleware())
+	suite.router.GET("/things/:id", func(c *gin.Context) {
+		suite.traced = trace.SpanContextFromContext(c.Request.Context())
+		c.Status(http.StatusNoContent)
+	})
+	suite.router.GET("/broken", func(c *gin.Context) { c.Status(http.StatusServiceUnavailable) })
+}
+
+func (suite *TracingMiddlewareSuite) Test_ContinuesIncomingTrace() {
+	req := httptest.NewRequest(http.MethodGet, "/things/42", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	req.Header.Set(CorrelationIDKeyHeader, "corr-1")
+
+	suite.router.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := suite.spans.GetSpans()
+	suite.Require().Len(spans, 1)
+	span := spans[0]
+	suite.Equal("GET /things/:id", span.Name)
+	suite.Equal(trace.SpanKindServer, span.SpanKind)
+	suite.Equal("4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext.TraceID().String())
+	suite.Equal("00f067aa0ba902b7", span.Parent.SpanID().String())
+	suite.Equal(span.SpanContext.SpanID(), suite.traced.SpanID(), "handlers see the server span")
+	suite.Contains(span.Attributes, attribute.Int("http.response.status_code", http.StatusNoContent))
+	suite.Contains(span.Attributes, attribute.String("correlation_id", "corr-1"))
+	suite.Equal(codes.Unset, span.Status.Code)
+}
+
+func (suite *TracingMiddlewareSuite) Test_StartsTraceAndMarksServerErrors() {
+	suite.router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/broken", nil))
+
+	spans := suite.spans.GetSpans()
+	suite.Require().Len(spans, 1)
+	suite.False(spans[0].Parent.IsValid())
+	suite.Equal(codes.Error, spans[0].Status.Code)
+}
@@ -0,0 +1,218 @@
+package migrate
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type ChangeKind string
+
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeModified ChangeKind = "modified"
+	// ChangeUnmanaged reports a field only the live mapping has, usually
+	// mapped dynamically. put_mapping merges mappings and keeps it, so it is
+	// informational.
+	ChangeUnmanaged ChangeKind = "unmanaged"
+)
+
+var staticSettings = map[string]bool{
+	"number_of_shards":         true,
+	"number_of_routing_shards": true,
+	"codec":                    true,
+	"routing_partition_size":   true,
+	"soft_deletes.enabled":     true,
+}
+
+var updatableMappingParams = map[string]bool{
+	"ignore_above": true,
+}
+
+type Change struct {
+	Path         string      `json:"path"`
+	Kind         ChangeKind  `json:"kind"`
+	Live         interface{} `json:"live,omitempty"`
+	Desired      interface{} `json:"desired,omitempty"`
+	Incompatible bool        `json:"incompatible"`
+	Reason       string      `json:"reason,omitempty"`
+}
+
+type IndexDiff struct {
+	Index   string   `json:"index"`
+	Exists  bool     `json:"exists"`
+	Changes []Change `json:"changes"`
+}
+
+func (d IndexDiff) RequiresReindex() bool {
+	for _, c := range d.Changes {
+		if c.Incompatible {
+			return true
+		}
+	}
+	return false
+}
+
+func (d IndexDiff) mappingChanged() bool {
+	for _, c := range d.Changes {
+		if c.Kind != ChangeUnmanaged && strings.HasPrefix(c.Path, "mappings.") {
+			return true
+		}
+	}
+	return false
+}
+
+func (d IndexDiff) settingChanges() map[string]interface{} {
+	settings := map[string]interface{}{}
+	for _, c := range d.Changes {
+		if key, ok := strings.CutPrefix(c.Path, "settings."); ok {
+			settings[key] = c.Desired
+		}
+	}
+	return settings
+}
+
+type indexSchema struct {
+	Mappings map[string]interface{} `json:"mappings"`
+	Settings map[string]interface{} `json:"settings"`
+}
+
+func parseIndexSchema(schema []byte) (*indexSchema, error) {
+	var s indexSchema
+	if err := json.Unmarshal(schema, &s); err != nil {
+		return nil, fmt.Errorf("invalid json schema: %w", err)
+	}
+	return &s, nil
+}
+
+func diffIndex(index string, desired *indexSchema, liveMappings, liveSettings map[string]interface{}) IndexDiff {
+	d := IndexDiff{Index: index, Exists: true}
+	d.Changes = append(d.Changes, diffMappings(desired.Mappings, liveMappings)...)
+	d.Changes = append(d.Changes, diffSettings(desired.Settings, liveSettings)...)
+	sort.SliceStable(d.Changes, func(i, j int) bool { return d.Changes[i].Path < d.Changes[j].Path })
+	return d
+}
+
+func diffMappings(desired, live map[string]interface{}) []Change {
+	desiredFields := map[string]map[string]interface{}{}
+	liveFields := map[string]map[string]interface{}{}
+	flattenProperties("", asMap(desired["properties"]), desiredFields)
+	flattenProperties("", asMap(live["properties"]), liveFields)
+
+	var changes []Change
+	for path, want := range desiredFields {
+		have, ok := liveFields[path]
+		if !ok {
+			changes = append(changes, Change{Path: "mappings." + path, Kind: ChangeAdded, Desired: want})
+			continue
+		}
+		for _, attr := range sortedKeys(want, have) {
+			if reflect.DeepEqual(want[attr], have[attr]) {
+				continue
+			}
+			change := Change{
+				Path:    "mappings." + path + "." + attr,
+				Kind:    ChangeModified,
+				Live:    have[attr],
+				Desired: want[attr],
+			}
+			switch {
+			case attr == "type":
+				change.Incompatible = true
+				change.Reason = "field type change requires reindex"
+			case !updatableMappingParams[attr]:
+				change.Incompatible = true
+				change.Reason = "mapping parameter cannot be changed on an existing field"
+			}
+			changes = append(changes, change)
+		}
+	}
+	for path, have := range liveFields {
+		if _, ok := desiredFields[path]; !ok {
+			changes = append(changes, Change{
+				Path:   "mappings." + path,
+				Kind:   ChangeUnmanaged,
+				Live:   have,
+				Reason: "field is not in the schema and is kept",
+			})
+		}
+	}
+	return changes
+}
+
+func diffSettings(desired, live map[string]interface{}) []Change {
+	want := map[string]interface{}{}
+	flattenSettings("", desired, want)
+
+	var changes []Change
+	for _, key := range sortedKeys(want) {
+		wantVal := fmt.Sprint(want[key])
+		haveVal, ok := live["index."+key]
+		if ok && fmt.Sprint(haveVal) == wantVal {
+			continue
+		}
+		change := Change{Path: "settings." + key, Kind: ChangeModified, Live: haveVal, Desired: want[key]}
+		if !ok {
+			change.Kind = ChangeAdded
+		}
+		if staticSettings[key] || strings.HasPrefix(key, "analysis.") {
+			change.Incompatible = true
+			change.Reason = "static setting cannot be changed on an existing index"
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func flattenProperties(prefix string, props map[string]interface{}, out map[string]map[string]interface{}) {
+	for name, raw := range props {
+		def := asMap(raw)
+		path := prefix + name
+		attrs := map[string]interface{}{}
+		for k, v := range def {
+			if k != "properties" && k != "fields" {
+				attrs[k] = v
+			}
+		}
+		out[path] = attrs
+		flattenProperties(path+".", asMap(def["properties"]), out)
+		flattenProperties(path+".", asMap(def["fields"]), out)
+	}
+}
+
+func flattenSettings(prefix string, settings map[string]interface{}, out map[string]interface{}) {
+	for k, v := range settings {
+		key := strings.TrimPrefix(prefix+k, "index.")
+		if key == "index" {
+			flattenSettings("", asMap(v), out)
+			continue
+		}
+		if nested, ok := v.(map[string]interface{}); ok {
+			flattenSettings(key+".", nested, out)
+			continue
+		}
+		out[key] = v
+	}
+}
+
+func asMap(v interface{}) map[string]interface{} {
+	m, _ := v.(map[string]interface{})
+	return m
+}
+
+func sortedKeys(maps ...map[string]interface{}) []string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, m := range maps {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,144 @@
+package migrate
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DiffTestSuite struct {
+	suite.Suite
+}
+
+func TestDiffSuite(t *testing.T) {
+	suite.Run(t, new(DiffTestSuite))
+}
+
+func (s *DiffTestSuite) desired(schema string) *indexSchema {
+	parsed, err := parseIndexSchema([]byte(schema))
+	s.Require().NoError(err)
+	return parsed
+}
+
+func (s *DiffTestSuite) Test_DiffIndex_NoChanges() {
+	desired := s.desired(`{
+		"settings": {"number_of_replicas": "1"},
+		"mappings": {"properties": {"name": {"type": "text", "fields": {"keyword": {"type": "keyword", "ignore_above": 256}}}}}
+	}`)
+	live := map[string]interface{}{"properties": desired.Mappings["properties"]}
+
+	d := diffIndex("services", desired, live, map[string]interface{}{"index.number_of_replicas": "1"})
+
+	s.Empty(d.Changes)
+	s.False(d.RequiresReindex())
+}
+
+func (s *DiffTestSuite) Test_DiffIndex_AddedFieldAndDynamicSettingAreCompatible() {
+	desired := s.desired(`{
+		"settings": {"number_of_replicas": 0, "refresh_interval": "5s"},
+		"mappings": {"properties": {"name": {"type": "text"}, "owner": {"type": "keyword"}}}
+	}`)
+	live := s.desired(`{"mappings": {"properties": {"name": {"type": "text"}}}}`).Mappings
+
+	d := diffIndex("services", desired, live, map[string]interface{}{"index.number_of_replicas": "3"})
+
+	s.False(d.RequiresReindex())
+	s.Require().Len(d.Changes, 3)
+	s.Equal("mappings.owner", d.Changes[0].Path)
+	s.Equal(ChangeAdded, d.Changes[0].Kind)
+	s.Equal("settings.number_of_replicas", d.Changes[1].Path)
+	s.Equal(ChangeModified, d.Changes[1].Kind)
+	s.Equal("settings.refresh_interval", d.Changes[2].Path)
+	s.Equal(ChangeAdded, d.Changes[2].Kind)
+	s.True(d.mappingChanged())
+	s.Equal(map[string]interface{}{"number_of_replicas": float64(0), "refresh_interval": "5s"}, d.settingChanges())
+}
+
+func (s *DiffTestSuite) Test_DiffIndex_FlagsIncompatibleChanges() {
+	desired := s.desired(`{
+		"settings": {"index": {"number_of_shards": "1"}},
+		"mappings": {"properties": {"versions": {"type": "nested", "properties": {"version_number": {"type": "text"}}}}}
+	}`)
+	live := s.desired(`{"mappings": {"properties": {
+		"versions": {"type": "nested", "properties": {"version_number": {"type": "keyword"}}}
+	}}}`).Mappings
+
+	d := diffIndex("services", desired, live, map[string]interface{}{"index.number_of_shards": "3"})
+
+	s.True(d.RequiresReindex())
+	s.Require().Len(d.Changes, 2)
+	s.Equal("mappings.versions.version_number.type", d.Changes[0].Path)
+	s.Equal("field type change requires reindex", d.Changes[0].Reason)
+	s.Equal("settings.number_of_shards", d.Changes[1].Path)
+	s.True(d.Changes[1].Incompatible)
+}
+
+func (s *DiffTestSuite) Test_DiffIndex_LiveOnlyFieldsAreInformational() {
+	desired := s.desired(`{"mappings": {"properties": {"name": {"type": "text"}}}}`)
+	live := s.desired(`{"mappings": {"properties": {
+		"name": {"type": "text"},
+		"legacy": {"type": "text", "fields": {"keyword": {"type": "keyword", "ignore_above": 256}}}
+	}}}`).Mappings
+
+	d := diffIndex("services", desired, live, map[string]interface{}{})
+
+	s.False(d.RequiresReindex())
+	s.False(d.mappingChanged(), "put_mapping keeps the field, nothing to apply")
+	s.Require().Len(d.Changes, 2)
+	s.Equal("mappings.legacy", d.Changes[0].Path)
+	s.Equal(ChangeUnmanaged, d.Changes[0].Kind)
+	s.Equal("mappings.legacy.keyword", d.Changes[1].Path)
+	s.False(d.Changes[1].Incompatible)
+}
+
+func (s *DiffTestSuite) Test_DiffIndex_ServicesSchemaDeclaresSerializedFields() {
+	schema, err := os.ReadFile("../../migrations/services.json")
+	s.Require().NoError(err)
+	desired := s.desired(string(schema)).Mappings
+	live := map[string]interface{}{}
+	for field, mapping := range asMap(desired["properties"]) {
+		live[field] = mapping
+	}
+	// The mapping OpenSearch creates dynamically for id when it is not declared.
+	live["id"] = s.desired(`{"mappings": {"id": {"type": "text", "fields": {"keyword": {"type": "keyword", "ignore_above": 256}}}}}`).Mappings["id"]
+
+	s.Empty(diffMappings(desired, map[string]interface{}{"properties": live}))
+}
+
+func (s *DiffTestSuite) Test_PlanRollback_RevertsNewerOperationsInReverseOrder() {
+	history := []Operation{
+		{Version: 1, Sequence: 1, Index: "services", Type: OperationCreateIndex, Reversible: true},
+		{Version: 2, Sequence: 1, Index: "services", Type: OperationUpdateSettings, Reversible: true},
+		{Version: 2, Sequence: 2, Index: "owners", Type: OperationCreateIndex, Reversible: true},
+	}
+
+	ops, err := planRollback(history, 1)
+
+	s.Require().NoError(err)
+	s.Require().Len(ops, 2)
+	s.Equal("2-2", ops[0].ID())
+	s.Equal("2-1", ops[1].ID())
+}
+
+func (s *DiffTestSuite) Test_DroppedIndices_ListsCreatedIndices() {
+	ops := []Operation{
+		{Version: 2, Sequence: 2, Index: "owners", Type: OperationCreateIndex, Reversible: true},
+		{Version: 2, Sequence: 1, Index: "services", Type: OperationUpdateSettings, Reversible: true},
+	}
+
+	s.Equal([]string{"owners"}, DroppedIndices(ops))
+	s.Empty(DroppedIndices(ops[1:]))
+}
+
+func (s *DiffTestSuite) Test_PlanRollback_RejectsIrreversibleOperations() {
+	history := []Operation{
+		{Version: 1, Sequence: 1, Index: "services", Type: OperationCreateIndex, Reversible: true},
+		{Version: 2, Sequence: 1, Index: "services", Type: OperationPutMapping},
+	}
+
+	_, err := planRollback(history, 0)
+
+	s.Require().Error(err)
+	s.Contains(err.Error(), "put_mapping on services (version 2)")
+}
@@ -0,0 +1,267 @@
+package migrate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"catalog-service/internal/logger"
+
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+)
+
+const HistoryIndexName = "catalog_migrations"
+
+type OperationType string
+
+const (
+	OperationCreateIndex    OperationType = "create_index"
+	OperationPutMapping     OperationType = "put_mapping"
+	OperationUpdateSettings OperationType = "update_settings"
+)
+
+type Operation struct {
+	Version          int           `json:"version"`
+	Sequence         int           `json:"sequence"`
+	Index            string        `json:"index"`
+	Type             OperationType `json:"type"`
+	Reversible       bool          `json:"reversible"`
+	PreviousSettings string        `json:"previous_settings,omitempty"`
+	AppliedAt        time.Time     `json:"applied_at"`
+}
+
+func (o Operation) ID() string {
+	return fmt.Sprintf("%d-%d", o.Version, o.Sequence)
+}
+
+func (m *Migrate) History() ([]Operation, error) {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"size":  10000,
+		"query": map[string]interface{}{"match_all": map[string]interface{}{}},
+	})
+	req := opensearchapi.SearchRequest{
+		Index: []string{HistoryIndexName},
+		Body:  bytes.NewReader(body),
+	}
+	res, err := req.Do(ctx, m.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migration history: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.IsError() {
+		return nil, fmt.Errorf("error reading migration history: %s", res.String())
+	}
+
+	var searchResp struct {
+		Hits struct {
+			Hits []struct {
+				Source Operation `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&searchResp); err != nil {
+		return nil, fmt.Errorf("failed to decode migration history: %w", err)
+	}
+
+	ops := make([]Operation, 0, len(searchResp.Hits.Hits))
+	for _, h := range searchResp.Hits.Hits {
+		ops = append(ops, h.Source)
+	}
+	sortOperations(ops)
+	return ops, nil
+}
+
+func (m *Migrate) CurrentVersion() (int, error) {
+	ops, err := m.History()
+	if err != nil {
+		return 0, err
+	}
+	return latestVersion(ops), nil
+}
+
+// PlanRollback returns the operations Rollback reverts to reach version to,
+// newest first.
+func (m *Migrate) PlanRollback(to int) ([]Operation, error) {
+	history, err := m.History()
+	if err != nil {
+		return nil, err
+	}
+	return planRollback(history, to)
+}
+
+// DroppedIndices returns the indices that reverting ops deletes with all their
+// documents.
+func DroppedIndices(ops []Operation) []string {
+	var indices []string
+	for _, op := range ops {
+		if op.Type == OperationCreateIndex {
+			indices = append(indices, op.Index)
+		}
+	}
+	return indices
+}
+
+// Rollback reverts the operations recorded after version to. Reverting an
+// index creation deletes the index, so it is refused unless allowDataLoss is
+// set.
+func (m *Migrate) Rollback(to int, allowDataLoss bool) error {
+	history, err := m.History()
+	if err != nil {
+		return err
+	}
+
+	ops, err := planRollback(history, to)
+	if err != nil {
+		return err
+	}
+	if dropped := DroppedIndices(ops); len(dropped) > 0 && !allowDataLoss {
+		return fmt.Errorf("rolling back to version %d deletes indices %s with all their documents, allow data loss to proceed", to, strings.Join(dropped, ", "))
+	}
+	if len(ops) == 0 {
+		logger.NonContext.Infof("already at migration version %d. nothing to roll back.", latestVersion(history))
+		return nil
+	}
+
+	for _, op := range ops {
+		if err := m.revert(op); err != nil {
+			return fmt.Errorf("failed to revert %s on index %s (version %d): %w", op.Type, op.Index, op.Version, err)
+		}
+		if err := m.deleteOperation(op); err != nil {
+			return err
+		}
+		logger.NonContext.Infof("reverted %s on index %s (version %d)", op.Type, op.Index, op.Version)
+	}
+	return nil
+}
+
+func planRollback(history []Operation, to int) ([]Operation, error) {
+	if to < 0 {
+		return nil, fmt.Errorf("invalid rollback target version %d", to)
+	}
+
+	var ops []Operation
+	var irreversible []string
+	for _, op := range history {
+		if op.Version <= to {
+			continue
+		}
+		if !op.Reversible {
+			irreversible = append(irreversible, fmt.Sprintf("%s on %s (version %d)", op.Type, op.Index, op.Version))
+		}
+		ops = append(ops, op)
+	}
+	if len(irreversible) > 0 {
+		return nil, fmt.Errorf("cannot roll back to version %d, irreversible operations: %s", to, strings.Join(irreversible, ", "))
+	}
+
+	sortOperations(ops)
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops, nil
+}
+
+func (m *Migrate) recordOperation(op Operation) error {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+
+	op.AppliedAt = time.Now().UTC()
+	body, err := json.Marshal(op)
+	if err != nil {
+		return fmt.Errorf("failed to marshal migration operation: %w", err)
+	}
+	req := opensearchapi.IndexRequest{
+		Index:      HistoryIndexName,
+		DocumentID: op.ID(),
+		Body:       bytes.NewReader(body),
+		Refresh:    "true",
+	}
+	res, err := req.Do(ctx, m.client)
+	if err != nil {
+		return fmt.Errorf("failed to record migration operation: %w", err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return fmt.Errorf("error recording migration operation: %s", res.String())
+	}
+	return nil
+}
+
+func (m *Migrate) deleteOperation(op Operation) error {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+
+	req := opensearchapi.DeleteRequest{
+		Index:      HistoryIndexName,
+		DocumentID: op.ID(),
+		Refresh:    "true",
+	}
+	res, err := req.Do(ctx, m.client)
+	if err != nil {
+		return fmt.Errorf("failed to delete migration record %s: %w", op.ID(), err)
+	}
+	defer res.Body.Close()
+	if res.IsError() && res.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("error deleting migration record %s: %s", op.ID(), res.String())
+	}
+	return nil
+}
+
+func (m *Migrate) revert(op Operation) error {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+
+	var res *opensearchapi.Response
+	var err error
+	switch op.Type {
+	case OperationCreateIndex:
+		req := opensearchapi.IndicesDeleteRequest{Index: []string{op.Index}}
+		res, err = req.Do(ctx, m.client)
+	case OperationUpdateSettings:
+		req := opensearchapi.IndicesPutSettingsRequest{
+			Index: []string{op.Index},
+			Body:  strings.NewReader(op.PreviousSettings),
+		}
+		res, err = req.Do(ctx, m.client)
+	default:
+		return fmt.Errorf("operation %s is not reversible", op.Type)
+	}
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.IsError() && !(op.Type == OperationCreateIndex && res.StatusCode == http.StatusNotFound) {
+		return fmt.Errorf("%s", res.String())
+	}
+	return nil
+}
+
+func latestVersion(ops []Operation) int {
+	version := 0
+	for _, op := range ops {
+		if op.Version > version {
+			version = op.Version
+		}
+	}
+	return version
+}
+
+func sortOperations(ops []Operation) {
+	sort.Slice(ops, func(i, j int) bool {
+		if ops[i].Version != ops[j].Version {
+			return ops[i].Version < ops[j].Version
+		}
+		return ops[i].Sequence < ops[j].Sequence
+	})
+}
@@ -8,9 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
-	"time"
 
 	"github.com/opensearch-project/opensearch-go/v2"
 	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
@@ -18,60 +16,186 @@ import (
 
 type Migrate struct {
 	client *opensearch.Client
+	env    string
+	lookup LookupFunc
+}
+
+type schemaFile struct {
+	index  string
+	schema []byte
 }
 
 func New(client *opensearch.Client) *Migrate {
 	return &Migrate{
 		client: client,
+		env:    strings.ToLower(config.AppEnv()),
+		lookup: config.Get().Lookup,
 	}
 }
 
 func (m *Migrate) Run(schemaDir string) error {
+	schemas, err := m.loadSchemas(schemaDir)
+	if err != nil {
+		return err
+	}
+
+	current, err := m.CurrentVersion()
+	if err != nil {
+		return err
+	}
+	version := current + 1
+	sequence := 0
+
+	for _, s := range schemas {
+		ops, err := m.createOrUpdateIndex(s.index, s.schema)
+		if err != nil {
+			return fmt.Errorf("failed to create/update index %s: %w", s.index, err)
+		}
+
+		for _, op := range ops {
+			sequence++
+			op.Version = version
+			op.Sequence = sequence
+			if err := m.recordOperation(op); err != nil {
+				return err
+			}
+		}
+
+		logger.NonContext.Infof("successfully migrated index: %s\n", s.index)
+	}
+
+	if sequence > 0 {
+		logger.NonContext.Infof("applied migration version %d with %d operation(s)", version, sequence)
+	}
+	return nil
+}
+
+func (m *Migrate) Diff(schemaDir string) ([]IndexDiff, error) {
+	schemas, err := m.loadSchemas(schemaDir)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := make([]IndexDiff, 0, len(schemas))
+	for _, s := range schemas {
+		d, err := m.diff(s.index, s.schema)
+		if err != nil {
+			return nil, fmt.Errorf("failed to diff index %s: %w", s.index, err)
+		}
+		diffs = append(diffs, d)
+	}
+	return diffs, nil
+}
+
+func (m *Migrate) loadSchemas(schemaDir string) ([]schemaFile, error) {
 	files, err := os.ReadDir(schemaDir)
 	if err != nil {
-		return fmt.Errorf("failed to read schema directory %s: %w", schemaDir, err)
+		return nil, fmt.Errorf("failed to read schema directory %s: %w", schemaDir, err)
 	}
 
+	var schemas []schemaFile
 	for _, file := range files {
 		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
 			continue
 		}
 
-		indexName := strings.TrimSuffix(file.Name(), ".json")
-
-		schemaPath := filepath.Join(schemaDir, file.Name())
-		schema, err := os.ReadFile(schemaPath)
+		schema, err := loadSchema(schemaDir, file.Name(), m.env, m.lookup)
 		if err != nil {
-			return fmt.Errorf("failed to read schema file %s: %w", file.Name(), err)
+			return nil, fmt.Errorf("failed to load schema file %s: %w", file.Name(), err)
 		}
 
-		if err := m.createOrUpdateIndex(indexName, schema); err != nil {
-			return fmt.Errorf("failed to create/update index %s: %w", indexName, err)
-		}
+		schemas = append(schemas, schemaFile{
+			index:  strings.TrimSuffix(file.Name(), ".json"),
+			schema: schema,
+		})
+	}
+	return schemas, nil
+}
 
-		logger.NonContext.Infof("successfully migrated index: %s\n", indexName)
+func (m *Migrate) diff(indexName string, schema []byte) (IndexDiff, error) {
+	desired, err := parseIndexSchema(schema)
+	if err != nil {
+		return IndexDiff{}, err
 	}
 
-	return nil
+	exists, err := m.indexExists(indexName)
+	if err != nil {
+		return IndexDiff{}, err
+	}
+	if !exists {
+		return IndexDiff{Index: indexName, Exists: false}, nil
+	}
+
+	mappings, err := m.liveMappings(indexName)
+	if err != nil {
+		return IndexDiff{}, err
+	}
+	settings, err := m.liveSettings(indexName)
+	if err != nil {
+		return IndexDiff{}, err
+	}
+	return diffIndex(indexName, desired, mappings, settings), nil
 }
 
-func (m *Migrate) createOrUpdateIndex(indexName string, schema []byte) error {
+func (m *Migrate) createOrUpdateIndex(indexName string, schema []byte) ([]Operation, error) {
 	exists, err := m.indexExists(indexName)
 	if err != nil {
-		return fmt.Errorf("failed to check if index exists %s: %w", indexName, err)
+		return nil, fmt.Errorf("failed to check if index exists %s: %w", indexName, err)
+	}
+
+	if !exists {
+		created, err := m.createIndex(indexName, schema)
+		if err != nil || !created {
+			return nil, err
+		}
+		return []Operation{{Index: indexName, Type: OperationCreateIndex, Reversible: true}}, nil
+	}
+
+	d, err := m.diff(indexName, schema)
+	if err != nil {
+		return nil, err
+	}
+	if d.RequiresReindex() {
+		return nil, fmt.Errorf("index %s has changes that require a reindex, run the diff command for details", indexName)
+	}
+	if !d.mappingChanged() && len(d.settingChanges()) == 0 {
+		logger.NonContext.Infof("index %s is up to date. skipping update.", indexName)
+		return nil, nil
 	}
 
-	if exists {
-		logger.NonContext.Infof("index %s already exists. skipping update.", indexName)
-		return nil
+	var ops []Operation
+	if d.mappingChanged() {
+		desired, _ := parseIndexSchema(schema)
+		if err := m.putMapping(indexName, desired.Mappings); err != nil {
+			return nil, err
+		}
+		ops = append(ops, Operation{Index: indexName, Type: OperationPutMapping})
 	}
 
-	return m.createIndex(indexName, schema)
+	if settings := d.settingChanges(); len(settings) > 0 {
+		previous, err := m.previousSettings(indexName, settings)
+		if err != nil {
+			return nil, err
+		}
+		if err := m.putSettings(indexName, settings); err != nil {
+			return nil, err
+		}
+		ops = append(ops, Operation{
+			Index:            indexName,
+			Type:             OperationUpdateSettings,
+			Reversible:       true,
+			PreviousSettings: previous,
+		})
+	}
+	return ops, nil
+}
+
+func (m *Migrate) requestContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), config.OpenSearch().DialTimeout())
 }
 
 func (m *Migrate) indexExists(indexName string) (bool, error) {
-	timeout := time.Duration(config.OpenSearch().DialTimeout()) * time.Millisecond
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	ctx, cancel := m.requestContext()
 	defer cancel()
 	req := opensearchapi.IndicesExistsRequest{
 		Index: []string{indexName},
@@ -85,14 +209,13 @@ func (m *Migrate) indexExists(indexName string) (bool, error) {
 	return res.StatusCode == 200, nil
 }
 
-func (m *Migrate) createIndex(indexName string, schema []byte) error {
+func (m *Migrate) createIndex(indexName string, schema []byte) (bool, error) {
 	var js json.RawMessage
 	if err := json.Unmarshal(schema, &js); err != nil {
-		return fmt.Errorf("invalid json schema for index %s: %w", indexName, err)
+		return false, fmt.Errorf("invalid json schema for index %s: %w", indexName, err)
 	}
 
-	timeout := time.Duration(config.OpenSearch().DialTimeout()) * time.Millisecond
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	ctx, cancel := m.requestContext()
 	defer cancel()
 
 	req := opensearchapi.IndicesCreateRequest{
@@ -101,17 +224,132 @@ func (m *Migrate) createIndex(indexName string, schema []byte) error {
 	}
 	res, err := req.Do(ctx, m.client)
 	if err != nil {
-		return fmt.Errorf("failed to create index %s: %w", indexName, err)
+		return false, fmt.Errorf("failed to create index %s: %w", indexName, err)
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
 		if res.StatusCode == 400 && strings.Contains(res.String(), "resource_already_exists_exception") {
 			logger.NonContext.Infof("index %s already exists. skipping creation.", indexName)
-			return nil
+			return false, nil
 		}
-		return fmt.Errorf("error creating index %s: %s", indexName, res.String())
+		return false, fmt.Errorf("error creating index %s: %s", indexName, res.String())
+	}
+
+	return true, nil
+}
+
+func (m *Migrate) liveMappings(indexName string) (map[string]interface{}, error) {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+
+	req := opensearchapi.IndicesGetMappingRequest{Index: []string{indexName}}
+	res, err := req.Do(ctx, m.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mapping for %s: %w", indexName, err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return nil, fmt.Errorf("error getting mapping for %s: %s", indexName, res.String())
+	}
+
+	var resp map[string]struct {
+		Mappings map[string]interface{} `json:"mappings"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to decode mapping for %s: %w", indexName, err)
+	}
+	for _, idx := range resp {
+		return idx.Mappings, nil
+	}
+	return map[string]interface{}{}, nil
+}
+
+func (m *Migrate) liveSettings(indexName string) (map[string]interface{}, error) {
+	ctx, cancel := m.requestContext()
+	defer cancel()
+
+	flat := true
+	req := opensearchapi.IndicesGetSettingsRequest{Index: []string{indexName}, FlatSettings: &flat}
+	res, err := req.Do(ctx, m.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settings for %s: %w", indexName, err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return nil, fmt.Errorf("error getting settings for %s: %s", indexName, res.String())
+	}
+
+	var resp map[string]struct {
+		Settings map[string]interface{} `json:"settings"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to decode settings for %s: %w", indexName, err)
+	}
+	for _, idx := range resp {
+		return idx.Settings, nil
+	}
+	return map[string]interface{}{}, nil
+}
+
+func (m *Migrate) previousSettings(indexName string, changed map[string]interface{}) (string, error) {
+	live, err := m.liveSettings(indexName)
+	if err != nil {
+		return "", err
+	}
+	previous := map[string]interface{}{}
+	for key := range changed {
+		previous["index."+key] = live["index."+key]
+	}
+	b, err := json.Marshal(previous)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal previous settings: %w", err)
+	}
+	return string(b), nil
+}
+
+func (m *Migrate) putMapping(indexName string, mappings map[string]interface{}) error {
+	body, err := json.Marshal(mappings)
+	if err != nil {
+		return fmt.Errorf("failed to marshal mapping for %s: %w", indexName, err)
+	}
+
+	ctx, cancel := m.requestContext()
+	defer cancel()
+
+	req := opensearchapi.IndicesPutMappingRequest{Index: []string{indexName}, Body: bytes.NewReader(body)}
+	res, err := req.Do(ctx, m.client)
+	if err != nil {
+		return fmt.Errorf("failed to update mapping for %s: %w", indexName, err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return fmt.Errorf("error updating mapping for %s: %s", indexName, res.String())
+	}
+	return nil
+}
+
+func (m *Migrate) putSettings(indexName string, settings map[string]interface{}) error {
+	prefixed := make(map[string]interface{}, len(settings))
+	for k, v := range settings {
+		prefixed["index."+k] = v
+	}
+	body, err := json.Marshal(prefixed)
+	if err != nil {
+		return fmt.Errorf("failed to marshal settings for %s: %w", indexName, err)
 	}
 
+	ctx, cancel := m.requestContext()
+	defer cancel()
+
+	req := opensearchapi.IndicesPutSettingsRequest{Index: []string{indexName}, Body: bytes.NewReader(body)}
+	res, err := req.Do(ctx, m.client)
+	if err != nil {
+		return fmt.Errorf("failed to update settings for %s: %w", indexName, err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return fmt.Errorf("error updating settings for %s: %s", indexName, res.String())
+	}
 	return nil
 }
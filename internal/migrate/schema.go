@@ -0,0 +1,93 @@
+package migrate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+type LookupFunc func(key string) (string, bool)
+
+var placeholderPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::([^}]*))?\}`)
+
+func loadSchema(schemaDir, fileName, env string, lookup LookupFunc) ([]byte, error) {
+	base, err := readTemplate(filepath.Join(schemaDir, fileName), lookup)
+	if err != nil {
+		return nil, err
+	}
+
+	if env == "" {
+		return base, nil
+	}
+
+	overlay, err := readTemplate(filepath.Join(schemaDir, env, fileName), lookup)
+	if errors.Is(err, os.ErrNotExist) {
+		return base, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeSchemas(base, overlay)
+}
+
+func readTemplate(path string, lookup LookupFunc) ([]byte, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	rendered, err := renderTemplate(raw, lookup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render schema file %s: %w", path, err)
+	}
+	return rendered, nil
+}
+
+func renderTemplate(schema []byte, lookup LookupFunc) ([]byte, error) {
+	var missing []string
+	rendered := placeholderPattern.ReplaceAllFunc(schema, func(match []byte) []byte {
+		groups := placeholderPattern.FindSubmatch(match)
+		key := string(groups[1])
+		if value, ok := lookup(key); ok {
+			return []byte(value)
+		}
+		if strings.Contains(string(match), ":") {
+			return groups[2]
+		}
+		missing = append(missing, key)
+		return match
+	})
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("unresolved placeholders: %s", strings.Join(missing, ", "))
+	}
+	return rendered, nil
+}
+
+func mergeSchemas(base, overlay []byte) ([]byte, error) {
+	var baseDoc, overlayDoc map[string]interface{}
+	if err := json.Unmarshal(base, &baseDoc); err != nil {
+		return nil, fmt.Errorf("invalid base schema: %w", err)
+	}
+	if err := json.Unmarshal(overlay, &overlayDoc); err != nil {
+		return nil, fmt.Errorf("invalid overlay schema: %w", err)
+	}
+	return json.Marshal(deepMerge(baseDoc, overlayDoc))
+}
+
+func deepMerge(dst, src map[string]interface{}) map[string]interface{} {
+	for key, srcVal := range src {
+		srcMap, srcIsMap := srcVal.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			dst[key] = deepMerge(dstMap, srcMap)
+			continue
+		}
+		dst[key] = srcVal
+	}
+	return dst
+}
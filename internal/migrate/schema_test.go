@@ -0,0 +1,87 @@
+package migrate
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SchemaTestSuite struct {
+	suite.Suite
+	dir string
+}
+
+func TestSchemaSuite(t *testing.T) {
+	suite.Run(t, new(SchemaTestSuite))
+}
+
+func (s *SchemaTestSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+}
+
+func (s *SchemaTestSuite) writeFile(rel, content string) {
+	path := filepath.Join(s.dir, rel)
+	s.Require().NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+	s.Require().NoError(os.WriteFile(path, []byte(content), 0o644))
+}
+
+func lookupFrom(values map[string]string) LookupFunc {
+	return func(key string) (string, bool) {
+		v, ok := values[key]
+		return v, ok
+	}
+}
+
+func (s *SchemaTestSuite) Test_RenderTemplate_ResolvesValuesAndDefaults() {
+	out, err := renderTemplate([]byte(`{"a": "${A}", "b": "${B:2}", "c": ${C:3}}`), lookupFrom(map[string]string{"A": "1"}))
+
+	s.Require().NoError(err)
+	s.JSONEq(`{"a": "1", "b": "2", "c": 3}`, string(out))
+}
+
+func (s *SchemaTestSuite) Test_RenderTemplate_FailsOnMissingValue() {
+	_, err := renderTemplate([]byte(`{"a": "${MISSING}"}`), lookupFrom(nil))
+
+	s.Require().Error(err)
+	s.Contains(err.Error(), "MISSING")
+}
+
+func (s *SchemaTestSuite) Test_LoadSchema_MergesEnvOverlay() {
+	s.writeFile("services.json", `{
+		"settings": {"number_of_shards": "${SHARDS:1}", "number_of_replicas": "${REPLICAS:0}"},
+		"mappings": {"properties": {"name": {"type": "text"}}}
+	}`)
+	s.writeFile("production/services.json", `{"settings": {"number_of_replicas": "${REPLICAS:2}"}}`)
+
+	out, err := loadSchema(s.dir, "services.json", "production", lookupFrom(map[string]string{"SHARDS": "3"}))
+	s.Require().NoError(err)
+
+	var doc map[string]map[string]interface{}
+	s.Require().NoError(json.Unmarshal(out, &doc))
+	s.Equal("3", doc["settings"]["number_of_shards"])
+	s.Equal("2", doc["settings"]["number_of_replicas"])
+	s.NotNil(doc["mappings"]["properties"])
+}
+
+func (s *SchemaTestSuite) Test_LoadSchema_WithoutOverlayReturnsBase() {
+	s.writeFile("services.json", `{"settings": {"number_of_replicas": "${REPLICAS:0}"}}`)
+
+	out, err := loadSchema(s.dir, "services.json", "dev", lookupFrom(nil))
+
+	s.Require().NoError(err)
+	s.JSONEq(`{"settings": {"number_of_replicas": "0"}}`, string(out))
+}
+
+func (s *SchemaTestSuite) Test_LoadSchema_ServicesKeepsBaselineShardsOutsideDev() {
+	for env, shards := range map[string]string{"": "3", "uat": "3", "production": "3", "dev": "1"} {
+		out, err := loadSchema("../../migrations", "services.json", env, lookupFrom(nil))
+		s.Require().NoError(err, env)
+
+		var doc map[string]map[string]interface{}
+		s.Require().NoError(json.Unmarshal(out, &doc), env)
+		s.Equal(shards, doc["settings"]["number_of_shards"], env)
+	}
+}
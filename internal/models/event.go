@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+type EventType string
+
+const (
+	EventServiceCreated    EventType = "service.created"
+	EventServiceUpdated    EventType = "service.updated"
+	EventServiceDeleted    EventType = "service.deleted"
+	EventVersionPublished  EventType = "version.published"
+	EventVersionDeprecated EventType = "version.deprecated"
+)
+
+var EventTypes = []EventType{
+	EventServiceCreated,
+	EventServiceUpdated,
+	EventServiceDeleted,
+	EventVersionPublished,
+	EventVersionDeprecated,
+}
+
+type ServiceEvent struct {
+	ID            int64     `json:"id"`
+	Type          EventType `json:"type"`
+	ServiceID     string    `json:"service_id"`
+	ChangedFields []string  `json:"changed_fields"`
+	Versions      []string  `json:"versions,omitempty"`
+	Labels        []string  `json:"labels,omitempty"`
+	// DedupID identifies the write that produced the event. It is the same
+	// on every redelivery of the event, unlike ID.
+	DedupID    string    `json:"dedup_id,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+type ServiceFilter struct {
+	Name          string
+	Version       string
+	Operation     string
+	UpdatedAfter  time.Time
+	UpdatedBefore time.Time
+}
+
+func (f ServiceFilter) IsEmpty() bool {
+	return f == ServiceFilter{}
+}
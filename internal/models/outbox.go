@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// OutboxMessage is an event waiting to be published. It is stored together
+// with the write that produced it and removed once published.
+type OutboxMessage struct {
+	ID        string       `json:"id"`
+	Event     ServiceEvent `json:"event"`
+	Sequence  int          `json:"sequence"`
+	Attempts  int          `json:"attempts"`
+	LastError string       `json:"last_error,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+}
@@ -11,6 +11,8 @@ type Service struct {
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
 	Versions    []Version `json:"versions"`
+	Operations  []string  `json:"operations,omitempty"`
+	Labels      []string  `json:"labels,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -18,6 +20,34 @@ type Service struct {
 type Version struct {
 	VersionNumber string `json:"version_number"`
 	Details       string `json:"details"`
+	Deprecated    bool   `json:"deprecated,omitempty"`
+}
+
+func (s *Service) MergeVersions(versions []Version) bool {
+	changed := false
+	for _, v := range versions {
+		found := false
+		for i := range s.Versions {
+			if s.Versions[i].VersionNumber != v.VersionNumber {
+				continue
+			}
+			found = true
+			if v.Details != "" && s.Versions[i].Details != v.Details {
+				s.Versions[i].Details = v.Details
+				changed = true
+			}
+			if v.Deprecated && !s.Versions[i].Deprecated {
+				s.Versions[i].Deprecated = true
+				changed = true
+			}
+			break
+		}
+		if !found {
+			s.Versions = append(s.Versions, v)
+			changed = true
+		}
+	}
+	return changed
 }
 
 func ParseService(data []byte) (*Service, error) {
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+type Spec struct {
+	ID          string    `json:"id"`
+	ServiceID   string    `json:"service_id"`
+	Version     string    `json:"version"`
+	Format      string    `json:"format"`
+	ContentType string    `json:"content_type"`
+	Content     string    `json:"content"`
+	Operations  []string  `json:"operations"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+type Webhook struct {
+	ID         string      `json:"id"`
+	URL        string      `json:"url"`
+	Secret     string      `json:"secret"`
+	EventTypes []EventType `json:"event_types"`
+	ServiceIDs []string    `json:"service_ids"`
+	Labels     []string    `json:"labels"`
+	Active     bool        `json:"active"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+}
+
+// Matches reports whether the webhook subscribes to event. Empty filters match
+// everything; a label filter matches services with any of the labels.
+func (w *Webhook) Matches(event *ServiceEvent) bool {
+	if !w.Active {
+		return false
+	}
+	if len(w.EventTypes) > 0 && !slices.Contains(w.EventTypes, event.Type) {
+		return false
+	}
+	if len(w.ServiceIDs) > 0 && !slices.Contains(w.ServiceIDs, event.ServiceID) {
+		return false
+	}
+	if len(w.Labels) > 0 && !slices.ContainsFunc(w.Labels, func(l string) bool { return slices.Contains(event.Labels, l) }) {
+		return false
+	}
+	return true
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+type WebhookDelivery struct {
+	ID           string         `json:"id"`
+	WebhookID    string         `json:"webhook_id"`
+	Event        ServiceEvent   `json:"event"`
+	Status       DeliveryStatus `json:"status"`
+	Attempts     int            `json:"attempts"`
+	ResponseCode int            `json:"response_code,omitempty"`
+	Error        string         `json:"error,omitempty"`
+	Redelivery   bool           `json:"redelivery,omitempty"`
+	NextRetryAt  *time.Time     `json:"next_retry_at,omitempty"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+}
+
+type WebhookFilter struct {
+	ServiceID string
+	Label     string
+	EventType EventType
+}
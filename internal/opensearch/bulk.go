@@ -0,0 +1,374 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"catalog-service/internal/logger"
+
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+)
+
+const (
+	DefaultBulkBatchSize  = 500
+	DefaultBulkBatchBytes = 5 * 1024 * 1024
+	DefaultBulkWorkers    = 4
+)
+
+type BulkAction string
+
+const (
+	BulkActionIndex  BulkAction = "index"
+	BulkActionUpdate BulkAction = "update"
+	BulkActionDelete BulkAction = "delete"
+
+	BulkResultCreated = "created"
+	BulkResultUpdated = "updated"
+	BulkResultNoop    = "noop"
+
+	bulkRetryOnConflict = 3
+)
+
+type BulkDocument struct {
+	ID       string
+	Action   BulkAction
+	Document interface{}
+	Refs     []interface{}
+}
+
+// WriteOperation is one action of a Write request. Unlike BulkDocument it
+// names its index, so a single request can span indices.
+type WriteOperation struct {
+	Action   BulkAction
+	Index    string
+	ID       string
+	Document interface{}
+}
+
+type BulkOptions struct {
+	BatchSize  int
+	BatchBytes int
+	Workers    int
+	Refresh    bool
+	OnItem     func(BulkItemResult)
+}
+
+type BulkItemResult struct {
+	ID     string
+	Status int
+	Result string
+	Err    error
+	Refs   []interface{}
+}
+
+type BulkResult struct {
+	Indexed   int
+	Created   int
+	Updated   int
+	Unchanged int
+	Failed    []BulkItemResult
+}
+
+type bulkBatch struct {
+	items []bulkBatchItem
+	body  bytes.Buffer
+}
+
+type bulkBatchItem struct {
+	id   string
+	refs []interface{}
+}
+
+type bulkResponse struct {
+	Errors bool                                `json:"errors"`
+	Items  []map[string]bulkResponseItemResult `json:"items"`
+}
+
+type bulkResponseItemResult struct {
+	ID     string `json:"_id"`
+	Status int    `json:"status"`
+	Result string `json:"result"`
+	Error  *struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	} `json:"error"`
+}
+
+func (o BulkOptions) withDefaults() BulkOptions {
+	if o.BatchSize <= 0 {
+		o.BatchSize = DefaultBulkBatchSize
+	}
+	if o.BatchBytes <= 0 {
+		o.BatchBytes = DefaultBulkBatchBytes
+	}
+	if o.Workers <= 0 {
+		o.Workers = DefaultBulkWorkers
+	}
+	return o
+}
+
+func (c *ClientImpl) BulkIndex(ctx context.Context, indexName string, docs <-chan BulkDocument, opts BulkOptions) (*BulkResult, error) {
+	log := logger.NewContextLogger(ctx, "Client/BulkIndex")
+	ctx = withOperation(ctx, "bulk", indexName)
+	opts = opts.withDefaults()
+
+	result := &BulkResult{}
+	var mu sync.Mutex
+	report := func(item BulkItemResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		result.add(item)
+		if opts.OnItem != nil {
+			opts.OnItem(item)
+		}
+	}
+
+	batches := make(chan *bulkBatch, opts.Workers)
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batches {
+				c.sendBulkBatch(ctx, indexName, batch, report)
+			}
+		}()
+	}
+
+	err := batchDocuments(ctx, docs, opts, batches, report)
+	close(batches)
+	wg.Wait()
+	if err != nil {
+		return result, err
+	}
+
+	if opts.Refresh {
+		if err := c.refreshIndex(ctx, indexName); err != nil {
+			return result, err
+		}
+	}
+
+	log.Infof("bulk indexing completed: %d indexed (%d created, %d updated, %d unchanged), %d failed",
+		result.Indexed, result.Created, result.Updated, result.Unchanged, len(result.Failed))
+	return result, nil
+}
+
+func (r *BulkResult) add(item BulkItemResult) {
+	if item.Err != nil {
+		r.Failed = append(r.Failed, item)
+		return
+	}
+	r.Indexed++
+	switch item.Result {
+	case BulkResultCreated:
+		r.Created++
+	case BulkResultUpdated:
+		r.Updated++
+	case BulkResultNoop:
+		r.Unchanged++
+	}
+}
+
+func bulkActionLine(doc BulkDocument) []byte {
+	action := doc.Action
+	if action == "" {
+		action = BulkActionIndex
+	}
+	meta := map[string]interface{}{}
+	if doc.ID != "" {
+		meta["_id"] = doc.ID
+	}
+	if action == BulkActionUpdate {
+		meta["retry_on_conflict"] = bulkRetryOnConflict
+	}
+	line, _ := json.Marshal(map[string]interface{}{string(action): meta})
+	return line
+}
+
+func batchDocuments(ctx context.Context, docs <-chan BulkDocument, opts BulkOptions, batches chan<- *bulkBatch, report func(BulkItemResult)) error {
+	batch := &bulkBatch{}
+	flush := func() error {
+		if len(batch.items) == 0 {
+			return nil
+		}
+		select {
+		case batches <- batch:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		batch = &bulkBatch{}
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case doc, ok := <-docs:
+			if !ok {
+				return flush()
+			}
+			source, err := json.Marshal(doc.Document)
+			if err != nil {
+				report(BulkItemResult{ID: doc.ID, Err: fmt.Errorf("failed to marshal document: %w", err), Refs: doc.Refs})
+				continue
+			}
+			action := bulkActionLine(doc)
+			if batch.body.Len() > 0 && batch.body.Len()+len(action)+len(source)+2 > opts.BatchBytes {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+			batch.body.Write(action)
+			batch.body.WriteByte('\n')
+			batch.body.Write(source)
+			batch.body.WriteByte('\n')
+			batch.items = append(batch.items, bulkBatchItem{id: doc.ID, refs: doc.Refs})
+			if len(batch.items) >= opts.BatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+func (c *ClientImpl) sendBulkBatch(ctx context.Context, indexName string, batch *bulkBatch, report func(BulkItemResult)) {
+	log := logger.NewContextLogger(ctx, "Client/BulkIndex")
+	failAll := func(err error) {
+		log.Errorf(err, "bulk request of %d documents failed", len(batch.items))
+		for _, item := range batch.items {
+			report(BulkItemResult{ID: item.id, Err: err, Refs: item.refs})
+		}
+	}
+
+	req := opensearchapi.BulkRequest{
+		Index: indexName,
+		Body:  bytes.NewReader(batch.body.Bytes()),
+	}
+	res, err := req.Do(ctx, c.Client)
+	if err != nil {
+		failAll(fmt.Errorf("failed to execute bulk request: %w", err))
+		return
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		failAll(fmt.Errorf("error executing bulk request: %s", res.String()))
+		return
+	}
+
+	var resp bulkResponse
+	if err := json.NewDecoder(res.Body).Decode(&resp); err != nil {
+		failAll(fmt.Errorf("failed to decode bulk response: %w", err))
+		return
+	}
+
+	for i, sent := range batch.items {
+		if i >= len(resp.Items) {
+			report(BulkItemResult{ID: sent.id, Err: fmt.Errorf("missing item in bulk response"), Refs: sent.refs})
+			continue
+		}
+		for _, item := range resp.Items[i] {
+			result := BulkItemResult{ID: sent.id, Status: item.Status, Result: item.Result, Refs: sent.refs}
+			if result.ID == "" {
+				result.ID = item.ID
+			}
+			if item.Error != nil {
+				result.Err = fmt.Errorf("%s: %s", item.Error.Type, item.Error.Reason)
+			}
+			report(result)
+		}
+	}
+}
+
+func (c *ClientImpl) refreshIndex(ctx context.Context, indexName string) error {
+	req := opensearchapi.IndicesRefreshRequest{Index: []string{indexName}}
+	res, err := req.Do(withOperation(ctx, "refresh", indexName), c.Client)
+	if err != nil {
+		return fmt.Errorf("failed to refresh index: %w", err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return fmt.Errorf("error refreshing index: %s", res.String())
+	}
+	return nil
+}
+
+// Write sends ops in a single bulk request and refreshes the affected shards
+// before returning. The results are in the order of ops; a missing document
+// fails its item with ErrNotFound. OpenSearch applies each item on its own, so
+// some items can fail while others succeed.
+func (c *ClientImpl) Write(ctx context.Context, ops []WriteOperation) ([]BulkItemResult, error) {
+	var body bytes.Buffer
+	for _, op := range ops {
+		action := op.Action
+		if action == "" {
+			action = BulkActionIndex
+		}
+		line, _ := json.Marshal(map[string]interface{}{string(action): map[string]interface{}{"_index": op.Index, "_id": op.ID}})
+		body.Write(line)
+		body.WriteByte('\n')
+		if action == BulkActionDelete {
+			continue
+		}
+		source, err := json.Marshal(op.Document)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal document %s: %w", op.ID, err)
+		}
+		body.Write(source)
+		body.WriteByte('\n')
+	}
+
+	req := opensearchapi.BulkRequest{
+		Body:    bytes.NewReader(body.Bytes()),
+		Refresh: "true",
+	}
+	res, err := req.Do(withOperation(ctx, "bulk", writeIndices(ops)), c.Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute bulk request: %w", err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return nil, fmt.Errorf("error executing bulk request: %s", res.String())
+	}
+
+	var resp bulkResponse
+	if err := json.NewDecoder(res.Body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to decode bulk response: %w", err)
+	}
+	results := make([]BulkItemResult, len(ops))
+	for i, op := range ops {
+		results[i] = BulkItemResult{ID: op.ID, Err: fmt.Errorf("missing item in bulk response")}
+		if i >= len(resp.Items) {
+			continue
+		}
+		for _, item := range resp.Items[i] {
+			results[i] = BulkItemResult{ID: op.ID, Status: item.Status, Result: item.Result}
+			switch {
+			case item.Error != nil:
+				results[i].Err = fmt.Errorf("%s: %s", item.Error.Type, item.Error.Reason)
+			case item.Status == http.StatusNotFound:
+				results[i].Err = fmt.Errorf("%w: %s/%s", ErrNotFound, op.Index, op.ID)
+			}
+		}
+	}
+	return results, nil
+}
+
+// writeIndices lists the indices ops write to, in order of first use.
+func writeIndices(ops []WriteOperation) string {
+	var indices []string
+	for _, op := range ops {
+		if !slices.Contains(indices, op.Index) {
+			indices = append(indices, op.Index)
+		}
+	}
+	return strings.Join(indices, ",")
+}
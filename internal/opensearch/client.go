@@ -6,20 +6,28 @@ import (
 	"catalog-service/internal/logger"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"time"
 
 	"github.com/opensearch-project/opensearch-go/v2"
 	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
 )
 
+var ErrNotFound = errors.New("document not found")
+
 type Client interface {
+	ClusterHealth(ctx context.Context) (*ClusterHealth, error)
 	IndexExists(indexName string) (bool, error)
 	IndexDocument(ctx context.Context, id string, document interface{}, indexName string) error
 	Search(ctx context.Context, indexName string, searchBody map[string]interface{}) ([]map[string]interface{}, int, error)
 	FindDocumentByID(ctx context.Context, indexName, id string) (map[string]interface{}, error)
 	DeleteDocumentByID(ctx context.Context, indexName, id string) error
+	BulkIndex(ctx context.Context, indexName string, docs <-chan BulkDocument, opts BulkOptions) (*BulkResult, error)
+	Write(ctx context.Context, ops []WriteOperation) ([]BulkItemResult, error)
+	Scroll(ctx context.Context, indexName string, searchBody map[string]interface{}, keepAlive time.Duration, fn ScrollFunc) error
 }
 
 type ClientImpl struct {
@@ -40,7 +48,17 @@ func NewClient(addresses []string) (*ClientImpl, error) {
 	}
 	client, err := opensearch.NewClient(opensearch.Config{
 		Addresses: osCfg.Host(),
-		Transport: transport,
+		Transport: newResilientTransport(instrumentedTransport{next: transport}, ResilienceOptions{
+			MaxRetries:      osCfg.MaxRetries(),
+			InitialBackoff:  osCfg.RetryInitialBackoff(),
+			MaxBackoff:      osCfg.RetryMaxBackoff(),
+			Timeout:         osCfg.RequestTimeout,
+			BreakerFailures: osCfg.BreakerFailures(),
+			BreakerCooldown: osCfg.BreakerCooldown(),
+		}),
+		// Retries are left to the resilient transport, which does not resend
+		// writes OpenSearch may have executed.
+		DisableRetry: true,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create OpenSearch client: %w", err)
@@ -48,11 +66,35 @@ func NewClient(addresses []string) (*ClientImpl, error) {
 	return &ClientImpl{Client: client}, nil
 }
 
+type ClusterHealth struct {
+	ClusterName   string `json:"cluster_name"`
+	Status        string `json:"status"`
+	NumberOfNodes int    `json:"number_of_nodes"`
+}
+
+func (c *ClientImpl) ClusterHealth(ctx context.Context) (*ClusterHealth, error) {
+	req := opensearchapi.ClusterHealthRequest{}
+	res, err := req.Do(withOperation(ctx, "cluster_health", ""), c.Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster health: %w", err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return nil, fmt.Errorf("error getting cluster health: %s", res.String())
+	}
+
+	var health ClusterHealth
+	if err := json.NewDecoder(res.Body).Decode(&health); err != nil {
+		return nil, fmt.Errorf("failed to decode cluster health: %w", err)
+	}
+	return &health, nil
+}
+
 func (c *ClientImpl) IndexExists(indexName string) (bool, error) {
 	req := opensearchapi.IndicesExistsRequest{
 		Index: []string{indexName},
 	}
-	res, err := req.Do(context.Background(), c.Client)
+	res, err := req.Do(withOperation(context.Background(), "index_exists", indexName), c.Client)
 	if err != nil {
 		return false, fmt.Errorf("failed to check index existence: %w", err)
 	}
@@ -63,6 +105,7 @@ func (c *ClientImpl) IndexExists(indexName string) (bool, error) {
 
 func (c *ClientImpl) IndexDocument(ctx context.Context, id string, document interface{}, indexName string) error {
 	log := logger.NewContextLogger(ctx, "Client/IndexDocument")
+	ctx = withOperation(ctx, "index", indexName)
 
 	docJSON, err := json.Marshal(document)
 	if err != nil {
@@ -98,6 +141,7 @@ func (c *ClientImpl) IndexDocument(ctx context.Context, id string, document inte
 
 func (c *ClientImpl) Search(ctx context.Context, indexName string, searchBody map[string]interface{}) ([]map[string]interface{}, int, error) {
 	log := logger.NewContextLogger(ctx, "Client/Search")
+	ctx = withOperation(ctx, "search", indexName)
 	searchBodyBytes, err := json.Marshal(searchBody)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to marshal search query: %w", err)
@@ -150,6 +194,7 @@ func (c *ClientImpl) Search(ctx context.Context, indexName string, searchBody ma
 
 func (c *ClientImpl) FindDocumentByID(ctx context.Context, indexName, id string) (map[string]interface{}, error) {
 	log := logger.NewContextLogger(ctx, "Client/FindDocumentByID")
+	ctx = withOperation(ctx, "get", indexName)
 	req := opensearchapi.GetRequest{
 		Index:      indexName,
 		DocumentID: id,
@@ -161,6 +206,9 @@ func (c *ClientImpl) FindDocumentByID(ctx context.Context, indexName, id string)
 	}
 	defer res.Body.Close()
 
+	if res.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s/%s", ErrNotFound, indexName, id)
+	}
 	if res.IsError() {
 		return nil, fmt.Errorf("error getting document by id: %s", res.String())
 	}
@@ -173,13 +221,14 @@ func (c *ClientImpl) FindDocumentByID(ctx context.Context, indexName, id string)
 		return nil, fmt.Errorf("failed to decode get response: %w", err)
 	}
 	if !getResp.Found {
-		return nil, fmt.Errorf("document not found")
+		return nil, fmt.Errorf("%w: %s/%s", ErrNotFound, indexName, id)
 	}
 	return getResp.Source, nil
 }
 
 func (c *ClientImpl) DeleteDocumentByID(ctx context.Context, indexName, id string) error {
 	log := logger.NewContextLogger(ctx, "Client/DeleteDocumentByID")
+	ctx = withOperation(ctx, "delete", indexName)
 	req := opensearchapi.DeleteRequest{
 		Index:      indexName,
 		DocumentID: id,
@@ -191,6 +240,9 @@ func (c *ClientImpl) DeleteDocumentByID(ctx context.Context, indexName, id strin
 		return fmt.Errorf("failed to delete document by id: %w", err)
 	}
 	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s/%s", ErrNotFound, indexName, id)
+	}
 	if res.IsError() {
 		return fmt.Errorf("error deleting document by id: %s", res.String())
 	}
@@ -4,12 +4,18 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	"catalog-service/internal/config"
 	"catalog-service/internal/logger"
+	"catalog-service/internal/metrics"
+	"catalog-service/internal/tracing"
 
+	"github.com/opensearch-project/opensearch-go/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const TestIndexName = "test-index"
@@ -27,6 +33,18 @@ func (suite *ClientTestSuite) SetupTest() {
 	logger.Setup("INFO", "json")
 }
 
+func (suite *ClientTestSuite) Test_ClusterHealth_DecodesStatus() {
+	client := newMockClient(unmarshalJSON(`{"cluster_name": "catalog", "status": "yellow", "number_of_nodes": 1}`), http.StatusOK)
+
+	health, err := client.ClusterHealth(context.Background())
+
+	suite.Require().NoError(err)
+	suite.Equal(&ClusterHealth{ClusterName: "catalog", Status: "yellow", NumberOfNodes: 1}, health)
+
+	_, err = newMockClient(nil, http.StatusServiceUnavailable).ClusterHealth(context.Background())
+	suite.Error(err)
+}
+
 func (suite *ClientTestSuite) Test_IndexExists_ReturnsTrueWhenStatusOK() {
 	client := newMockClient(nil, http.StatusOK)
 
@@ -143,7 +161,7 @@ func (suite *ClientTestSuite) Test_FindDocumentByID_NotFound() {
 	client := newMockClient(unmarshalJSON(body), http.StatusOK)
 	ctx := context.Background()
 	_, err := client.FindDocumentByID(ctx, TestIndexName, "not-exist-id")
-	assert.Error(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, ErrNotFound)
 }
 
 func (suite *ClientTestSuite) Test_DeleteDocumentByID_Success() {
@@ -163,5 +181,155 @@ func (suite *ClientTestSuite) Test_DeleteDocumentByID_NotFound() {
 	client := newMockClient(unmarshalJSON(body), http.StatusNotFound)
 	ctx := context.Background()
 	err := client.DeleteDocumentByID(ctx, TestIndexName, "not-exist-id")
-	assert.Error(suite.T(), err)
-}
\ No newline at end of file
+	assert.ErrorIs(suite.T(), err, ErrNotFound)
+}
+func (suite *ClientTestSuite) Test_BulkIndex_ReportsPerItemErrors() {
+	body := `{
+		"errors": true,
+		"items": [
+			{ "index": { "_id": "doc1", "status": 201 } },
+			{ "index": { "_id": "doc2", "status": 400, "error": { "type": "mapper_parsing_exception", "reason": "failed to parse" } } }
+		]
+	}`
+	client := newMockClient(unmarshalJSON(body), http.StatusOK)
+
+	docs := make(chan BulkDocument, 2)
+	docs <- BulkDocument{ID: "doc1", Document: map[string]string{"name": "ok"}}
+	docs <- BulkDocument{ID: "doc2", Document: map[string]string{"name": "bad"}}
+	close(docs)
+
+	var reported []BulkItemResult
+	result, err := client.BulkIndex(context.Background(), TestIndexName, docs, BulkOptions{
+		OnItem: func(item BulkItemResult) { reported = append(reported, item) },
+	})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.Indexed)
+	assert.Len(suite.T(), result.Failed, 1)
+	assert.Equal(suite.T(), "doc2", result.Failed[0].ID)
+	assert.Equal(suite.T(), http.StatusBadRequest, result.Failed[0].Status)
+	assert.Contains(suite.T(), result.Failed[0].Err.Error(), "mapper_parsing_exception")
+	assert.Len(suite.T(), reported, 2)
+}
+
+func (suite *ClientTestSuite) Test_BulkIndex_FailsWholeBatchOnRequestError() {
+	client := newMockClient(nil, http.StatusInternalServerError)
+
+	docs := make(chan BulkDocument, 2)
+	docs <- BulkDocument{ID: "doc1", Document: map[string]string{"name": "one"}}
+	docs <- BulkDocument{ID: "doc2", Document: map[string]string{"name": "two"}}
+	close(docs)
+
+	result, err := client.BulkIndex(context.Background(), TestIndexName, docs, BulkOptions{})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, result.Indexed)
+	assert.Len(suite.T(), result.Failed, 2)
+}
+
+func (suite *ClientTestSuite) Test_Write_ReturnsResultsInOrder() {
+	body := `{
+		"errors": true,
+		"items": [
+			{ "delete": { "_index": "services", "_id": "svc-1", "status": 404, "result": "not_found" } },
+			{ "index": { "_index": "outbox", "_id": "msg-1", "status": 201, "result": "created" } },
+			{ "index": { "_index": "outbox", "_id": "msg-2", "status": 429, "error": { "type": "es_rejected_execution_exception", "reason": "queue full" } } }
+		]
+	}`
+	client := newMockClient(unmarshalJSON(body), http.StatusOK)
+
+	results, err := client.Write(context.Background(), []WriteOperation{
+		{Action: BulkActionDelete, Index: "services", ID: "svc-1"},
+		{Index: "outbox", ID: "msg-1", Document: map[string]string{"type": "service.deleted"}},
+		{Index: "outbox", ID: "msg-2", Document: map[string]string{"type": "service.deleted"}},
+	})
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), results, 3)
+	assert.ErrorIs(suite.T(), results[0].Err, ErrNotFound)
+	assert.NoError(suite.T(), results[1].Err)
+	assert.Equal(suite.T(), BulkResultCreated, results[1].Result)
+	assert.Contains(suite.T(), results[2].Err.Error(), "es_rejected_execution_exception")
+}
+
+func (suite *ClientTestSuite) Test_Scroll_PagesUntilEmptyAndClearsScroll() {
+	client, transport := newSequenceClient(
+		unmarshalJSON(`{"_scroll_id": "s1", "hits": {"hits": [{"_source": {"name": "a"}}, {"_source": {"name": "b"}}]}}`),
+		unmarshalJSON(`{"_scroll_id": "s2", "hits": {"hits": [{"_source": {"name": "c"}}]}}`),
+		unmarshalJSON(`{"_scroll_id": "s2", "hits": {"hits": []}}`),
+		unmarshalJSON(`{"succeeded": true}`),
+	)
+
+	var names []string
+	err := client.Scroll(context.Background(), TestIndexName, map[string]interface{}{"size": 2}, time.Minute, func(hits []map[string]interface{}) error {
+		for _, hit := range hits {
+			names = append(names, hit["name"].(string))
+		}
+		return nil
+	})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"a", "b", "c"}, names)
+	assert.Len(suite.T(), transport.requests, 4)
+	assert.Equal(suite.T(), http.MethodDelete, transport.requests[3].Method)
+}
+
+func (suite *ClientTestSuite) Test_Scroll_StopsOnCallbackError() {
+	client, transport := newSequenceClient(
+		unmarshalJSON(`{"_scroll_id": "s1", "hits": {"hits": [{"_source": {"name": "a"}}]}}`),
+		unmarshalJSON(`{"succeeded": true}`),
+	)
+
+	err := client.Scroll(context.Background(), TestIndexName, map[string]interface{}{}, time.Minute, func(hits []map[string]interface{}) error {
+		return assert.AnError
+	})
+
+	assert.ErrorIs(suite.T(), err, assert.AnError)
+	assert.Len(suite.T(), transport.requests, 2)
+	assert.Equal(suite.T(), http.MethodDelete, transport.requests[1].Method)
+}
+
+func (suite *ClientTestSuite) Test_Transport_CountsFailuresButNotMissingDocuments() {
+	errorSeries := func() int {
+		count, err := testutil.GatherAndCount(metrics.Registry, "catalog_opensearch_errors_total")
+		suite.Require().NoError(err)
+		return count
+	}
+	roundTrip := func(operation string, status int) {
+		transport := instrumentedTransport{next: &mockTransport{resp: &http.Response{StatusCode: status, Body: http.NoBody}}}
+		req, _ := http.NewRequestWithContext(withOperation(context.Background(), operation, TestIndexName), http.MethodGet, "http://mock:9200", nil)
+		_, err := transport.RoundTrip(req)
+		suite.Require().NoError(err)
+	}
+	before := errorSeries()
+
+	roundTrip("test_missing", http.StatusNotFound)
+	suite.Equal(before, errorSeries())
+
+	roundTrip("test_failing", http.StatusInternalServerError)
+	suite.Equal(before+1, errorSeries())
+}
+
+func (suite *ClientTestSuite) Test_Transport_TracesRequestsAndPropagatesContext() {
+	spans := tracing.Record()
+	transport := &sequenceTransport{bodies: []map[string]interface{}{unmarshalJSON(`{"found": true, "_source": {"name": "a"}}`)}}
+	osClient, _ := opensearch.NewClient(opensearch.Config{
+		Addresses: []string{"http://mock:9200"},
+		Transport: instrumentedTransport{next: transport},
+	})
+	client := &ClientImpl{Client: osClient}
+	ctx, parent := tracing.Tracer().Start(context.Background(), "request")
+
+	_, err := client.FindDocumentByID(ctx, TestIndexName, "doc1")
+	parent.End()
+
+	suite.Require().NoError(err)
+	ended := spans.GetSpans()
+	suite.Require().Len(ended, 2)
+	span := ended[0]
+	suite.Equal("opensearch.get", span.Name)
+	suite.Equal(parent.SpanContext().SpanID(), span.Parent.SpanID())
+	suite.Contains(span.Attributes, attribute.String("db.operation.name", "get"))
+	suite.Contains(span.Attributes, attribute.String("db.collection.name", TestIndexName))
+	suite.Contains(transport.requests[0].Header.Get("traceparent"), span.SpanContext.SpanID().String())
+}
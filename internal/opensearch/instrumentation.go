@@ -0,0 +1,72 @@
+package opensearch
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"catalog-service/internal/metrics"
+	"catalog-service/internal/tracing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type operationKey struct{}
+
+type operation struct {
+	name  string
+	index string
+}
+
+// withOperation names the OpenSearch operation and index of the requests made
+// with ctx in their metrics and spans.
+func withOperation(ctx context.Context, name, index string) context.Context {
+	return context.WithValue(ctx, operationKey{}, operation{name: name, index: index})
+}
+
+// instrumentedTransport records the latency of every request, counts failed
+// ones and traces each request in a client span whose context is sent to
+// OpenSearch in the traceparent header. Missing documents are not failures.
+type instrumentedTransport struct {
+	next http.RoundTripper
+}
+
+func (t instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	op, _ := req.Context().Value(operationKey{}).(operation)
+	if op.name == "" {
+		op.name = "other"
+	}
+	ctx, span := tracing.Tracer().Start(req.Context(), "opensearch."+op.name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system.name", "opensearch"),
+			attribute.String("db.operation.name", op.name),
+			attribute.String("db.collection.name", op.index),
+			attribute.String("http.request.method", req.Method),
+			attribute.String("server.address", req.URL.Host),
+		))
+	defer span.End()
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	start := time.Now()
+	res, err := t.next.RoundTrip(req)
+	failed := err != nil || (res.StatusCode >= http.StatusBadRequest && res.StatusCode != http.StatusNotFound)
+	metrics.ObserveOpenSearch(op.name, time.Since(start), failed)
+
+	switch {
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case failed:
+		span.SetAttributes(attribute.Int("http.response.status_code", res.StatusCode))
+		span.SetStatus(codes.Error, res.Status)
+	default:
+		span.SetAttributes(attribute.Int("http.response.status_code", res.StatusCode))
+	}
+	return res, err
+}
@@ -0,0 +1,315 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"catalog-service/internal/logger"
+	"catalog-service/internal/metrics"
+)
+
+// ErrUnavailable is returned when OpenSearch did not answer an operation, even
+// after retries, or when the circuit breaker is open. The API answers 503.
+var ErrUnavailable = errors.New("opensearch is unavailable")
+
+// idempotentOperations can be sent again after any transient failure. The
+// others, such as deletes and bulk writes, are only sent again when OpenSearch
+// cannot have executed them: a 429 or a failed connection. Retrying a delete
+// that was executed would report a missing document.
+var idempotentOperations = map[string]bool{
+	"cluster_health": true,
+	"index_exists":   true,
+	"search":         true,
+	"get":            true,
+	"index":          true,
+	"refresh":        true,
+	"clear_scroll":   true,
+}
+
+type ResilienceOptions struct {
+	MaxRetries     int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Timeout returns the timeout of one attempt of an operation.
+	Timeout         func(operation string) time.Duration
+	BreakerFailures int
+	BreakerCooldown time.Duration
+}
+
+// resilientTransport retries transient failures with jittered exponential
+// backoff, honouring Retry-After, bounds every attempt with the timeout of its
+// operation and stops sending requests while the circuit breaker is open.
+type resilientTransport struct {
+	next    http.RoundTripper
+	opts    ResilienceOptions
+	breaker *breaker
+	sleep   func(ctx context.Context, d time.Duration) error
+}
+
+func newResilientTransport(next http.RoundTripper, opts ResilienceOptions) *resilientTransport {
+	if opts.InitialBackoff <= 0 {
+		opts.InitialBackoff = 100 * time.Millisecond
+	}
+	if opts.MaxBackoff < opts.InitialBackoff {
+		opts.MaxBackoff = opts.InitialBackoff
+	}
+	return &resilientTransport{
+		next:    next,
+		opts:    opts,
+		breaker: newBreaker(opts.BreakerFailures, opts.BreakerCooldown),
+		sleep:   sleepContext,
+	}
+}
+
+func (t *resilientTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	op, _ := req.Context().Value(operationKey{}).(operation)
+	getBody, err := replayableBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The breaker counts operations, not attempts, so one operation retried
+	// MaxRetries times is a single failure.
+	probe, err := t.breaker.allow()
+	if err != nil {
+		return nil, err
+	}
+	for attempt := 0; ; attempt++ {
+		res, err := t.attempt(req, op.name, getBody)
+		if req.Context().Err() != nil {
+			// The caller gave up, which says nothing about OpenSearch.
+			t.breaker.release(probe)
+			return nil, req.Context().Err()
+		}
+		failed, executed := classify(res, err)
+		if !failed {
+			t.breaker.record(true)
+			return res, err
+		}
+
+		retryable := !executed || idempotentOperations[op.name]
+		wait := t.backoff(attempt, res)
+		if !retryable || attempt >= t.opts.MaxRetries || wait > t.opts.MaxBackoff {
+			t.breaker.record(false)
+			return nil, unavailable(res, err, attempt+1)
+		}
+		logger.NewContextLogger(req.Context(), "Client/RoundTrip").Warnf("opensearch %s failed (%s), retry %d in %s", op.name, cause(res, err), attempt+1, wait)
+		metrics.OpenSearchRetry(op.name)
+		if res != nil {
+			res.Body.Close()
+		}
+		if err := t.sleep(req.Context(), wait); err != nil {
+			t.breaker.release(probe)
+			return nil, err
+		}
+	}
+}
+
+// attempt sends one copy of req, bounded by the timeout of the operation. The
+// response body is read before returning, so the timeout can be released.
+func (t *resilientTransport) attempt(req *http.Request, operation string, getBody func() (io.ReadCloser, error)) (*http.Response, error) {
+	ctx := req.Context()
+	if t.opts.Timeout != nil {
+		if timeout := t.opts.Timeout(operation); timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+	}
+	clone := req.Clone(ctx)
+	if getBody != nil {
+		body, err := getBody()
+		if err != nil {
+			return nil, err
+		}
+		clone.Body = body
+	}
+
+	res, err := t.next.RoundTrip(clone)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	return res, nil
+}
+
+// backoff returns the Retry-After of res when it has one, and otherwise a
+// random duration up to InitialBackoff doubled attempt times.
+func (t *resilientTransport) backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if wait, ok := retryAfter(res.Header.Get("Retry-After")); ok {
+			return wait
+		}
+	}
+	ceiling := t.opts.MaxBackoff
+	if attempt < 32 {
+		ceiling = min(t.opts.InitialBackoff<<attempt, t.opts.MaxBackoff)
+	}
+	return time.Duration(rand.Int64N(int64(ceiling) + 1))
+}
+
+// classify reports whether an attempt failed transiently and whether
+// OpenSearch may have executed it.
+func classify(res *http.Response, err error) (failed, executed bool) {
+	if err != nil {
+		return true, !notSent(err)
+	}
+	switch res.StatusCode {
+	case http.StatusTooManyRequests:
+		return true, false
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true, true
+	}
+	return false, true
+}
+
+// notSent reports whether err happened before the request reached OpenSearch.
+func notSent(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
+
+func unavailable(res *http.Response, err error, attempts int) error {
+	if res != nil {
+		res.Body.Close()
+	}
+	return fmt.Errorf("%w: %s after %d attempt(s)", ErrUnavailable, cause(res, err), attempts)
+}
+
+func cause(res *http.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return res.Status
+}
+
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+func replayableBody(req *http.Request) (func() (io.ReadCloser, error), error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		return req.GetBody, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	return func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(body)), nil }, nil
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// breaker opens after failures consecutive failed operations and then rejects
+// requests for cooldown. After that a single probe request is let through; it
+// closes the breaker when it succeeds and opens it again when it fails.
+type breaker struct {
+	failures int
+	cooldown time.Duration
+	now      func() time.Time
+
+	mu       sync.Mutex
+	count    int
+	openedAt time.Time
+	open     bool
+	probing  bool
+}
+
+func newBreaker(failures int, cooldown time.Duration) *breaker {
+	return &breaker{failures: failures, cooldown: cooldown, now: time.Now}
+}
+
+// allow reports whether a request may be sent, and whether it is the probe.
+func (b *breaker) allow() (probe bool, err error) {
+	if b.failures <= 0 {
+		return false, nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.open {
+		return false, nil
+	}
+	if b.probing || b.now().Sub(b.openedAt) < b.cooldown {
+		return false, fmt.Errorf("%w: circuit breaker is open", ErrUnavailable)
+	}
+	b.probing = true
+	return true, nil
+}
+
+// release lets another probe through when the probe ended without an outcome.
+func (b *breaker) release(probe bool) {
+	if !probe {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+func (b *breaker) record(ok bool) {
+	if b.failures <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	wasOpen := b.open
+	b.probing = false
+	if ok {
+		b.count = 0
+		b.open = false
+	} else {
+		b.count++
+		if b.open || b.count >= b.failures {
+			b.open = true
+			b.openedAt = b.now()
+		}
+	}
+	if b.open != wasOpen {
+		metrics.OpenSearchBreakerOpen(b.open)
+		if b.open {
+			logger.NonContext.Warnf("opensearch circuit breaker opened after %d failed operations", b.count)
+		} else {
+			logger.NonContext.Info("opensearch circuit breaker closed")
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package opensearch
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"catalog-service/internal/config"
+	"catalog-service/internal/logger"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type attemptFunc func(req *http.Request) (*http.Response, error)
+
+// scriptedTransport answers the n-th request with the n-th step, repeating the
+// last one, and keeps the bodies it was sent.
+type scriptedTransport struct {
+	steps  []attemptFunc
+	bodies []string
+}
+
+func (t *scriptedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := ""
+	if req.Body != nil {
+		b, _ := io.ReadAll(req.Body)
+		body = string(b)
+	}
+	t.bodies = append(t.bodies, body)
+	step := t.steps[min(len(t.bodies), len(t.steps))-1]
+	return step(req)
+}
+
+func status(code int, headers ...string) attemptFunc {
+	return func(*http.Request) (*http.Response, error) {
+		res := &http.Response{StatusCode: code, Status: http.StatusText(code), Header: http.Header{}, Body: io.NopCloser(strings.NewReader(`{}`))}
+		for i := 0; i+1 < len(headers); i += 2 {
+			res.Header.Set(headers[i], headers[i+1])
+		}
+		return res, nil
+	}
+}
+
+func fail(err error) attemptFunc {
+	return func(*http.Request) (*http.Response, error) { return nil, err }
+}
+
+type ResilienceSuite struct {
+	suite.Suite
+	next      *scriptedTransport
+	transport *resilientTransport
+	waits     []time.Duration
+	now       time.Time
+}
+
+func TestResilienceSuite(t *testing.T) {
+	suite.Run(t, new(ResilienceSuite))
+}
+
+func (suite *ResilienceSuite) SetupTest() {
+	config.Load()
+	logger.Setup("INFO", "json")
+	suite.next = &scriptedTransport{}
+	suite.waits = nil
+	suite.now = time.Now()
+	suite.transport = newResilientTransport(suite.next, ResilienceOptions{
+		MaxRetries:      3,
+		InitialBackoff:  100 * time.Millisecond,
+		MaxBackoff:      5 * time.Second,
+		BreakerFailures: 5,
+		BreakerCooldown: 10 * time.Second,
+	})
+	suite.transport.sleep = func(_ context.Context, d time.Duration) error {
+		suite.waits = append(suite.waits, d)
+		return nil
+	}
+	suite.transport.breaker.now = func() time.Time { return suite.now }
+}
+
+func (suite *ResilienceSuite) send(operation, method string) (*http.Response, error) {
+	req, _ := http.NewRequestWithContext(withOperation(context.Background(), operation, TestIndexName), method, "http://mock:9200/"+TestIndexName, strings.NewReader(`{"q":1}`))
+	return suite.transport.RoundTrip(req)
+}
+
+func (suite *ResilienceSuite) Test_RetriesIdempotentOperationsWithBackoff() {
+	suite.next.steps = []attemptFunc{status(503), fail(io.EOF), status(200)}
+
+	res, err := suite.send("search", http.MethodPost)
+
+	suite.Require().NoError(err)
+	suite.Equal(http.StatusOK, res.StatusCode)
+	suite.Equal([]string{`{"q":1}`, `{"q":1}`, `{"q":1}`}, suite.next.bodies, "the body is sent again")
+	suite.Require().Len(suite.waits, 2)
+	suite.LessOrEqual(suite.waits[0], 100*time.Millisecond)
+	suite.LessOrEqual(suite.waits[1], 200*time.Millisecond)
+}
+
+func (suite *ResilienceSuite) Test_HonoursRetryAfter() {
+	suite.next.steps = []attemptFunc{status(429, "Retry-After", "2"), status(200)}
+
+	_, err := suite.send("search", http.MethodPost)
+
+	suite.Require().NoError(err)
+	suite.Equal([]time.Duration{2 * time.Second}, suite.waits)
+}
+
+func (suite *ResilienceSuite) Test_GivesUpWhenRetryAfterExceedsMaxBackoff() {
+	suite.next.steps = []attemptFunc{status(503, "Retry-After", "60")}
+
+	_, err := suite.send("search", http.MethodPost)
+
+	suite.ErrorIs(err, ErrUnavailable)
+	suite.Len(suite.next.bodies, 1)
+}
+
+func (suite *ResilienceSuite) Test_RetriesWritesOnlyWhenNotExecuted() {
+	suite.next.steps = []attemptFunc{status(503)}
+	_, err := suite.send("delete", http.MethodDelete)
+	suite.ErrorIs(err, ErrUnavailable)
+	suite.Len(suite.next.bodies, 1, "a delete that may have run is not sent again")
+
+	suite.SetupTest()
+	suite.next.steps = []attemptFunc{status(429), fail(&net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}), status(200)}
+	res, err := suite.send("delete", http.MethodDelete)
+	suite.Require().NoError(err)
+	suite.Equal(http.StatusOK, res.StatusCode)
+	suite.Len(suite.next.bodies, 3)
+}
+
+func (suite *ResilienceSuite) Test_ReturnsUnavailableAfterLastRetry() {
+	suite.next.steps = []attemptFunc{status(502)}
+
+	_, err := suite.send("get", http.MethodGet)
+
+	suite.ErrorIs(err, ErrUnavailable)
+	suite.ErrorContains(err, "after 4 attempt(s)")
+}
+
+func (suite *ResilienceSuite) Test_BoundsAttemptsWithOperationTimeout() {
+	suite.transport.opts.MaxRetries = 0
+	suite.transport.opts.Timeout = func(operation string) time.Duration {
+		suite.Equal("get", operation)
+		return 10 * time.Millisecond
+	}
+	suite.next.steps = []attemptFunc{func(req *http.Request) (*http.Response, error) {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	}}
+
+	start := time.Now()
+	_, err := suite.send("get", http.MethodGet)
+
+	suite.ErrorIs(err, ErrUnavailable)
+	suite.Less(time.Since(start), time.Second)
+}
+
+func (suite *ResilienceSuite) Test_BreakerFailsFastUntilProbeSucceeds() {
+	suite.transport.opts.MaxRetries = 0
+	suite.next.steps = []attemptFunc{status(503)}
+	for range 5 {
+		_, _ = suite.send("search", http.MethodPost)
+	}
+
+	_, err := suite.send("search", http.MethodPost)
+	suite.ErrorIs(err, ErrUnavailable)
+	suite.ErrorContains(err, "circuit breaker is open")
+	suite.Len(suite.next.bodies, 5, "no request is sent while open")
+
+	suite.now = suite.now.Add(10 * time.Second)
+	suite.next.steps = []attemptFunc{status(200)}
+	_, err = suite.send("search", http.MethodPost)
+	suite.Require().NoError(err)
+	_, err = suite.send("search", http.MethodPost)
+	suite.NoError(err)
+}
+
+func (suite *ResilienceSuite) Test_BreakerCountsOperationsNotAttempts() {
+	suite.next.steps = []attemptFunc{status(503)}
+	for range 4 {
+		_, err := suite.send("search", http.MethodPost)
+		suite.ErrorContains(err, "after 4 attempt(s)")
+	}
+	suite.Len(suite.next.bodies, 16)
+
+	_, err := suite.send("search", http.MethodPost)
+	suite.ErrorContains(err, "after 4 attempt(s)", "the fifth operation is still sent")
+	_, err = suite.send("search", http.MethodPost)
+	suite.ErrorContains(err, "circuit breaker is open")
+}
+
+func (suite *ResilienceSuite) Test_FailedProbeReopensBreaker() {
+	suite.transport.opts.MaxRetries = 0
+	suite.next.steps = []attemptFunc{status(503)}
+	for range 5 {
+		_, _ = suite.send("search", http.MethodPost)
+	}
+	suite.now = suite.now.Add(10 * time.Second)
+
+	_, _ = suite.send("search", http.MethodPost)
+	_, err := suite.send("search", http.MethodPost)
+
+	suite.ErrorContains(err, "circuit breaker is open")
+	suite.Len(suite.next.bodies, 6)
+}
+
+func (suite *ResilienceSuite) Test_CancelledProbeLetsTheNextOneThrough() {
+	suite.transport.opts.MaxRetries = 0
+	suite.next.steps = []attemptFunc{status(503)}
+	for range 5 {
+		_, _ = suite.send("search", http.MethodPost)
+	}
+	suite.now = suite.now.Add(10 * time.Second)
+
+	ctx, cancel := context.WithCancel(withOperation(context.Background(), "search", TestIndexName))
+	suite.next.steps = []attemptFunc{func(req *http.Request) (*http.Response, error) {
+		cancel()
+		return nil, req.Context().Err()
+	}}
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "http://mock:9200/"+TestIndexName, strings.NewReader(`{"q":1}`))
+	_, err := suite.transport.RoundTrip(req)
+	suite.ErrorIs(err, context.Canceled)
+
+	suite.next.steps = []attemptFunc{status(200)}
+	_, err = suite.send("search", http.MethodPost)
+	suite.NoError(err, "the cancelled probe does not hold the breaker open")
+}
+
+func (suite *ResilienceSuite) Test_NotFoundIsNotAFailure() {
+	suite.next.steps = []attemptFunc{status(404)}
+
+	res, err := suite.send("get", http.MethodGet)
+
+	suite.Require().NoError(err)
+	suite.Equal(http.StatusNotFound, res.StatusCode)
+	suite.Empty(suite.waits)
+}
@@ -0,0 +1,111 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"catalog-service/internal/logger"
+
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+)
+
+type ScrollFunc func(hits []map[string]interface{}) error
+
+type scrollResponse struct {
+	ScrollID string `json:"_scroll_id"`
+	Hits     struct {
+		Hits []struct {
+			Source map[string]interface{} `json:"_source"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+func (c *ClientImpl) Scroll(ctx context.Context, indexName string, searchBody map[string]interface{}, keepAlive time.Duration, fn ScrollFunc) error {
+	log := logger.NewContextLogger(ctx, "Client/Scroll")
+	ctx = withOperation(ctx, "scroll", indexName)
+	searchBodyBytes, err := json.Marshal(searchBody)
+	if err != nil {
+		return fmt.Errorf("failed to marshal search query: %w", err)
+	}
+
+	log.Debugf("scroll body: %s", searchBodyBytes)
+	req := opensearchapi.SearchRequest{
+		Index:  []string{indexName},
+		Body:   bytes.NewReader(searchBodyBytes),
+		Scroll: keepAlive,
+	}
+	res, err := req.Do(ctx, c.Client)
+	if err != nil {
+		return fmt.Errorf("failed to execute scroll query: %w", err)
+	}
+	page, err := decodeScrollResponse(res)
+	if err != nil {
+		return err
+	}
+
+	scrollID := page.ScrollID
+	defer func() {
+		if scrollID != "" {
+			c.clearScroll(ctx, scrollID)
+		}
+	}()
+
+	for pages := 1; ; pages++ {
+		if len(page.Hits.Hits) == 0 {
+			log.Debugf("scroll finished after %d pages", pages)
+			return nil
+		}
+		hits := make([]map[string]interface{}, 0, len(page.Hits.Hits))
+		for _, hit := range page.Hits.Hits {
+			if hit.Source != nil {
+				hits = append(hits, hit.Source)
+			}
+		}
+		if err := fn(hits); err != nil {
+			return err
+		}
+
+		next := opensearchapi.ScrollRequest{ScrollID: scrollID, Scroll: keepAlive}
+		res, err := next.Do(ctx, c.Client)
+		if err != nil {
+			return fmt.Errorf("failed to fetch next scroll page: %w", err)
+		}
+		if page, err = decodeScrollResponse(res); err != nil {
+			return err
+		}
+		if page.ScrollID != "" {
+			scrollID = page.ScrollID
+		}
+	}
+}
+
+func (c *ClientImpl) clearScroll(ctx context.Context, scrollID string) {
+	log := logger.NewContextLogger(ctx, "Client/Scroll")
+	req := opensearchapi.ClearScrollRequest{ScrollID: []string{scrollID}}
+	res, err := req.Do(withOperation(context.WithoutCancel(ctx), "clear_scroll", ""), c.Client)
+	if err != nil {
+		log.Errorf(err, "failed to clear scroll")
+		return
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+	if res.IsError() {
+		log.Warnf("failed to clear scroll: %s", res.String())
+	}
+}
+
+func decodeScrollResponse(res *opensearchapi.Response) (*scrollResponse, error) {
+	defer res.Body.Close()
+	if res.IsError() {
+		return nil, fmt.Errorf("error executing scroll query: %s", res.String())
+	}
+	var page scrollResponse
+	if err := json.NewDecoder(res.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to decode scroll response: %w", err)
+	}
+	return &page, nil
+}
@@ -40,3 +40,31 @@ func unmarshalJSON(jsonStr string) map[string]interface{} {
 	_ = json.Unmarshal([]byte(jsonStr), &result)
 	return result
 }
+
+type sequenceTransport struct {
+	bodies   []map[string]interface{}
+	requests []*http.Request
+}
+
+func (m *sequenceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body map[string]interface{}
+	if n := len(m.requests); n < len(m.bodies) {
+		body = m.bodies[n]
+	}
+	m.requests = append(m.requests, req)
+	b, _ := json.Marshal(body)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader(b)),
+		Header:     make(http.Header),
+	}, nil
+}
+
+func newSequenceClient(bodies ...map[string]interface{}) (*ClientImpl, *sequenceTransport) {
+	transport := &sequenceTransport{bodies: bodies}
+	osClient, _ := opensearch.NewClient(opensearch.Config{
+		Addresses: []string{"http://mock:9200"},
+		Transport: transport,
+	})
+	return &ClientImpl{Client: osClient}, transport
+}
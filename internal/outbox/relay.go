@@ -0,0 +1,86 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"catalog-service/internal/logger"
+	"catalog-service/internal/repository"
+)
+
+const maxErrorLength = 512
+
+type Options struct {
+	PollInterval time.Duration
+	BatchSize    int
+}
+
+// Relay publishes outbox messages to a sink in the order they were written and
+// removes each one once published. A message whose removal fails is published
+// again, so delivery is at least once; consumers deduplicate on the message id.
+type Relay struct {
+	repo repository.OutboxRepository
+	sink Sink
+	opts Options
+}
+
+func NewRelay(repo repository.OutboxRepository, sink Sink, opts Options) *Relay {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = time.Second
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+	return &Relay{repo: repo, sink: sink, opts: opts}
+}
+
+// Run relays messages until ctx is cancelled. Full batches are followed
+// immediately by the next one; otherwise the outbox is polled every
+// PollInterval.
+func (r *Relay) Run(ctx context.Context) {
+	for {
+		relayed, err := r.RelayBatch(ctx)
+		if err != nil {
+			logger.NewContextLogger(ctx, "Relay/Run").Errorf(err, "failed to relay outbox messages")
+		}
+		if err == nil && relayed == r.opts.BatchSize {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.opts.PollInterval):
+		}
+	}
+}
+
+// RelayBatch publishes the oldest pending messages and returns how many were
+// published. It stops at the first failure so later messages are not
+// published ahead of it.
+func (r *Relay) RelayBatch(ctx context.Context) (int, error) {
+	log := logger.NewContextLogger(ctx, "Relay/RelayBatch")
+	messages, err := r.repo.Pending(ctx, r.opts.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, msg := range messages {
+		if err := r.sink.Publish(ctx, msg); err != nil {
+			msg.Attempts++
+			msg.LastError = err.Error()
+			if len(msg.LastError) > maxErrorLength {
+				msg.LastError = msg.LastError[:maxErrorLength]
+			}
+			if saveErr := r.repo.Save(ctx, msg); saveErr != nil {
+				log.Errorf(saveErr, "failed to record attempt %d of outbox message %s", msg.Attempts, msg.ID)
+			}
+			log.Warnf("outbox message %s (%s for %s) not published, attempt %d: %v", msg.ID, msg.Event.Type, msg.Event.ServiceID, msg.Attempts, err)
+			return i, err
+		}
+		if err := r.repo.Delete(ctx, msg.ID); err != nil && !errors.Is(err, repository.ErrNotFound) {
+			log.Errorf(err, "outbox message %s was published but not removed; it will be published again", msg.ID)
+		}
+	}
+	return len(messages), nil
+}
@@ -0,0 +1,109 @@
+package outbox
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"catalog-service/internal/config"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	mockrepo "catalog-service/test/mocks/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RelaySuite struct {
+	suite.Suite
+	repo *mockrepo.OutboxRepository
+	sink *MemorySink
+}
+
+func TestRelaySuite(t *testing.T) {
+	suite.Run(t, new(RelaySuite))
+}
+
+func (suite *RelaySuite) SetupTest() {
+	config.Load()
+	logger.Setup("INFO", "json")
+	suite.repo = new(mockrepo.OutboxRepository)
+	suite.sink = &MemorySink{}
+}
+
+func pending(ids ...string) []*models.OutboxMessage {
+	messages := make([]*models.OutboxMessage, 0, len(ids))
+	for i, id := range ids {
+		messages = append(messages, &models.OutboxMessage{ID: id, Sequence: i, Event: models.ServiceEvent{Type: models.EventServiceUpdated, ServiceID: "svc-1"}})
+	}
+	return messages
+}
+
+func (suite *RelaySuite) Test_PublishesInOrderAndRemovesMessages() {
+	suite.repo.On("Pending", mock.Anything, 10).Return(pending("m1", "m2"), nil)
+	suite.repo.On("Delete", mock.Anything, "m1").Return(nil)
+	suite.repo.On("Delete", mock.Anything, "m2").Return(nil)
+
+	relayed, err := NewRelay(suite.repo, suite.sink, Options{BatchSize: 10}).RelayBatch(context.Background())
+
+	suite.Require().NoError(err)
+	suite.Equal(2, relayed)
+	published := suite.sink.Messages()
+	suite.Require().Len(published, 2)
+	suite.Equal("m1", published[0].ID)
+	suite.Equal("m2", published[1].ID)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *RelaySuite) Test_StopsAtFirstFailureAndRecordsAttempt() {
+	suite.repo.On("Pending", mock.Anything, 10).Return(pending("m1", "m2"), nil)
+	suite.repo.On("Save", mock.Anything, mock.MatchedBy(func(msg *models.OutboxMessage) bool {
+		return msg.ID == "m1" && msg.Attempts == 1 && msg.LastError == assert.AnError.Error()
+	})).Return(nil)
+	suite.sink.Fail(assert.AnError)
+
+	relayed, err := NewRelay(suite.repo, suite.sink, Options{BatchSize: 10}).RelayBatch(context.Background())
+
+	suite.ErrorIs(err, assert.AnError)
+	suite.Equal(0, relayed)
+	suite.repo.AssertExpectations(suite.T())
+	suite.repo.AssertNotCalled(suite.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (suite *RelaySuite) Test_RepublishesMessagesThatWereNotRemoved() {
+	suite.repo.On("Pending", mock.Anything, 10).Return(pending("m1"), nil).Twice()
+	suite.repo.On("Delete", mock.Anything, "m1").Return(assert.AnError).Once()
+	suite.repo.On("Delete", mock.Anything, "m1").Return(nil).Once()
+	relay := NewRelay(suite.repo, suite.sink, Options{BatchSize: 10})
+
+	_, err := relay.RelayBatch(context.Background())
+	suite.Require().NoError(err)
+	_, err = relay.RelayBatch(context.Background())
+	suite.Require().NoError(err)
+
+	published := suite.sink.Messages()
+	suite.Require().Len(published, 2)
+	suite.Equal(published[0].ID, published[1].ID)
+}
+
+func (suite *RelaySuite) Test_RunPollsUntilCancelled() {
+	suite.repo.On("Pending", mock.Anything, 10).Return(pending("m1"), nil).Once()
+	suite.repo.On("Pending", mock.Anything, 10).Return([]*models.OutboxMessage{}, nil)
+	suite.repo.On("Delete", mock.Anything, "m1").Return(nil)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		NewRelay(suite.repo, suite.sink, Options{BatchSize: 10, PollInterval: 10 * time.Millisecond}).Run(ctx)
+	}()
+
+	suite.Eventually(func() bool { return len(suite.sink.Messages()) == 1 }, time.Second, 5*time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		suite.Fail("relay did not stop")
+	}
+}
@@ -0,0 +1,202 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"catalog-service/internal/events"
+	"catalog-service/internal/models"
+)
+
+const (
+	// DedupHeader carries the message id on HTTP deliveries. It is the same on
+	// every redelivery of a message.
+	DedupHeader = "Idempotency-Key"
+
+	recentIDs = 1024
+)
+
+// Sink publishes outbox messages. Publish may be called more than once for the
+// same message; sinks and their consumers deduplicate on the message id.
+type Sink interface {
+	Publish(ctx context.Context, msg *models.OutboxMessage) error
+}
+
+// FileSink appends one JSON event per line to a file.
+type FileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open outbox file: %w", err)
+	}
+	return &FileSink{file: file}, nil
+}
+
+func (s *FileSink) Publish(_ context.Context, msg *models.OutboxMessage) error {
+	line, err := json.Marshal(eventOf(msg))
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return s.file.Sync()
+}
+
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
+
+// HTTPSink POSTs the event of each message as JSON, with the message id in
+// DedupHeader. Any 2xx response counts as published.
+type HTTPSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewHTTPSink(url string, timeout time.Duration) *HTTPSink {
+	return &HTTPSink{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+func (s *HTTPSink) Publish(ctx context.Context, msg *models.OutboxMessage) error {
+	body, err := json.Marshal(eventOf(msg))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(DedupHeader, msg.ID)
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("sink responded %s", res.Status)
+	}
+	return nil
+}
+
+// MemorySink keeps published messages in memory, for tests.
+type MemorySink struct {
+	mu       sync.Mutex
+	messages []*models.OutboxMessage
+	err      error
+}
+
+func (s *MemorySink) Publish(_ context.Context, msg *models.OutboxMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.messages = append(s.messages, msg)
+	return nil
+}
+
+func (s *MemorySink) Messages() []*models.OutboxMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*models.OutboxMessage(nil), s.messages...)
+}
+
+// Fail makes Publish return err until it is called with nil.
+func (s *MemorySink) Fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// BrokerSink publishes messages to the in-process broker that serves the event
+// stream and webhooks. Messages it has recently published are skipped, so a
+// relay retrying after a failed acknowledgement does not emit the event twice.
+type BrokerSink struct {
+	broker *events.Broker
+
+	mu     sync.Mutex
+	seen   map[string]struct{}
+	recent []string
+}
+
+func NewBrokerSink(broker *events.Broker) *BrokerSink {
+	return &BrokerSink{broker: broker, seen: map[string]struct{}{}}
+}
+
+func (s *BrokerSink) Publish(ctx context.Context, msg *models.OutboxMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.seen[msg.ID]; ok {
+		return nil
+	}
+	if err := s.broker.Publish(ctx, eventOf(msg)); err != nil {
+		return err
+	}
+	if len(s.recent) == recentIDs {
+		delete(s.seen, s.recent[0])
+		s.recent = s.recent[1:]
+	}
+	s.seen[msg.ID] = struct{}{}
+	s.recent = append(s.recent, msg.ID)
+	return nil
+}
+
+// Fanout publishes every message to all sinks. Publish succeeds once every
+// sink took the message; when it is retried, sinks that already took it are
+// skipped.
+type Fanout struct {
+	sinks []Sink
+
+	mu        sync.Mutex
+	delivered map[string][]bool
+}
+
+func NewFanout(sinks ...Sink) *Fanout {
+	return &Fanout{sinks: sinks, delivered: map[string][]bool{}}
+}
+
+func (f *Fanout) Publish(ctx context.Context, msg *models.OutboxMessage) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	done, ok := f.delivered[msg.ID]
+	if !ok {
+		done = make([]bool, len(f.sinks))
+		f.delivered[msg.ID] = done
+	}
+	for i, sink := range f.sinks {
+		if done[i] {
+			continue
+		}
+		if err := sink.Publish(ctx, msg); err != nil {
+			return err
+		}
+		done[i] = true
+	}
+	delete(f.delivered, msg.ID)
+	return nil
+}
+
+// eventOf returns a copy of the event, so sinks that assign ids do not change
+// the stored message.
+func eventOf(msg *models.OutboxMessage) *models.ServiceEvent {
+	event := msg.Event
+	event.DedupID = msg.ID
+	return &event
+}
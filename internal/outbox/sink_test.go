@@ -0,0 +1,112 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"catalog-service/internal/config"
+	"catalog-service/internal/events"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	mockrepo "catalog-service/test/mocks/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type SinkSuite struct {
+	suite.Suite
+	msg *models.OutboxMessage
+}
+
+func TestSinkSuite(t *testing.T) {
+	suite.Run(t, new(SinkSuite))
+}
+
+func (suite *SinkSuite) SetupTest() {
+	config.Load()
+	logger.Setup("INFO", "json")
+	suite.msg = &models.OutboxMessage{ID: "msg-1", Event: models.ServiceEvent{Type: models.EventServiceCreated, ServiceID: "svc-1", ChangedFields: []string{"name"}}}
+}
+
+func (suite *SinkSuite) Test_FileSink_AppendsEventsWithDedupID() {
+	path := filepath.Join(suite.T().TempDir(), "outbox.jsonl")
+	sink, err := NewFileSink(path)
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(sink.Publish(context.Background(), suite.msg))
+	suite.Require().NoError(sink.Publish(context.Background(), suite.msg))
+	suite.Require().NoError(sink.Close())
+
+	content, err := os.ReadFile(path)
+	suite.Require().NoError(err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	suite.Require().Len(lines, 2)
+	var event models.ServiceEvent
+	suite.Require().NoError(json.Unmarshal([]byte(lines[0]), &event))
+	suite.Equal("msg-1", event.DedupID)
+	suite.Equal("svc-1", event.ServiceID)
+	suite.Empty(suite.msg.Event.DedupID, "the stored message is not changed")
+}
+
+func (suite *SinkSuite) Test_HTTPSink_SendsDedupHeader() {
+	var got *http.Request
+	status := http.StatusAccepted
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+	sink := NewHTTPSink(server.URL, time.Second)
+
+	suite.Require().NoError(sink.Publish(context.Background(), suite.msg))
+	suite.Equal("msg-1", got.Header.Get(DedupHeader))
+	suite.Equal("application/json", got.Header.Get("Content-Type"))
+
+	status = http.StatusServiceUnavailable
+	suite.Error(sink.Publish(context.Background(), suite.msg))
+}
+
+func (suite *SinkSuite) Test_BrokerSink_SkipsRecentlyPublishedMessages() {
+	store := new(mockrepo.EventRepository)
+	store.On("LastID", mock.Anything).Return(int64(0), nil)
+	store.On("Append", mock.Anything, mock.Anything).Return(nil)
+	broker := events.NewBroker(store)
+	sub, err := broker.Subscribe(context.Background(), 0)
+	suite.Require().NoError(err)
+	defer sub.Close()
+	sink := NewBrokerSink(broker)
+
+	suite.Require().NoError(sink.Publish(context.Background(), suite.msg))
+	suite.Require().NoError(sink.Publish(context.Background(), suite.msg))
+	suite.Require().NoError(sink.Publish(context.Background(), &models.OutboxMessage{ID: "msg-2", Event: suite.msg.Event}))
+
+	first, second := <-sub.Events, <-sub.Events
+	suite.Equal("msg-1", first.DedupID)
+	suite.Equal(int64(1), first.ID)
+	suite.Equal("msg-2", second.DedupID)
+	suite.Equal(int64(2), second.ID)
+	store.AssertNumberOfCalls(suite.T(), "Append", 2)
+}
+
+func (suite *SinkSuite) Test_Fanout_RetriesOnlyFailedSinks() {
+	ok, failing := &MemorySink{}, &MemorySink{}
+	failing.Fail(assert.AnError)
+	fanout := NewFanout(ok, failing)
+
+	suite.ErrorIs(fanout.Publish(context.Background(), suite.msg), assert.AnError)
+	failing.Fail(nil)
+	suite.NoError(fanout.Publish(context.Background(), suite.msg))
+
+	suite.Len(ok.Messages(), 1)
+	suite.Len(failing.Messages(), 1)
+	suite.Empty(fanout.delivered)
+}
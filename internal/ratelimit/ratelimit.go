@@ -0,0 +1,145 @@
+package ratelimit
+
+import (
+	"container/list"
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limit is a token bucket holding up to Burst tokens and refilled with
+// PerMinute tokens a minute. Each request takes one token.
+type Limit struct {
+	PerMinute int
+	Burst     int
+}
+
+func (l Limit) Enabled() bool {
+	return l.PerMinute > 0
+}
+
+// perSecond is the refill rate in tokens a second.
+func (l Limit) perSecond() float64 {
+	return float64(l.PerMinute) / 60
+}
+
+func (l Limit) burst() int {
+	return max(l.Burst, 1)
+}
+
+type Decision struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is the time until the next token when the request was denied.
+	RetryAfter time.Duration
+}
+
+// Store keeps the buckets. MemoryStore keeps them in process; a shared backend
+// such as Redis implements Store so instances enforce a limit together, and
+// must then take the token atomically.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Decision, error)
+}
+
+// idleSweep is how often MemoryStore drops buckets that have refilled
+// completely, which behave exactly like missing ones.
+const idleSweep = time.Minute
+
+// MemoryStore keeps at most maxKeys buckets. When full, the least recently
+// used bucket is dropped, so callers choosing new keys cannot grow it without
+// bound.
+type MemoryStore struct {
+	now     func() time.Time
+	maxKeys int
+
+	mu        sync.Mutex
+	order     *list.List
+	buckets   map[string]*list.Element
+	lastSweep time.Time
+}
+
+type bucket struct {
+	key     string
+	tokens  float64
+	updated time.Time
+	limit   Limit
+}
+
+func NewMemoryStore(maxKeys int) *MemoryStore {
+	return &MemoryStore{
+		now:     time.Now,
+		maxKeys: max(maxKeys, 1),
+		order:   list.New(),
+		buckets: make(map[string]*list.Element),
+	}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, limit Limit) (Decision, error) {
+	if !limit.Enabled() {
+		return Decision{Allowed: true}, nil
+	}
+	now := s.now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep(now)
+
+	b := s.bucket(key, limit, now)
+	b.limit = limit
+	b.refill(now)
+
+	decision := Decision{Limit: limit.burst()}
+	if b.tokens >= 1 {
+		b.tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = seconds((1 - b.tokens) / limit.perSecond())
+	}
+	decision.Remaining = int(math.Floor(b.tokens))
+	decision.Reset = seconds((float64(limit.burst()) - b.tokens) / limit.perSecond())
+	return decision, nil
+}
+
+func (s *MemoryStore) bucket(key string, limit Limit, now time.Time) *bucket {
+	if elem, ok := s.buckets[key]; ok {
+		s.order.MoveToFront(elem)
+		return elem.Value.(*bucket)
+	}
+	b := &bucket{key: key, tokens: float64(limit.burst()), updated: now}
+	s.buckets[key] = s.order.PushFront(b)
+	for s.order.Len() > s.maxKeys {
+		s.remove(s.order.Back())
+	}
+	return b
+}
+
+func (s *MemoryStore) remove(elem *list.Element) {
+	s.order.Remove(elem)
+	delete(s.buckets, elem.Value.(*bucket).key)
+}
+
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < idleSweep {
+		return
+	}
+	s.lastSweep = now
+	for _, elem := range s.buckets {
+		b := elem.Value.(*bucket)
+		if b.refill(now); b.tokens >= float64(b.limit.burst()) {
+			s.remove(elem)
+		}
+	}
+}
+
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.updated).Seconds()
+	b.tokens = min(float64(b.limit.burst()), b.tokens+elapsed*b.limit.perSecond())
+	b.updated = now
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,103 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MemoryStoreSuite struct {
+	suite.Suite
+	now   time.Time
+	store *MemoryStore
+	limit Limit
+}
+
+func TestMemoryStoreSuite(t *testing.T) {
+	suite.Run(t, new(MemoryStoreSuite))
+}
+
+func (suite *MemoryStoreSuite) SetupTest() {
+	suite.now = time.Now()
+	suite.store = NewMemoryStore(100)
+	suite.store.now = func() time.Time { return suite.now }
+	suite.limit = Limit{PerMinute: 60, Burst: 3}
+}
+
+func (suite *MemoryStoreSuite) take(key string) Decision {
+	decision, err := suite.store.Take(context.Background(), key, suite.limit)
+	suite.Require().NoError(err)
+	return decision
+}
+
+func (suite *MemoryStoreSuite) Test_AllowsBurstThenDenies() {
+	for remaining := 2; remaining >= 0; remaining-- {
+		decision := suite.take("alice")
+		suite.True(decision.Allowed)
+		suite.Equal(3, decision.Limit)
+		suite.Equal(remaining, decision.Remaining)
+	}
+
+	decision := suite.take("alice")
+
+	suite.False(decision.Allowed)
+	suite.Equal(0, decision.Remaining)
+	suite.Equal(time.Second, decision.RetryAfter)
+	suite.Equal(3*time.Second, decision.Reset)
+	suite.True(suite.take("bob").Allowed, "consumers have their own bucket")
+}
+
+func (suite *MemoryStoreSuite) Test_RefillsAtRate() {
+	for range 3 {
+		suite.take("alice")
+	}
+
+	suite.now = suite.now.Add(500 * time.Millisecond)
+	decision := suite.take("alice")
+	suite.False(decision.Allowed)
+	suite.Equal(500*time.Millisecond, decision.RetryAfter)
+
+	suite.now = suite.now.Add(500 * time.Millisecond)
+	suite.True(suite.take("alice").Allowed)
+
+	suite.now = suite.now.Add(time.Hour)
+	suite.Equal(2, suite.take("alice").Remaining, "the bucket does not grow past the burst")
+}
+
+func (suite *MemoryStoreSuite) Test_DropsRefilledBuckets() {
+	suite.take("alice")
+	suite.limit = Limit{PerMinute: 1, Burst: 5}
+	suite.take("bob")
+	suite.take("bob")
+
+	suite.now = suite.now.Add(idleSweep)
+	suite.take("carol")
+
+	suite.Len(suite.store.buckets, 2)
+	suite.Contains(suite.store.buckets, "bob", "bob's bucket is still refilling")
+}
+
+func (suite *MemoryStoreSuite) Test_EvictsLeastRecentlyUsedBuckets() {
+	suite.store.maxKeys = 2
+	for range 3 {
+		suite.take("alice")
+	}
+	suite.take("bob")
+	suite.take("alice")
+
+	suite.take("carol")
+
+	suite.Len(suite.store.buckets, 2)
+	suite.NotContains(suite.store.buckets, "bob")
+	suite.False(suite.take("alice").Allowed, "alice's bucket was kept")
+}
+
+func (suite *MemoryStoreSuite) Test_DisabledLimitAllowsEverything() {
+	suite.limit = Limit{}
+	for range 10 {
+		suite.True(suite.take("alice").Allowed)
+	}
+	suite.Empty(suite.store.buckets)
+}
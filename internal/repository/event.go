@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	"catalog-service/internal/opensearch"
+)
+
+const EventIndexName = "service_events"
+
+// EventRepositoryImpl keeps the last capacity events. Each event is stored in
+// slot ID % capacity, so appending overwrites the oldest event once the log is
+// full and the index never grows past capacity documents.
+type EventRepositoryImpl struct {
+	opensearch.Client
+	capacity int64
+}
+
+func NewEventRepository(client opensearch.Client, capacity int) (EventRepository, error) {
+	if capacity <= 0 {
+		return nil, fmt.Errorf("event log capacity must be positive, got %d", capacity)
+	}
+	return &EventRepositoryImpl{Client: client, capacity: int64(capacity)}, nil
+}
+
+func (r *EventRepositoryImpl) Append(ctx context.Context, event *models.ServiceEvent) error {
+	slot := strconv.FormatInt(event.ID%r.capacity, 10)
+	return r.IndexDocument(ctx, slot, event, EventIndexName)
+}
+
+func (r *EventRepositoryImpl) ListAfter(ctx context.Context, afterID int64, limit int) ([]*models.ServiceEvent, error) {
+	log := logger.NewContextLogger(ctx, "EventRepositoryImpl/ListAfter")
+	body := map[string]interface{}{
+		"query": map[string]interface{}{
+			"range": map[string]interface{}{"id": map[string]interface{}{"gt": afterID}},
+		},
+		"sort": []interface{}{map[string]interface{}{"id": "asc"}},
+		"size": limit,
+	}
+	hits, _, err := r.Search(ctx, EventIndexName, body)
+	if err != nil {
+		log.Errorf(err, "failed to list events after %d", afterID)
+		return nil, fmt.Errorf("event search failed: %w", err)
+	}
+	return decodeEvents(hits)
+}
+
+func (r *EventRepositoryImpl) LastID(ctx context.Context) (int64, error) {
+	body := map[string]interface{}{
+		"sort": []interface{}{map[string]interface{}{"id": "desc"}},
+		"size": 1,
+	}
+	hits, _, err := r.Search(ctx, EventIndexName, body)
+	if err != nil {
+		return 0, fmt.Errorf("event search failed: %w", err)
+	}
+	events, err := decodeEvents(hits)
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+	return events[0].ID, nil
+}
+
+func decodeEvents(hits []map[string]interface{}) ([]*models.ServiceEvent, error) {
+	events := make([]*models.ServiceEvent, 0, len(hits))
+	for _, hit := range hits {
+		var event models.ServiceEvent
+		b, _ := json.Marshal(hit)
+		if err := json.Unmarshal(b, &event); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal event: %w", err)
+		}
+		events = append(events, &event)
+	}
+	return events, nil
+}
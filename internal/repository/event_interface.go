@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"catalog-service/internal/models"
+	"context"
+)
+
+type EventRepository interface {
+	Append(ctx context.Context, event *models.ServiceEvent) error
+	ListAfter(ctx context.Context, afterID int64, limit int) ([]*models.ServiceEvent, error)
+	LastID(ctx context.Context) (int64, error)
+}
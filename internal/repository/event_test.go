@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"catalog-service/internal/config"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	opensearchmock "catalog-service/test/mocks/opensearch"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type EventRepoTestSuite struct {
+	suite.Suite
+}
+
+func TestEventRepo(t *testing.T) {
+	suite.Run(t, new(EventRepoTestSuite))
+}
+
+func (suite *EventRepoTestSuite) SetupTest() {
+	config.Load()
+	logger.Setup("INFO", "json")
+}
+
+func (suite *EventRepoTestSuite) Test_Append_OverwritesOldestSlot() {
+	mockClient := new(opensearchmock.Client)
+	mockClient.On("IndexDocument", mock.Anything, "2", mock.Anything, "service_events").Return(nil).Twice()
+
+	repo, err := NewEventRepository(mockClient, 5)
+	suite.Require().NoError(err)
+
+	suite.NoError(repo.Append(context.Background(), &models.ServiceEvent{ID: 2}))
+	suite.NoError(repo.Append(context.Background(), &models.ServiceEvent{ID: 7}))
+	mockClient.AssertExpectations(suite.T())
+
+	_, err = NewEventRepository(mockClient, 0)
+	suite.Error(err)
+}
+
+func (suite *EventRepoTestSuite) Test_ListAfter_SortsByID() {
+	mockClient := new(opensearchmock.Client)
+	mockClient.On("Search", mock.Anything, "service_events", mock.MatchedBy(func(body map[string]interface{}) bool {
+		return assert.ObjectsAreEqual(map[string]interface{}{"gt": int64(4)}, body["query"].(map[string]interface{})["range"].(map[string]interface{})["id"]) &&
+			assert.ObjectsAreEqual([]interface{}{map[string]interface{}{"id": "asc"}}, body["sort"]) &&
+			body["size"] == 2
+	})).Return([]map[string]interface{}{
+		{"id": float64(5), "type": "service.created", "service_id": "svc-1", "changed_fields": []interface{}{"name"}},
+		{"id": float64(6), "type": "service.deleted", "service_id": "svc-1", "changed_fields": []interface{}{}},
+	}, 2, nil)
+
+	repo := &EventRepositoryImpl{Client: mockClient, capacity: 10}
+
+	events, err := repo.ListAfter(context.Background(), 4, 2)
+	suite.Require().NoError(err)
+	suite.Require().Len(events, 2)
+	suite.Equal(int64(5), events[0].ID)
+	suite.Equal(models.EventServiceCreated, events[0].Type)
+	suite.Equal([]string{"name"}, events[0].ChangedFields)
+	suite.Equal(models.EventServiceDeleted, events[1].Type)
+}
+
+func (suite *EventRepoTestSuite) Test_LastID() {
+	mockClient := new(opensearchmock.Client)
+	mockClient.On("Search", mock.Anything, "service_events", mock.Anything).Return([]map[string]interface{}{{"id": float64(42)}}, 1, nil).Once()
+	mockClient.On("Search", mock.Anything, "service_events", mock.Anything).Return([]map[string]interface{}{}, 0, nil).Once()
+
+	repo := &EventRepositoryImpl{Client: mockClient, capacity: 10}
+
+	id, err := repo.LastID(context.Background())
+	suite.NoError(err)
+	suite.Equal(int64(42), id)
+
+	id, err = repo.LastID(context.Background())
+	suite.NoError(err)
+	suite.Equal(int64(0), id)
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	"catalog-service/internal/opensearch"
+
+	"github.com/google/uuid"
+)
+
+const OutboxIndexName = "outbox"
+
+type OutboxRepositoryImpl struct {
+	opensearch.Client
+}
+
+func NewOutboxRepository(client opensearch.Client) (OutboxRepository, error) {
+	return &OutboxRepositoryImpl{Client: client}, nil
+}
+
+// Pending returns the oldest messages in the order their writes happened.
+func (r *OutboxRepositoryImpl) Pending(ctx context.Context, limit int) ([]*models.OutboxMessage, error) {
+	log := logger.NewContextLogger(ctx, "OutboxRepositoryImpl/Pending")
+	body := map[string]interface{}{
+		"query": map[string]interface{}{"match_all": map[string]interface{}{}},
+		"sort": []interface{}{
+			map[string]interface{}{"created_at": "asc"},
+			map[string]interface{}{"sequence": "asc"},
+		},
+		"size": limit,
+	}
+	hits, _, err := r.Search(ctx, OutboxIndexName, body)
+	if err != nil {
+		log.Errorf(err, "failed to list pending outbox messages")
+		return nil, fmt.Errorf("outbox search failed: %w", err)
+	}
+
+	messages := make([]*models.OutboxMessage, 0, len(hits))
+	for _, hit := range hits {
+		var msg models.OutboxMessage
+		if err := decodeDocument(hit, &msg); err != nil {
+			log.Errorf(err, "failed to unmarshal outbox hit")
+			continue
+		}
+		messages = append(messages, &msg)
+	}
+	return messages, nil
+}
+
+func (r *OutboxRepositoryImpl) Save(ctx context.Context, msg *models.OutboxMessage) error {
+	return r.IndexDocument(ctx, msg.ID, msg, OutboxIndexName)
+}
+
+func (r *OutboxRepositoryImpl) Delete(ctx context.Context, id string) error {
+	return r.DeleteDocumentByID(ctx, OutboxIndexName, id)
+}
+
+// newOutboxMessages wraps events written together. Sequence keeps their order,
+// since created_at is only stored to the millisecond.
+func newOutboxMessages(events []*models.ServiceEvent) []*models.OutboxMessage {
+	now := time.Now().UTC()
+	messages := make([]*models.OutboxMessage, 0, len(events))
+	for i, event := range events {
+		id := uuid.NewString()
+		event.DedupID = id
+		if event.OccurredAt.IsZero() {
+			event.OccurredAt = now
+		}
+		messages = append(messages, &models.OutboxMessage{ID: id, Event: *event, Sequence: i, CreatedAt: now})
+	}
+	return messages
+}
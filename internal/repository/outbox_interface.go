@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"catalog-service/internal/models"
+	"context"
+)
+
+type OutboxRepository interface {
+	Pending(ctx context.Context, limit int) ([]*models.OutboxMessage, error)
+	Save(ctx context.Context, msg *models.OutboxMessage) error
+	Delete(ctx context.Context, id string) error
+}
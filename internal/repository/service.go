@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"catalog-service/internal/config"
 	"catalog-service/internal/logger"
 	"catalog-service/internal/models"
 	"catalog-service/internal/opensearch"
@@ -26,23 +27,32 @@ func NewServiceRepository(client opensearch.Client) (ServiceRepository, error) {
 	return &ServiceRepositoryImpl{Client: client}, nil
 }
 
-func (r *ServiceRepositoryImpl) Create(ctx context.Context, service *models.Service) error {
+func (r *ServiceRepositoryImpl) Create(ctx context.Context, service *models.Service, events ...*models.ServiceEvent) error {
 	log := logger.NewContextLogger(ctx, "ServiceRepositoryImpl/Create")
 	if err := r.prepareService(service); err != nil {
 		log.Errorf(err, "failed to prepare service")
 		return fmt.Errorf("failed to prepare service: %w", err)
 	}
+	for _, event := range events {
+		if event.ServiceID == "" {
+			event.ServiceID = service.ID
+		}
+	}
 
 	log.Debug("inserting record in services index")
-	return r.IndexDocument(ctx, service.ID, service, ServiceIndexName)
+	return r.write(ctx, opensearch.WriteOperation{Index: ServiceIndexName, ID: service.ID, Document: service}, events)
 }
 
 func (r *ServiceRepositoryImpl) Search(ctx context.Context, query string, page, limit int) ([]*models.Service, int, error) {
+	return r.SearchWithFilter(ctx, query, models.ServiceFilter{}, page, limit)
+}
+
+func (r *ServiceRepositoryImpl) SearchWithFilter(ctx context.Context, query string, filter models.ServiceFilter, page, limit int) ([]*models.Service, int, error) {
 	log := logger.NewContextLogger(ctx, "ServiceRepositoryImpl/Search")
 	from := (page - 1) * limit
-	log.Debugf("searching for query='%s', page=%d, limit=%d, from=%d", query, page, limit, from)
+	log.Debugf("searching for query='%s', filter=%+v, page=%d, limit=%d, from=%d", query, filter, page, limit, from)
 
-	searchBody := buildSearchBody(query, from, limit)
+	searchBody := buildSearchBody(query, filter, from, limit)
 
 	hits, total, err := r.Client.Search(ctx, ServiceIndexName, searchBody)
 	if err != nil {
@@ -65,6 +75,36 @@ func (r *ServiceRepositoryImpl) Search(ctx context.Context, query string, page,
 	return services, total, nil
 }
 
+func (r *ServiceRepositoryImpl) Export(ctx context.Context, query string, filter models.ServiceFilter, fn func(*models.Service) error) error {
+	log := logger.NewContextLogger(ctx, "ServiceRepositoryImpl/Export")
+	osCfg := config.OpenSearch()
+	log.Debugf("exporting services for query='%s'", query)
+
+	exported := 0
+	err := r.Client.Scroll(ctx, ServiceIndexName, buildExportBody(query, filter, osCfg.ScrollSize()), osCfg.ScrollKeepAlive(), func(hits []map[string]interface{}) error {
+		for _, hit := range hits {
+			var svc models.Service
+			bytes, _ := json.Marshal(hit)
+			if err := json.Unmarshal(bytes, &svc); err != nil {
+				log.Errorf(err, "failed to unmarshal export hit")
+				continue
+			}
+			if err := fn(&svc); err != nil {
+				return err
+			}
+			exported++
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf(err, "export failed after %d services", exported)
+		return fmt.Errorf("export failed: %w", err)
+	}
+
+	log.Infof("export completed: %d services", exported)
+	return nil
+}
+
 func (r *ServiceRepositoryImpl) FindByID(ctx context.Context, id string) (*models.Service, error) {
 	log := logger.NewContextLogger(ctx, "ServiceRepositoryImpl/FindByID")
 	doc, err := r.Client.FindDocumentByID(ctx, ServiceIndexName, id)
@@ -81,9 +121,28 @@ func (r *ServiceRepositoryImpl) FindByID(ctx context.Context, id string) (*model
 	return &svc, nil
 }
 
-func (r *ServiceRepositoryImpl) Delete(ctx context.Context, id string) error {
+func (r *ServiceRepositoryImpl) FindByName(ctx context.Context, name string) (*models.Service, error) {
+	log := logger.NewContextLogger(ctx, "ServiceRepositoryImpl/FindByName")
+	hits, _, err := r.Client.Search(ctx, ServiceIndexName, buildNameLookupBody(name))
+	if err != nil {
+		log.Errorf(err, "failed to look up service by name")
+		return nil, fmt.Errorf("name lookup failed: %w", err)
+	}
+	if len(hits) == 0 {
+		return nil, nil
+	}
+	var svc models.Service
+	b, _ := json.Marshal(hits[0])
+	if err := json.Unmarshal(b, &svc); err != nil {
+		log.Errorf(err, "failed to unmarshal document to Service")
+		return nil, err
+	}
+	return &svc, nil
+}
+
+func (r *ServiceRepositoryImpl) Delete(ctx context.Context, id string, events ...*models.ServiceEvent) error {
 	log := logger.NewContextLogger(ctx, "ServiceRepositoryImpl/Delete")
-	err := r.Client.DeleteDocumentByID(ctx, ServiceIndexName, id)
+	err := r.write(ctx, opensearch.WriteOperation{Action: opensearch.BulkActionDelete, Index: ServiceIndexName, ID: id}, events)
 	if err != nil {
 		log.Errorf(err, "failed to delete document")
 		return err
@@ -91,9 +150,57 @@ func (r *ServiceRepositoryImpl) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-func (r *ServiceRepositoryImpl) Update(ctx context.Context, service *models.Service) error {
+func (r *ServiceRepositoryImpl) Update(ctx context.Context, service *models.Service, events ...*models.ServiceEvent) error {
 	service.UpdatedAt = time.Now().UTC()
-	return r.IndexDocument(ctx, service.ID, service, ServiceIndexName)
+	return r.write(ctx, opensearch.WriteOperation{Index: ServiceIndexName, ID: service.ID, Document: service}, events)
+}
+
+// write applies op and stores events in the outbox with a single bulk request.
+// OpenSearch has no multi-document transactions, so items can still fail on
+// their own: when op fails the stored messages are removed again, and a
+// message that fails after op succeeded is retried once and otherwise logged.
+func (r *ServiceRepositoryImpl) write(ctx context.Context, op opensearch.WriteOperation, events []*models.ServiceEvent) error {
+	if len(events) == 0 {
+		if op.Action == opensearch.BulkActionDelete {
+			return r.DeleteDocumentByID(ctx, op.Index, op.ID)
+		}
+		return r.IndexDocument(ctx, op.ID, op.Document, op.Index)
+	}
+
+	log := logger.NewContextLogger(ctx, "ServiceRepositoryImpl/write")
+	messages := newOutboxMessages(events)
+	ops := []opensearch.WriteOperation{op}
+	for _, msg := range messages {
+		ops = append(ops, opensearch.WriteOperation{Index: OutboxIndexName, ID: msg.ID, Document: msg})
+	}
+	results, err := r.Client.Write(ctx, ops)
+	if err != nil {
+		return err
+	}
+
+	if results[0].Err != nil {
+		var undo []opensearch.WriteOperation
+		for i, msg := range messages {
+			if results[i+1].Err == nil {
+				undo = append(undo, opensearch.WriteOperation{Action: opensearch.BulkActionDelete, Index: OutboxIndexName, ID: msg.ID})
+			}
+		}
+		if len(undo) > 0 {
+			if _, err := r.Client.Write(ctx, undo); err != nil {
+				log.Errorf(err, "failed to remove outbox messages of failed write to %s", op.ID)
+			}
+		}
+		return results[0].Err
+	}
+	for i, msg := range messages {
+		if results[i+1].Err == nil {
+			continue
+		}
+		if err := r.IndexDocument(ctx, msg.ID, msg, OutboxIndexName); err != nil {
+			log.Errorf(err, "lost %s event for %s: failed to store outbox message %s", msg.Event.Type, op.ID, msg.ID)
+		}
+	}
+	return nil
 }
 
 func (r *ServiceRepositoryImpl) prepareService(service *models.Service) error {
@@ -114,32 +221,98 @@ func (r *ServiceRepositoryImpl) prepareService(service *models.Service) error {
 	return nil
 }
 
-func buildSearchBody(query string, from, size int) map[string]interface{} {
+func buildSearchBody(query string, filter models.ServiceFilter, from, size int) map[string]interface{} {
 	sortClause := []map[string]interface{}{
 		{UpdatedAtSortField: map[string]interface{}{"order": "desc"}},
 	}
 
+	return map[string]interface{}{
+		"query": buildFilteredQuery(query, filter),
+		"from":  from,
+		"size":  size,
+		"sort":  sortClause,
+	}
+}
+
+func buildNameLookupBody(name string) map[string]interface{} {
+	return map[string]interface{}{
+		"query": map[string]interface{}{
+			"term": map[string]interface{}{"name.keyword": name},
+		},
+		"sort": []map[string]interface{}{
+			{"created_at": map[string]interface{}{"order": "asc"}},
+		},
+		"size": 1,
+	}
+}
+
+func buildExportBody(query string, filter models.ServiceFilter, size int) map[string]interface{} {
+	return map[string]interface{}{
+		"query": buildFilteredQuery(query, filter),
+		"size":  size,
+		"sort":  []string{"_doc"},
+	}
+}
+
+func buildFilteredQuery(query string, filter models.ServiceFilter) map[string]interface{} {
+	if filter.IsEmpty() {
+		return buildQuery(query)
+	}
+
+	var clauses []map[string]interface{}
+	if filter.Name != "" {
+		clauses = append(clauses, map[string]interface{}{
+			"term": map[string]interface{}{"name.keyword": filter.Name},
+		})
+	}
+	if filter.Version != "" {
+		clauses = append(clauses, map[string]interface{}{
+			"nested": map[string]interface{}{
+				"path": "versions",
+				"query": map[string]interface{}{
+					"term": map[string]interface{}{"versions.version_number": filter.Version},
+				},
+			},
+		})
+	}
+	if filter.Operation != "" {
+		clauses = append(clauses, map[string]interface{}{
+			"term": map[string]interface{}{"operations.keyword": filter.Operation},
+		})
+	}
+	if !filter.UpdatedAfter.IsZero() || !filter.UpdatedBefore.IsZero() {
+		rng := map[string]interface{}{}
+		if !filter.UpdatedAfter.IsZero() {
+			rng["gte"] = filter.UpdatedAfter.Format(time.RFC3339)
+		}
+		if !filter.UpdatedBefore.IsZero() {
+			rng["lt"] = filter.UpdatedBefore.Format(time.RFC3339)
+		}
+		clauses = append(clauses, map[string]interface{}{
+			"range": map[string]interface{}{UpdatedAtSortField: rng},
+		})
+	}
+
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"must":   []map[string]interface{}{buildQuery(query)},
+			"filter": clauses,
+		},
+	}
+}
+
+func buildQuery(query string) map[string]interface{} {
 	if query == "" {
 		return map[string]interface{}{
-			"query": map[string]interface{}{
-				"match_all": map[string]interface{}{},
-			},
-			"from": from,
-			"size": size,
-			"sort": sortClause,
+			"match_all": map[string]interface{}{},
 		}
 	}
 
 	return map[string]interface{}{
-		"query": map[string]interface{}{
-			"simple_query_string": map[string]interface{}{
-				"query":            fmt.Sprintf("\"%s\"", query),
-				"fields":           []string{"name", "description"},
-				"default_operator": "and",
-			},
+		"simple_query_string": map[string]interface{}{
+			"query":            fmt.Sprintf("\"%s\"", query),
+			"fields":           []string{"name", "description", "operations"},
+			"default_operator": "and",
 		},
-		"from": from,
-		"size": size,
-		"sort": sortClause,
 	}
 }
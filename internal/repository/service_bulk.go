@@ -0,0 +1,310 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	"catalog-service/internal/opensearch"
+
+	"github.com/google/uuid"
+)
+
+const DefaultNaturalKeyField = "name"
+
+// naturalKeyTermFields maps the fields services can be keyed on to their
+// keyword mapping in migrations/services.json, which exact lookups need.
+var naturalKeyTermFields = map[string]string{
+	"id":   "id.keyword",
+	"name": "name.keyword",
+}
+
+// ValidateNaturalKeyField fails for fields without a keyword mapping, whose
+// lookups would match nothing and duplicate existing services.
+func ValidateNaturalKeyField(field string) error {
+	if _, ok := naturalKeyTermFields[field]; !ok {
+		return fmt.Errorf("unsupported natural key field %q: must be id or name", field)
+	}
+	return nil
+}
+
+var naturalKeyNamespace = uuid.MustParse("6f1c2b8e-3c1d-4b7a-9d2e-5a4f0c9e7b11")
+
+const mergeServiceScript = `
+boolean changed = false;
+if (params.description != null && params.description != '' && params.description != ctx._source.description) {
+  ctx._source.description = params.description;
+  changed = true;
+}
+if (ctx._source.versions == null) {
+  ctx._source.versions = new ArrayList();
+}
+for (def v : params.versions) {
+  def existing = null;
+  for (def e : ctx._source.versions) {
+    if (e['version_number'] == v['version_number']) {
+      existing = e;
+      break;
+    }
+  }
+  if (existing == null) {
+    ctx._source.versions.add(v);
+    changed = true;
+  } else if (v['details'] != null && v['details'] != '' && v['details'] != existing['details']) {
+    existing['details'] = v['details'];
+    changed = true;
+  }
+}
+if (changed) {
+  ctx._source.updated_at = params.updated_at;
+} else {
+  ctx.op = 'noop';
+}`
+
+func (r *ServiceRepositoryImpl) BulkCreate(ctx context.Context, services <-chan *models.Service, opts opensearch.BulkOptions) (*opensearch.BulkResult, error) {
+	log := logger.NewContextLogger(ctx, "ServiceRepositoryImpl/BulkCreate")
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	docs := make(chan opensearch.BulkDocument)
+	go func() {
+		defer close(docs)
+		for service := range services {
+			if err := r.prepareService(service); err != nil {
+				log.Errorf(err, "failed to prepare service")
+				continue
+			}
+			select {
+			case docs <- opensearch.BulkDocument{ID: service.ID, Document: service, Refs: []interface{}{service}}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	log.Debug("bulk inserting records in services index")
+	return r.BulkIndex(ctx, ServiceIndexName, docs, opts)
+}
+
+func (r *ServiceRepositoryImpl) BulkUpsert(ctx context.Context, services <-chan *models.Service, keyField string, opts opensearch.BulkOptions) (*opensearch.BulkResult, error) {
+	log := logger.NewContextLogger(ctx, "ServiceRepositoryImpl/BulkUpsert")
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if keyField == "" {
+		keyField = DefaultNaturalKeyField
+	}
+	if err := ValidateNaturalKeyField(keyField); err != nil {
+		return nil, err
+	}
+	chunkSize := opts.BatchSize
+	if chunkSize <= 0 {
+		chunkSize = opensearch.DefaultBulkBatchSize
+	}
+
+	resolver := &naturalKeyResolver{repo: r, field: keyField, ids: map[string]string{}}
+	var lookupErr error
+	var once sync.Once
+	fail := func(err error) {
+		once.Do(func() {
+			lookupErr = err
+			cancel()
+		})
+	}
+
+	docs := make(chan opensearch.BulkDocument)
+	go func() {
+		defer close(docs)
+		chunk := make([]*models.Service, 0, chunkSize)
+		for service := range services {
+			chunk = append(chunk, service)
+			if len(chunk) < chunkSize {
+				continue
+			}
+			if err := r.emitUpserts(ctx, chunk, resolver, docs); err != nil {
+				fail(err)
+				return
+			}
+			chunk = make([]*models.Service, 0, chunkSize)
+		}
+		if err := r.emitUpserts(ctx, chunk, resolver, docs); err != nil {
+			fail(err)
+		}
+	}()
+
+	log.Debugf("bulk upserting records in services index keyed on %s", keyField)
+	result, err := r.BulkIndex(ctx, ServiceIndexName, docs, opts)
+	if lookupErr != nil {
+		return result, lookupErr
+	}
+	return result, err
+}
+
+func (r *ServiceRepositoryImpl) emitUpserts(ctx context.Context, chunk []*models.Service, resolver *naturalKeyResolver, docs chan<- opensearch.BulkDocument) error {
+	log := logger.NewContextLogger(ctx, "ServiceRepositoryImpl/BulkUpsert")
+	merged, sources, keys := mergeByNaturalKey(chunk, resolver.field)
+	if len(merged) == 0 {
+		return nil
+	}
+
+	ids, err := resolver.resolve(ctx, keys)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	for _, key := range keys {
+		service := merged[key]
+		service.ID = ids[key]
+		if service.CreatedAt.IsZero() {
+			service.CreatedAt = now
+		}
+		service.UpdatedAt = now
+
+		select {
+		case docs <- opensearch.BulkDocument{ID: service.ID, Action: opensearch.BulkActionUpdate, Document: buildUpsertDocument(service), Refs: sources[key]}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	log.Debugf("emitted %d upserts for %d records", len(keys), len(chunk))
+	return nil
+}
+
+type naturalKeyResolver struct {
+	repo  *ServiceRepositoryImpl
+	field string
+	ids   map[string]string
+}
+
+func (k *naturalKeyResolver) resolve(ctx context.Context, keys []string) (map[string]string, error) {
+	log := logger.NewContextLogger(ctx, "ServiceRepositoryImpl/BulkUpsert")
+
+	var missing []string
+	for _, key := range keys {
+		if _, ok := k.ids[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+
+	if len(missing) > 0 {
+		hits, _, err := k.repo.Client.Search(ctx, ServiceIndexName, buildNaturalKeyLookupBody(k.field, missing))
+		if err != nil {
+			log.Errorf(err, "failed to look up existing services")
+			return nil, fmt.Errorf("natural key lookup failed: %w", err)
+		}
+		for _, hit := range hits {
+			key, _ := hit[k.field].(string)
+			id, _ := hit["id"].(string)
+			if key == "" || id == "" {
+				continue
+			}
+			if _, ok := k.ids[key]; !ok {
+				k.ids[key] = id
+			}
+		}
+		for _, key := range missing {
+			if _, ok := k.ids[key]; !ok {
+				k.ids[key] = NaturalKeyID(k.field, key)
+			}
+		}
+	}
+
+	ids := make(map[string]string, len(keys))
+	for _, key := range keys {
+		ids[key] = k.ids[key]
+	}
+	return ids, nil
+}
+
+func NaturalKeyID(field, key string) string {
+	return uuid.NewSHA1(naturalKeyNamespace, []byte(field+":"+key)).String()
+}
+
+func NaturalKey(service *models.Service, field string) string {
+	switch field {
+	case "name":
+		return strings.TrimSpace(service.Name)
+	case "id":
+		return strings.TrimSpace(service.ID)
+	}
+
+	b, err := json.Marshal(service)
+	if err != nil {
+		return ""
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return ""
+	}
+	value, _ := doc[field].(string)
+	return strings.TrimSpace(value)
+}
+
+func mergeByNaturalKey(services []*models.Service, field string) (map[string]*models.Service, map[string][]interface{}, []string) {
+	merged := make(map[string]*models.Service, len(services))
+	sources := make(map[string][]interface{}, len(services))
+	var keys []string
+	for _, service := range services {
+		key := NaturalKey(service, field)
+		if key == "" {
+			continue
+		}
+		sources[key] = append(sources[key], service)
+		existing, ok := merged[key]
+		if !ok {
+			copied := *service
+			copied.Versions = append([]models.Version(nil), service.Versions...)
+			merged[key] = &copied
+			keys = append(keys, key)
+			continue
+		}
+		if service.Description != "" {
+			existing.Description = service.Description
+		}
+		if !service.CreatedAt.IsZero() && (existing.CreatedAt.IsZero() || service.CreatedAt.Before(existing.CreatedAt)) {
+			existing.CreatedAt = service.CreatedAt
+		}
+		existing.MergeVersions(service.Versions)
+	}
+	return merged, sources, keys
+}
+
+func buildNaturalKeyLookupBody(field string, keys []string) map[string]interface{} {
+	keywordField := naturalKeyTermFields[field]
+	return map[string]interface{}{
+		"query": map[string]interface{}{
+			"terms": map[string]interface{}{keywordField: keys},
+		},
+		"collapse": map[string]interface{}{"field": keywordField},
+		"sort": []map[string]interface{}{
+			{"created_at": map[string]interface{}{"order": "asc"}},
+		},
+		"size":    len(keys),
+		"_source": []string{"id", field},
+	}
+}
+
+func buildUpsertDocument(service *models.Service) map[string]interface{} {
+	versions := service.Versions
+	if versions == nil {
+		versions = []models.Version{}
+	}
+	return map[string]interface{}{
+		"script": map[string]interface{}{
+			"lang":   "painless",
+			"source": mergeServiceScript,
+			"params": map[string]interface{}{
+				"description": service.Description,
+				"versions":    versions,
+				"updated_at":  service.UpdatedAt,
+			},
+		},
+		"upsert": service,
+	}
+}
@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"catalog-service/internal/cache"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/metrics"
+	"catalog-service/internal/models"
+	"catalog-service/internal/opensearch"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	serviceGeneration = "services:generation:detail"
+	searchGeneration  = "services:generation:search"
+)
+
+// CacheOptions sets how long results are cached. A zero TTL disables caching
+// for that operation.
+type CacheOptions struct {
+	FindByIDTTL time.Duration
+	SearchTTL   time.Duration
+}
+
+type bypassCacheKey struct{}
+
+// WithoutCache makes the cached repository read from OpenSearch. Reads whose
+// result is written back, such as the read of an update, use it so they never
+// start from a stale copy.
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassCacheKey{}, true)
+}
+
+// cachedServiceRepository is a read-through cache in front of another
+// ServiceRepository. Keys include generations stored next to the values:
+// writes replace the generation of the service and the search generation, so
+// every cached page is dropped at once, and bulk writes replace the generation
+// shared by all services as well. A load that started before a write finds its
+// key changed and is not cached. Concurrent misses for the same key share one
+// OpenSearch request.
+type cachedServiceRepository struct {
+	ServiceRepository
+	store cache.Store
+	opts  CacheOptions
+	group singleflight.Group
+}
+
+func NewCachedServiceRepository(next ServiceRepository, store cache.Store, opts CacheOptions) ServiceRepository {
+	return &cachedServiceRepository{ServiceRepository: next, store: store, opts: opts}
+}
+
+type searchPage struct {
+	Services []*models.Service `json:"services"`
+	Total    int               `json:"total"`
+}
+
+func (r *cachedServiceRepository) FindByID(ctx context.Context, id string) (*models.Service, error) {
+	if r.opts.FindByIDTTL <= 0 || ctx.Value(bypassCacheKey{}) != nil {
+		return r.ServiceRepository.FindByID(ctx, id)
+	}
+	key := func(ctx context.Context) (string, error) { return r.serviceKey(ctx, id) }
+	return readThrough(ctx, r, "FindByID", key, r.opts.FindByIDTTL, func(ctx context.Context) (*models.Service, error) {
+		return r.ServiceRepository.FindByID(ctx, id)
+	})
+}
+
+func (r *cachedServiceRepository) Search(ctx context.Context, query string, page, limit int) ([]*models.Service, int, error) {
+	return r.SearchWithFilter(ctx, query, models.ServiceFilter{}, page, limit)
+}
+
+func (r *cachedServiceRepository) SearchWithFilter(ctx context.Context, query string, filter models.ServiceFilter, page, limit int) ([]*models.Service, int, error) {
+	if r.opts.SearchTTL <= 0 || ctx.Value(bypassCacheKey{}) != nil {
+		return r.ServiceRepository.SearchWithFilter(ctx, query, filter, page, limit)
+	}
+	key := func(ctx context.Context) (string, error) { return r.searchKey(ctx, query, filter, page, limit) }
+	result, err := readThrough(ctx, r, "Search", key, r.opts.SearchTTL, func(ctx context.Context) (*searchPage, error) {
+		services, total, err := r.ServiceRepository.SearchWithFilter(ctx, query, filter, page, limit)
+		if err != nil {
+			return nil, err
+		}
+		return &searchPage{Services: services, Total: total}, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return result.Services, result.Total, nil
+}
+
+func (r *cachedServiceRepository) Create(ctx context.Context, service *models.Service, events ...*models.ServiceEvent) error {
+	err := r.ServiceRepository.Create(ctx, service, events...)
+	r.invalidate(ctx, service.ID)
+	return err
+}
+
+// Update and Delete invalidate even when they fail, since a write that timed
+// out may still have been applied.
+func (r *cachedServiceRepository) Update(ctx context.Context, service *models.Service, events ...*models.ServiceEvent) error {
+	err := r.ServiceRepository.Update(ctx, service, events...)
+	r.invalidate(ctx, service.ID)
+	return err
+}
+
+func (r *cachedServiceRepository) Delete(ctx context.Context, id string, events ...*models.ServiceEvent) error {
+	err := r.ServiceRepository.Delete(ctx, id, events...)
+	r.invalidate(ctx, id)
+	return err
+}
+
+func (r *cachedServiceRepository) BulkCreate(ctx context.Context, services <-chan *models.Service, opts opensearch.BulkOptions) (*opensearch.BulkResult, error) {
+	result, err := r.ServiceRepository.BulkCreate(ctx, services, opts)
+	r.invalidateAll(ctx)
+	return result, err
+}
+
+func (r *cachedServiceRepository) BulkUpsert(ctx context.Context, services <-chan *models.Service, keyField string, opts opensearch.BulkOptions) (*opensearch.BulkResult, error) {
+	result, err := r.ServiceRepository.BulkUpsert(ctx, services, keyField, opts)
+	r.invalidateAll(ctx)
+	return result, err
+}
+
+// readThrough returns the value cached under the current key, or loads,
+// caches and returns it. The loaded value is not cached when a write changed
+// the key meanwhile. The load is shared by concurrent callers and is not
+// cancelled when the caller that started it goes away. Errors are not cached,
+// and loads go straight to OpenSearch when the cache fails.
+func readThrough[T any](ctx context.Context, r *cachedServiceRepository, operation string, currentKey func(context.Context) (string, error), ttl time.Duration, load func(context.Context) (T, error)) (T, error) {
+	var value T
+	log := logger.NewContextLogger(ctx, "CachedServiceRepository/"+operation)
+	key, err := currentKey(ctx)
+	if err != nil {
+		return load(ctx)
+	}
+	if data, ok, err := r.store.Get(ctx, key); err != nil {
+		log.Warnf("cache get failed: %v", err)
+	} else if ok {
+		if err := json.Unmarshal(data, &value); err == nil {
+			metrics.CacheLookup(operation, true)
+			return value, nil
+		}
+	}
+	metrics.CacheLookup(operation, false)
+
+	ch := r.group.DoChan(key, func() (any, error) {
+		loadCtx := context.WithoutCancel(ctx)
+		loaded, err := load(loadCtx)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(loaded)
+		if err != nil {
+			return nil, err
+		}
+		if current, err := currentKey(loadCtx); err != nil || current != key {
+			log.Debugf("not caching %s, it changed while loading", key)
+			return data, nil
+		}
+		if err := r.store.Set(loadCtx, key, data, ttl); err != nil {
+			log.Warnf("cache set failed: %v", err)
+		}
+		return data, nil
+	})
+	select {
+	case <-ctx.Done():
+		return value, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return value, res.Err
+		}
+		// Every caller decodes its own copy, so callers can change what they get.
+		err := json.Unmarshal(res.Val.([]byte), &value)
+		return value, err
+	}
+}
+
+func (r *cachedServiceRepository) serviceKey(ctx context.Context, id string) (string, error) {
+	generation, err := r.generation(ctx, serviceGeneration)
+	if err != nil {
+		return "", err
+	}
+	own, err := r.generation(ctx, serviceGeneration+":"+id)
+	if err != nil {
+		return "", err
+	}
+	return "services:detail:" + generation + ":" + own + ":" + id, nil
+}
+
+func (r *cachedServiceRepository) searchKey(ctx context.Context, query string, filter models.ServiceFilter, page, limit int) (string, error) {
+	generation, err := r.generation(ctx, searchGeneration)
+	if err != nil {
+		return "", err
+	}
+	params, _ := json.Marshal(struct {
+		Query  string
+		Filter models.ServiceFilter
+		Page   int
+		Limit  int
+	}{query, filter, page, limit})
+	sum := sha256.Sum256(params)
+	return "services:search:" + generation + ":" + hex.EncodeToString(sum[:]), nil
+}
+
+// generation returns the current value of a generation key, starting a new
+// generation when there is none, e.g. after it was evicted.
+func (r *cachedServiceRepository) generation(ctx context.Context, name string) (string, error) {
+	value, ok, err := r.store.Get(ctx, name)
+	if err != nil {
+		logger.NewContextLogger(ctx, "CachedServiceRepository/generation").Warnf("cache get failed: %v", err)
+		return "", err
+	}
+	if ok {
+		return string(value), nil
+	}
+	return r.renew(ctx, name)
+}
+
+func (r *cachedServiceRepository) renew(ctx context.Context, name string) (string, error) {
+	generation := uuid.NewString()
+	if err := r.store.Set(ctx, name, []byte(generation), 0); err != nil {
+		return "", err
+	}
+	return generation, nil
+}
+
+func (r *cachedServiceRepository) invalidate(ctx context.Context, id string) {
+	log := logger.NewContextLogger(ctx, "CachedServiceRepository/invalidate")
+	ctx = context.WithoutCancel(ctx)
+	if id != "" {
+		if _, err := r.renew(ctx, serviceGeneration+":"+id); err != nil {
+			log.Errorf(err, "failed to invalidate cached service %s", id)
+		}
+	}
+	if _, err := r.renew(ctx, searchGeneration); err != nil {
+		log.Errorf(err, "failed to invalidate cached searches")
+	}
+}
+
+func (r *cachedServiceRepository) invalidateAll(ctx context.Context) {
+	ctx = context.WithoutCancel(ctx)
+	for _, name := range []string{serviceGeneration, searchGeneration} {
+		if _, err := r.renew(ctx, name); err != nil {
+			logger.NewContextLogger(ctx, "CachedServiceRepository/invalidateAll").Errorf(err, "failed to invalidate cached services")
+		}
+	}
+}
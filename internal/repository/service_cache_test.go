@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"catalog-service/internal/cache"
+	"catalog-service/internal/config"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	"catalog-service/internal/opensearch"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeServiceRepository counts reads and answers them from services. FindByID
+// waits for release, when set, after reading.
+type fakeServiceRepository struct {
+	ServiceRepository
+	mu       sync.Mutex
+	services map[string]*models.Service
+	finds    atomic.Int32
+	searches atomic.Int32
+	release  chan struct{}
+	err      error
+}
+
+func (f *fakeServiceRepository) FindByID(_ context.Context, id string) (*models.Service, error) {
+	f.finds.Add(1)
+	f.mu.Lock()
+	err := f.err
+	svc, ok := f.services[id]
+	var copied models.Service
+	if ok {
+		copied = *svc
+	}
+	f.mu.Unlock()
+	if f.release != nil {
+		<-f.release
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	return &copied, nil
+}
+
+func (f *fakeServiceRepository) SearchWithFilter(context.Context, string, models.ServiceFilter, int, int) ([]*models.Service, int, error) {
+	f.searches.Add(1)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	services := make([]*models.Service, 0, len(f.services))
+	for _, svc := range f.services {
+		copied := *svc
+		services = append(services, &copied)
+	}
+	return services, len(services), nil
+}
+
+func (f *fakeServiceRepository) Update(_ context.Context, service *models.Service, _ ...*models.ServiceEvent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.services[service.ID] = service
+	return f.err
+}
+
+func (f *fakeServiceRepository) Delete(_ context.Context, id string, _ ...*models.ServiceEvent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.services, id)
+	return nil
+}
+
+func (f *fakeServiceRepository) BulkCreate(context.Context, <-chan *models.Service, opensearch.BulkOptions) (*opensearch.BulkResult, error) {
+	return &opensearch.BulkResult{}, nil
+}
+
+type CachedServiceRepoTestSuite struct {
+	suite.Suite
+	next *fakeServiceRepository
+	repo ServiceRepository
+}
+
+func TestCachedServiceRepoSuite(t *testing.T) {
+	suite.Run(t, new(CachedServiceRepoTestSuite))
+}
+
+func (suite *CachedServiceRepoTestSuite) SetupTest() {
+	config.Load()
+	logger.Setup("INFO", "json")
+	suite.next = &fakeServiceRepository{services: map[string]*models.Service{
+		"svc-1": {ID: "svc-1", Name: "Billing", Description: "old"},
+	}}
+	suite.repo = NewCachedServiceRepository(suite.next, cache.NewLRU(100), CacheOptions{FindByIDTTL: time.Minute, SearchTTL: time.Minute})
+}
+
+func (suite *CachedServiceRepoTestSuite) Test_FindByID_ServesRepeatedReadsFromCache() {
+	first, err := suite.repo.FindByID(context.Background(), "svc-1")
+	suite.Require().NoError(err)
+	first.Description = "changed by caller"
+
+	second, err := suite.repo.FindByID(context.Background(), "svc-1")
+
+	suite.Require().NoError(err)
+	suite.Equal("old", second.Description, "callers get their own copy")
+	suite.Equal(int32(1), suite.next.finds.Load())
+}
+
+func (suite *CachedServiceRepoTestSuite) Test_FindByID_DoesNotCacheErrors() {
+	_, err := suite.repo.FindByID(context.Background(), "missing")
+	suite.ErrorIs(err, ErrNotFound)
+	_, err = suite.repo.FindByID(context.Background(), "missing")
+	suite.ErrorIs(err, ErrNotFound)
+
+	suite.Equal(int32(2), suite.next.finds.Load())
+}
+
+func (suite *CachedServiceRepoTestSuite) Test_FindByID_CollapsesConcurrentMisses() {
+	suite.next.release = make(chan struct{})
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			svc, err := suite.repo.FindByID(context.Background(), "svc-1")
+			assert.NoError(suite.T(), err)
+			assert.Equal(suite.T(), "Billing", svc.Name)
+		}()
+	}
+	suite.Eventually(func() bool { return suite.next.finds.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	close(suite.next.release)
+	wg.Wait()
+
+	suite.Equal(int32(1), suite.next.finds.Load())
+}
+
+func (suite *CachedServiceRepoTestSuite) Test_WritesInvalidateDetailAndSearches() {
+	ctx := context.Background()
+	_, _, err := suite.repo.Search(ctx, "", 1, 10)
+	suite.Require().NoError(err)
+	_, err = suite.repo.FindByID(ctx, "svc-1")
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.repo.Update(ctx, &models.Service{ID: "svc-1", Name: "Billing", Description: "new"}))
+
+	svc, err := suite.repo.FindByID(ctx, "svc-1")
+	suite.Require().NoError(err)
+	suite.Equal("new", svc.Description)
+	services, _, err := suite.repo.Search(ctx, "", 1, 10)
+	suite.Require().NoError(err)
+	suite.Equal("new", services[0].Description)
+	suite.Equal(int32(2), suite.next.searches.Load())
+
+	suite.Require().NoError(suite.repo.Delete(ctx, "svc-1"))
+	_, err = suite.repo.FindByID(ctx, "svc-1")
+	suite.ErrorIs(err, ErrNotFound)
+}
+
+func (suite *CachedServiceRepoTestSuite) Test_LoadRacingAWriteIsNotCached() {
+	ctx := context.Background()
+	suite.next.release = make(chan struct{})
+	stale := make(chan *models.Service)
+	go func() {
+		svc, _ := suite.repo.FindByID(ctx, "svc-1")
+		stale <- svc
+	}()
+	suite.Eventually(func() bool { return suite.next.finds.Load() == 1 }, time.Second, time.Millisecond)
+
+	suite.Require().NoError(suite.repo.Update(ctx, &models.Service{ID: "svc-1", Name: "Billing", Description: "new"}))
+	close(suite.next.release)
+	suite.Equal("old", (<-stale).Description, "the load read before the write")
+
+	svc, err := suite.repo.FindByID(ctx, "svc-1")
+
+	suite.Require().NoError(err)
+	suite.Equal("new", svc.Description)
+	suite.Equal(int32(2), suite.next.finds.Load())
+}
+
+func (suite *CachedServiceRepoTestSuite) Test_FailedUpdateStillInvalidates() {
+	ctx := context.Background()
+	_, err := suite.repo.FindByID(ctx, "svc-1")
+	suite.Require().NoError(err)
+	suite.next.err = ErrUnavailable
+
+	suite.Error(suite.repo.Update(ctx, &models.Service{ID: "svc-1", Description: "maybe applied"}))
+	suite.next.err = nil
+	_, err = suite.repo.FindByID(ctx, "svc-1")
+
+	suite.Require().NoError(err)
+	suite.Equal(int32(2), suite.next.finds.Load())
+}
+
+func (suite *CachedServiceRepoTestSuite) Test_BulkWritesInvalidateEverything() {
+	ctx := context.Background()
+	_, err := suite.repo.FindByID(ctx, "svc-1")
+	suite.Require().NoError(err)
+	_, _, err = suite.repo.Search(ctx, "billing", 1, 10)
+	suite.Require().NoError(err)
+
+	_, err = suite.repo.BulkCreate(ctx, nil, opensearch.BulkOptions{})
+	suite.Require().NoError(err)
+	_, err = suite.repo.FindByID(ctx, "svc-1")
+	suite.Require().NoError(err)
+	_, _, err = suite.repo.Search(ctx, "billing", 1, 10)
+	suite.Require().NoError(err)
+
+	suite.Equal(int32(2), suite.next.finds.Load())
+	suite.Equal(int32(2), suite.next.searches.Load())
+}
+
+func (suite *CachedServiceRepoTestSuite) Test_SearchesAreKeyedByParameters() {
+	ctx := context.Background()
+	for range 2 {
+		_, _, _ = suite.repo.Search(ctx, "billing", 1, 10)
+		_, _, _ = suite.repo.Search(ctx, "billing", 2, 10)
+		_, _, _ = suite.repo.SearchWithFilter(ctx, "billing", models.ServiceFilter{Version: "1.0.0"}, 1, 10)
+	}
+
+	suite.Equal(int32(3), suite.next.searches.Load())
+}
+
+func (suite *CachedServiceRepoTestSuite) Test_WithoutCacheAndZeroTTLReadThrough() {
+	_, err := suite.repo.FindByID(context.Background(), "svc-1")
+	suite.Require().NoError(err)
+	_, err = suite.repo.FindByID(WithoutCache(context.Background()), "svc-1")
+	suite.Require().NoError(err)
+	suite.Equal(int32(2), suite.next.finds.Load())
+
+	repo := NewCachedServiceRepository(suite.next, cache.NewLRU(100), CacheOptions{FindByIDTTL: time.Minute})
+	_, _, _ = repo.Search(context.Background(), "", 1, 10)
+	_, _, _ = repo.Search(context.Background(), "", 1, 10)
+	suite.Equal(int32(2), suite.next.searches.Load())
+}
@@ -2,13 +2,27 @@ package repository
 
 import (
 	"catalog-service/internal/models"
+	"catalog-service/internal/opensearch"
 	"context"
 )
 
+var (
+	ErrNotFound    = opensearch.ErrNotFound
+	ErrUnavailable = opensearch.ErrUnavailable
+)
+
+// ServiceRepository stores events passed to Create, Update and Delete in the
+// outbox in the same request as the write. Create fills in the service id of
+// events that have none.
 type ServiceRepository interface {
-	Create(ctx context.Context, service *models.Service) error
+	Create(ctx context.Context, service *models.Service, events ...*models.ServiceEvent) error
 	Search(ctx context.Context, query string, page, limit int) ([]*models.Service, int, error)
+	SearchWithFilter(ctx context.Context, query string, filter models.ServiceFilter, page, limit int) ([]*models.Service, int, error)
 	FindByID(ctx context.Context, id string) (*models.Service, error)
-	Delete(ctx context.Context, id string) error
-	Update(ctx context.Context, service *models.Service) error
+	FindByName(ctx context.Context, name string) (*models.Service, error)
+	Delete(ctx context.Context, id string, events ...*models.ServiceEvent) error
+	Update(ctx context.Context, service *models.Service, events ...*models.ServiceEvent) error
+	BulkCreate(ctx context.Context, services <-chan *models.Service, opts opensearch.BulkOptions) (*opensearch.BulkResult, error)
+	Export(ctx context.Context, query string, filter models.ServiceFilter, fn func(*models.Service) error) error
+	BulkUpsert(ctx context.Context, services <-chan *models.Service, keyField string, opts opensearch.BulkOptions) (*opensearch.BulkResult, error)
 }
@@ -8,6 +8,7 @@ import (
 	"catalog-service/internal/config"
 	"catalog-service/internal/logger"
 	"catalog-service/internal/models"
+	"catalog-service/internal/opensearch"
 	opensearchmock "catalog-service/test/mocks/opensearch"
 
 	"github.com/stretchr/testify/assert"
@@ -53,6 +54,40 @@ func (suite *ServiceRepoTestSuite) Test_Search_Success() {
 	assert.Equal(suite.T(), "Contact Us", services[1].Name)
 }
 
+func (suite *ServiceRepoTestSuite) Test_SearchWithFilter_AddsFilterClauses() {
+	mockClient := new(opensearchmock.Client)
+	after := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	mockClient.On("Search", mock.Anything, "services", mock.MatchedBy(func(body map[string]interface{}) bool {
+		return assert.ObjectsAreEqual(map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must": []map[string]interface{}{buildQuery("billing")},
+				"filter": []map[string]interface{}{
+					{"term": map[string]interface{}{"name.keyword": "Billing"}},
+					{"nested": map[string]interface{}{
+						"path":  "versions",
+						"query": map[string]interface{}{"term": map[string]interface{}{"versions.version_number": "2.0.0"}},
+					}},
+					{"term": map[string]interface{}{"operations.keyword": "GET /invoices"}},
+					{"range": map[string]interface{}{"updated_at": map[string]interface{}{"gte": "2024-06-01T00:00:00Z"}}},
+				},
+			},
+		}, body["query"]) && body["from"] == 10 && body["size"] == 5
+	})).Return([]map[string]interface{}{{"name": "Billing"}}, 11, nil)
+
+	repo := &ServiceRepositoryImpl{Client: mockClient}
+	services, total, err := repo.SearchWithFilter(context.Background(), "billing", models.ServiceFilter{
+		Name:         "Billing",
+		Version:      "2.0.0",
+		Operation:    "GET /invoices",
+		UpdatedAfter: after,
+	}, 3, 5)
+
+	suite.Require().NoError(err)
+	suite.Equal(11, total)
+	suite.Len(services, 1)
+	mockClient.AssertExpectations(suite.T())
+}
+
 func (suite *ServiceRepoTestSuite) Test_Search_Error() {
 	mockClient := new(opensearchmock.Client)
 	mockClient.On("Search", mock.Anything, "services", mock.Anything).Return(
@@ -109,3 +144,251 @@ func (suite *ServiceRepoTestSuite) Test_FindByID_NotFound() {
 	_, err := repo.FindByID(ctx, "does-not-exist-id")
 	assert.Error(suite.T(), err)
 }
+
+func (suite *ServiceRepoTestSuite) Test_Create_StoresEventsInOutboxWithTheWrite() {
+	mockClient := new(opensearchmock.Client)
+	var sent []opensearch.WriteOperation
+	mockClient.On("Write", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		sent = args.Get(1).([]opensearch.WriteOperation)
+	}).Return([]opensearch.BulkItemResult{{}, {}, {}}, nil)
+	repo := &ServiceRepositoryImpl{Client: mockClient}
+
+	svc := &models.Service{Name: "billing"}
+	created := &models.ServiceEvent{Type: models.EventServiceCreated}
+	published := &models.ServiceEvent{Type: models.EventVersionPublished}
+	suite.Require().NoError(repo.Create(context.Background(), svc, created, published))
+
+	suite.Require().Len(sent, 3)
+	suite.Equal(opensearch.WriteOperation{Index: ServiceIndexName, ID: svc.ID, Document: svc}, sent[0])
+	for i, event := range []*models.ServiceEvent{created, published} {
+		msg := sent[i+1].Document.(*models.OutboxMessage)
+		suite.Equal(OutboxIndexName, sent[i+1].Index)
+		suite.Equal(msg.ID, sent[i+1].ID)
+		suite.Equal(i, msg.Sequence)
+		suite.Equal(svc.ID, msg.Event.ServiceID)
+		suite.Equal(msg.ID, event.DedupID)
+	}
+}
+
+func (suite *ServiceRepoTestSuite) Test_Delete_RemovesOutboxMessagesWhenTheWriteFails() {
+	mockClient := new(opensearchmock.Client)
+	notFound := opensearch.BulkItemResult{Status: 404, Err: opensearch.ErrNotFound}
+	mockClient.On("Write", mock.Anything, mock.MatchedBy(func(ops []opensearch.WriteOperation) bool {
+		return len(ops) == 2 && ops[0].Action == opensearch.BulkActionDelete && ops[0].Index == ServiceIndexName
+	})).Return([]opensearch.BulkItemResult{notFound, {}}, nil).Once()
+	var undo []opensearch.WriteOperation
+	mockClient.On("Write", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		undo = args.Get(1).([]opensearch.WriteOperation)
+	}).Return([]opensearch.BulkItemResult{{}}, nil).Once()
+	repo := &ServiceRepositoryImpl{Client: mockClient}
+
+	event := &models.ServiceEvent{Type: models.EventServiceDeleted, ServiceID: "missing"}
+	err := repo.Delete(context.Background(), "missing", event)
+
+	suite.ErrorIs(err, ErrNotFound)
+	suite.Equal([]opensearch.WriteOperation{{Action: opensearch.BulkActionDelete, Index: OutboxIndexName, ID: event.DedupID}}, undo)
+}
+
+func (suite *ServiceRepoTestSuite) Test_Update_WithoutEventsIndexesDirectly() {
+	mockClient := new(opensearchmock.Client)
+	mockClient.On("IndexDocument", mock.Anything, "svc-1", mock.Anything, ServiceIndexName).Return(nil)
+	repo := &ServiceRepositoryImpl{Client: mockClient}
+
+	suite.NoError(repo.Update(context.Background(), &models.Service{ID: "svc-1"}))
+	mockClient.AssertNotCalled(suite.T(), "Write", mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceRepoTestSuite) Test_BulkCreate_PreparesAndForwardsServices() {
+	mockClient := new(opensearchmock.Client)
+	var forwarded []opensearch.BulkDocument
+	mockClient.On("BulkIndex", mock.Anything, "services", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			for doc := range args.Get(2).(<-chan opensearch.BulkDocument) {
+				forwarded = append(forwarded, doc)
+			}
+		}).
+		Return(&opensearch.BulkResult{Indexed: 2}, nil)
+
+	repo := &ServiceRepositoryImpl{Client: mockClient}
+
+	services := make(chan *models.Service, 2)
+	services <- &models.Service{Name: "First"}
+	services <- &models.Service{ID: "fixed-id", Name: "Second"}
+	close(services)
+
+	result, err := repo.BulkCreate(context.Background(), services, opensearch.BulkOptions{})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, result.Indexed)
+	assert.Len(suite.T(), forwarded, 2)
+	assert.NotEmpty(suite.T(), forwarded[0].ID)
+	assert.Equal(suite.T(), "fixed-id", forwarded[1].ID)
+	assert.False(suite.T(), forwarded[1].Document.(*models.Service).UpdatedAt.IsZero())
+}
+
+func (suite *ServiceRepoTestSuite) Test_BulkUpsert_ResolvesNaturalKeysAndMergesDuplicates() {
+	mockClient := new(opensearchmock.Client)
+	mockClient.On("Search", mock.Anything, "services", mock.Anything).Return(
+		[]map[string]interface{}{{"id": "existing-id", "name": "Forex Card"}}, 1, nil,
+	).Once()
+	var forwarded []opensearch.BulkDocument
+	mockClient.On("BulkIndex", mock.Anything, "services", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			for doc := range args.Get(2).(<-chan opensearch.BulkDocument) {
+				forwarded = append(forwarded, doc)
+			}
+		}).
+		Return(&opensearch.BulkResult{Indexed: 2, Created: 1, Updated: 1}, nil)
+
+	repo := &ServiceRepositoryImpl{Client: mockClient}
+
+	services := make(chan *models.Service, 3)
+	services <- &models.Service{Name: "Forex Card", Versions: []models.Version{{VersionNumber: "1.0"}}}
+	services <- &models.Service{Name: "Forex Card", Description: "Student card", Versions: []models.Version{{VersionNumber: "2.0"}}}
+	services <- &models.Service{Name: "Home Loan", Versions: []models.Version{{VersionNumber: "1.0"}}}
+	close(services)
+
+	result, err := repo.BulkUpsert(context.Background(), services, "name", opensearch.BulkOptions{})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.Created)
+	assert.Len(suite.T(), forwarded, 2)
+
+	assert.Equal(suite.T(), "existing-id", forwarded[0].ID)
+	assert.Equal(suite.T(), opensearch.BulkActionUpdate, forwarded[0].Action)
+	upsert := forwarded[0].Document.(map[string]interface{})["upsert"].(*models.Service)
+	assert.Equal(suite.T(), "Student card", upsert.Description)
+	assert.Len(suite.T(), upsert.Versions, 2)
+
+	assert.Equal(suite.T(), NaturalKeyID("name", "Home Loan"), forwarded[1].ID)
+	mockClient.AssertExpectations(suite.T())
+}
+
+func (suite *ServiceRepoTestSuite) Test_BulkUpsert_FailsWhenLookupFails() {
+	mockClient := new(opensearchmock.Client)
+	mockClient.On("Search", mock.Anything, "services", mock.Anything).Return(nil, 0, assert.AnError)
+	mockClient.On("BulkIndex", mock.Anything, "services", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			for range args.Get(2).(<-chan opensearch.BulkDocument) {
+			}
+		}).
+		Return(&opensearch.BulkResult{}, nil)
+
+	repo := &ServiceRepositoryImpl{Client: mockClient}
+
+	services := make(chan *models.Service, 1)
+	services <- &models.Service{Name: "Forex Card"}
+	close(services)
+
+	_, err := repo.BulkUpsert(context.Background(), services, "name", opensearch.BulkOptions{})
+	assert.ErrorIs(suite.T(), err, assert.AnError)
+}
+
+func (suite *ServiceRepoTestSuite) Test_BulkUpsert_LooksUpOtherKeyFieldsOnTheirKeywordMapping() {
+	mockClient := new(opensearchmock.Client)
+	mockClient.On("Search", mock.Anything, "services", mock.MatchedBy(func(body map[string]interface{}) bool {
+		terms := body["query"].(map[string]interface{})["terms"].(map[string]interface{})
+		return assert.ObjectsAreEqual([]string{"svc-1"}, terms["id.keyword"])
+	})).Return([]map[string]interface{}{{"id": "svc-1"}}, 1, nil).Once()
+	var forwarded []opensearch.BulkDocument
+	mockClient.On("BulkIndex", mock.Anything, "services", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			for doc := range args.Get(2).(<-chan opensearch.BulkDocument) {
+				forwarded = append(forwarded, doc)
+			}
+		}).
+		Return(&opensearch.BulkResult{Indexed: 1, Updated: 1}, nil)
+
+	repo := &ServiceRepositoryImpl{Client: mockClient}
+
+	services := make(chan *models.Service, 1)
+	services <- &models.Service{ID: "svc-1", Name: "Forex Card"}
+	close(services)
+
+	_, err := repo.BulkUpsert(context.Background(), services, "id", opensearch.BulkOptions{})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), forwarded, 1)
+	assert.Equal(suite.T(), "svc-1", forwarded[0].ID)
+	mockClient.AssertExpectations(suite.T())
+}
+
+func (suite *ServiceRepoTestSuite) Test_BulkUpsert_RejectsKeyFieldsWithoutKeywordMapping() {
+	mockClient := new(opensearchmock.Client)
+	repo := &ServiceRepositoryImpl{Client: mockClient}
+
+	services := make(chan *models.Service, 1)
+	services <- &models.Service{Name: "Forex Card", Description: "Travel card"}
+	close(services)
+
+	_, err := repo.BulkUpsert(context.Background(), services, "description", opensearch.BulkOptions{})
+	assert.ErrorContains(suite.T(), err, `unsupported natural key field "description"`)
+	mockClient.AssertNotCalled(suite.T(), "Search", mock.Anything, mock.Anything, mock.Anything)
+	mockClient.AssertNotCalled(suite.T(), "BulkIndex", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ServiceRepoTestSuite) Test_Export_StreamsServicesFromScroll() {
+	mockClient := new(opensearchmock.Client)
+	mockClient.On("Scroll", mock.Anything, "services", mock.MatchedBy(func(body map[string]interface{}) bool {
+		query := body["query"].(map[string]interface{})
+		_, filtered := query["simple_query_string"]
+		return filtered && body["size"] == config.OpenSearch().ScrollSize()
+	}), config.OpenSearch().ScrollKeepAlive(), mock.Anything).Run(func(args mock.Arguments) {
+		fn := args.Get(4).(opensearch.ScrollFunc)
+		_ = fn([]map[string]interface{}{{"name": "a"}, {"name": "b"}})
+		_ = fn([]map[string]interface{}{{"name": "c"}})
+	}).Return(nil)
+
+	repo := &ServiceRepositoryImpl{Client: mockClient}
+
+	var names []string
+	err := repo.Export(context.Background(), "us", models.ServiceFilter{}, func(service *models.Service) error {
+		names = append(names, service.Name)
+		return nil
+	})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"a", "b", "c"}, names)
+}
+
+func (suite *ServiceRepoTestSuite) Test_Export_AppliesSearchFilter() {
+	mockClient := new(opensearchmock.Client)
+	filter := models.ServiceFilter{Operation: "GET /invoices", UpdatedAfter: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)}
+	mockClient.On("Scroll", mock.Anything, "services", mock.MatchedBy(func(body map[string]interface{}) bool {
+		return assert.ObjectsAreEqual(buildFilteredQuery("us", filter), body["query"])
+	}), mock.Anything, mock.Anything).Return(nil)
+
+	repo := &ServiceRepositoryImpl{Client: mockClient}
+
+	err := repo.Export(context.Background(), "us", filter, func(service *models.Service) error { return nil })
+
+	assert.NoError(suite.T(), err)
+	mockClient.AssertExpectations(suite.T())
+}
+
+func (suite *ServiceRepoTestSuite) Test_Export_ReturnsScrollError() {
+	mockClient := new(opensearchmock.Client)
+	mockClient.On("Scroll", mock.Anything, "services", mock.Anything, mock.Anything, mock.Anything).Return(assert.AnError)
+
+	repo := &ServiceRepositoryImpl{Client: mockClient}
+
+	err := repo.Export(context.Background(), "", models.ServiceFilter{}, func(service *models.Service) error { return nil })
+
+	assert.ErrorIs(suite.T(), err, assert.AnError)
+}
+
+func (suite *ServiceRepoTestSuite) Test_FindByName_ReturnsOldestMatchOrNil() {
+	mockClient := new(opensearchmock.Client)
+	mockClient.On("Search", mock.Anything, "services", mock.MatchedBy(func(body map[string]interface{}) bool {
+		term := body["query"].(map[string]interface{})["term"].(map[string]interface{})
+		return term["name.keyword"] == "Billing" && body["size"] == 1
+	})).Return([]map[string]interface{}{{"id": "svc-1", "name": "Billing"}}, 1, nil).Once()
+	mockClient.On("Search", mock.Anything, "services", mock.Anything).Return([]map[string]interface{}{}, 0, nil).Once()
+
+	repo := &ServiceRepositoryImpl{Client: mockClient}
+
+	svc, err := repo.FindByName(context.Background(), "Billing")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "svc-1", svc.ID)
+
+	svc, err = repo.FindByName(context.Background(), "Unknown")
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), svc)
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	"catalog-service/internal/opensearch"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SpecIndexName      = "specs"
+	maxSpecsPerService = 1000
+)
+
+var specNamespace = uuid.MustParse("0b6f4f4e-8f0c-4d5e-a7a1-2c9d3e6b5f10")
+
+type SpecRepositoryImpl struct {
+	opensearch.Client
+}
+
+func NewSpecRepository(client opensearch.Client) (SpecRepository, error) {
+	return &SpecRepositoryImpl{Client: client}, nil
+}
+
+func SpecID(serviceID, version string) string {
+	return uuid.NewSHA1(specNamespace, []byte(serviceID+"@"+version)).String()
+}
+
+func (r *SpecRepositoryImpl) Save(ctx context.Context, spec *models.Spec) error {
+	log := logger.NewContextLogger(ctx, "SpecRepositoryImpl/Save")
+	if spec == nil || spec.ServiceID == "" || spec.Version == "" {
+		return fmt.Errorf("spec must reference a service and a version")
+	}
+
+	spec.ID = SpecID(spec.ServiceID, spec.Version)
+	now := time.Now().UTC()
+	if spec.CreatedAt.IsZero() {
+		spec.CreatedAt = now
+	}
+	spec.UpdatedAt = now
+
+	log.Debugf("storing %s spec for service %s version %s", spec.Format, spec.ServiceID, spec.Version)
+	return r.IndexDocument(ctx, spec.ID, spec, SpecIndexName)
+}
+
+func (r *SpecRepositoryImpl) FindByVersion(ctx context.Context, serviceID, version string) (*models.Spec, error) {
+	log := logger.NewContextLogger(ctx, "SpecRepositoryImpl/FindByVersion")
+	doc, err := r.Client.FindDocumentByID(ctx, SpecIndexName, SpecID(serviceID, version))
+	if err != nil {
+		log.Errorf(err, "failed to find spec for service %s version %s", serviceID, version)
+		return nil, err
+	}
+	var spec models.Spec
+	b, _ := json.Marshal(doc)
+	if err := json.Unmarshal(b, &spec); err != nil {
+		log.Errorf(err, "failed to unmarshal document to Spec")
+		return nil, err
+	}
+	return &spec, nil
+}
+
+func (r *SpecRepositoryImpl) FindByService(ctx context.Context, serviceID string) ([]*models.Spec, error) {
+	log := logger.NewContextLogger(ctx, "SpecRepositoryImpl/FindByService")
+	hits, _, err := r.Client.Search(ctx, SpecIndexName, buildSpecsByServiceBody(serviceID))
+	if err != nil {
+		log.Errorf(err, "failed to list specs for service %s", serviceID)
+		return nil, fmt.Errorf("spec search failed: %w", err)
+	}
+
+	specs := make([]*models.Spec, 0, len(hits))
+	for _, hit := range hits {
+		var spec models.Spec
+		b, _ := json.Marshal(hit)
+		if err := json.Unmarshal(b, &spec); err != nil {
+			log.Errorf(err, "failed to unmarshal spec hit")
+			continue
+		}
+		specs = append(specs, &spec)
+	}
+	return specs, nil
+}
+
+func buildSpecsByServiceBody(serviceID string) map[string]interface{} {
+	return map[string]interface{}{
+		"query": map[string]interface{}{
+			"term": map[string]interface{}{"service_id": serviceID},
+		},
+		"size":    maxSpecsPerService,
+		"_source": map[string]interface{}{"excludes": []string{"content"}},
+	}
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"catalog-service/internal/models"
+	"context"
+)
+
+type SpecRepository interface {
+	Save(ctx context.Context, spec *models.Spec) error
+	FindByVersion(ctx context.Context, serviceID, version string) (*models.Spec, error)
+	FindByService(ctx context.Context, serviceID string) ([]*models.Spec, error)
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"catalog-service/internal/config"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	opensearchmock "catalog-service/test/mocks/opensearch"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type SpecRepoTestSuite struct {
+	suite.Suite
+}
+
+func TestSpecRepo(t *testing.T) {
+	suite.Run(t, new(SpecRepoTestSuite))
+}
+
+func (suite *SpecRepoTestSuite) SetupTest() {
+	config.Load()
+	logger.Setup("INFO", "json")
+}
+
+func (suite *SpecRepoTestSuite) Test_Save_UsesDeterministicIDPerServiceVersion() {
+	mockClient := new(opensearchmock.Client)
+	mockClient.On("IndexDocument", mock.Anything, SpecID("svc-1", "1.0.0"), mock.Anything, "specs").Return(nil)
+
+	repo := &SpecRepositoryImpl{Client: mockClient}
+	spec := &models.Spec{ServiceID: "svc-1", Version: "1.0.0", Format: "openapi"}
+
+	assert.NoError(suite.T(), repo.Save(context.Background(), spec))
+	assert.Equal(suite.T(), SpecID("svc-1", "1.0.0"), spec.ID)
+	assert.NotEqual(suite.T(), SpecID("svc-1", "1.0.1"), spec.ID)
+	assert.False(suite.T(), spec.UpdatedAt.IsZero())
+	assert.Error(suite.T(), repo.Save(context.Background(), &models.Spec{ServiceID: "svc-1"}))
+}
+
+func (suite *SpecRepoTestSuite) Test_FindByService_ListsSpecsWithoutContent() {
+	mockClient := new(opensearchmock.Client)
+	mockClient.On("Search", mock.Anything, "specs", mock.MatchedBy(func(body map[string]interface{}) bool {
+		source := body["_source"].(map[string]interface{})
+		return assert.ObjectsAreEqual([]string{"content"}, source["excludes"])
+	})).Return([]map[string]interface{}{
+		{"service_id": "svc-1", "version": "1.0.0", "operations": []interface{}{"GET /pets"}},
+	}, 1, nil)
+
+	repo := &SpecRepositoryImpl{Client: mockClient}
+
+	specs, err := repo.FindByService(context.Background(), "svc-1")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), specs, 1)
+	assert.Equal(suite.T(), []string{"GET /pets"}, specs[0].Operations)
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	"catalog-service/internal/opensearch"
+
+	"github.com/google/uuid"
+)
+
+const (
+	WebhookIndexName  = "webhooks"
+	DeliveryIndexName = "webhook_deliveries"
+	maxWebhooks       = 1000
+)
+
+type WebhookRepositoryImpl struct {
+	opensearch.Client
+}
+
+func NewWebhookRepository(client opensearch.Client) (WebhookRepository, error) {
+	return &WebhookRepositoryImpl{Client: client}, nil
+}
+
+func (r *WebhookRepositoryImpl) Save(ctx context.Context, webhook *models.Webhook) error {
+	now := time.Now().UTC()
+	if webhook.ID == "" {
+		webhook.ID = uuid.NewString()
+	}
+	if webhook.CreatedAt.IsZero() {
+		webhook.CreatedAt = now
+	}
+	webhook.UpdatedAt = now
+	return r.IndexDocument(ctx, webhook.ID, webhook, WebhookIndexName)
+}
+
+func (r *WebhookRepositoryImpl) FindByID(ctx context.Context, id string) (*models.Webhook, error) {
+	doc, err := r.FindDocumentByID(ctx, WebhookIndexName, id)
+	if err != nil {
+		return nil, err
+	}
+	var webhook models.Webhook
+	if err := decodeDocument(doc, &webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *WebhookRepositoryImpl) List(ctx context.Context, filter models.WebhookFilter) ([]*models.Webhook, error) {
+	log := logger.NewContextLogger(ctx, "WebhookRepositoryImpl/List")
+	hits, _, err := r.Search(ctx, WebhookIndexName, buildWebhookListBody(filter))
+	if err != nil {
+		log.Errorf(err, "failed to list webhooks")
+		return nil, fmt.Errorf("webhook search failed: %w", err)
+	}
+
+	webhooks := make([]*models.Webhook, 0, len(hits))
+	for _, hit := range hits {
+		var webhook models.Webhook
+		if err := decodeDocument(hit, &webhook); err != nil {
+			log.Errorf(err, "failed to unmarshal webhook hit")
+			continue
+		}
+		webhooks = append(webhooks, &webhook)
+	}
+	return webhooks, nil
+}
+
+func (r *WebhookRepositoryImpl) Delete(ctx context.Context, id string) error {
+	return r.DeleteDocumentByID(ctx, WebhookIndexName, id)
+}
+
+func (r *WebhookRepositoryImpl) SaveDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	now := time.Now().UTC()
+	if delivery.ID == "" {
+		delivery.ID = uuid.NewString()
+	}
+	if delivery.CreatedAt.IsZero() {
+		delivery.CreatedAt = now
+	}
+	delivery.UpdatedAt = now
+	return r.IndexDocument(ctx, delivery.ID, delivery, DeliveryIndexName)
+}
+
+func (r *WebhookRepositoryImpl) FindDelivery(ctx context.Context, id string) (*models.WebhookDelivery, error) {
+	doc, err := r.FindDocumentByID(ctx, DeliveryIndexName, id)
+	if err != nil {
+		return nil, err
+	}
+	var delivery models.WebhookDelivery
+	if err := decodeDocument(doc, &delivery); err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *WebhookRepositoryImpl) ListDeliveries(ctx context.Context, webhookID string, limit int) ([]*models.WebhookDelivery, error) {
+	log := logger.NewContextLogger(ctx, "WebhookRepositoryImpl/ListDeliveries")
+	body := map[string]interface{}{
+		"query": map[string]interface{}{
+			"term": map[string]interface{}{"webhook_id": webhookID},
+		},
+		"sort": []interface{}{map[string]interface{}{"created_at": "desc"}},
+		"size": limit,
+	}
+	hits, _, err := r.Search(ctx, DeliveryIndexName, body)
+	if err != nil {
+		log.Errorf(err, "failed to list deliveries for webhook %s", webhookID)
+		return nil, fmt.Errorf("delivery search failed: %w", err)
+	}
+
+	deliveries := make([]*models.WebhookDelivery, 0, len(hits))
+	for _, hit := range hits {
+		var delivery models.WebhookDelivery
+		if err := decodeDocument(hit, &delivery); err != nil {
+			log.Errorf(err, "failed to unmarshal delivery hit")
+			continue
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+	return deliveries, nil
+}
+
+func buildWebhookListBody(filter models.WebhookFilter) map[string]interface{} {
+	var clauses []interface{}
+	if filter.ServiceID != "" {
+		clauses = append(clauses, map[string]interface{}{"term": map[string]interface{}{"service_ids": filter.ServiceID}})
+	}
+	if filter.Label != "" {
+		clauses = append(clauses, map[string]interface{}{"term": map[string]interface{}{"labels": filter.Label}})
+	}
+	if filter.EventType != "" {
+		clauses = append(clauses, map[string]interface{}{"term": map[string]interface{}{"event_types": string(filter.EventType)}})
+	}
+
+	query := map[string]interface{}{"match_all": map[string]interface{}{}}
+	if len(clauses) > 0 {
+		query = map[string]interface{}{"bool": map[string]interface{}{"filter": clauses}}
+	}
+	return map[string]interface{}{
+		"query": query,
+		"sort":  []interface{}{map[string]interface{}{"created_at": "asc"}},
+		"size":  maxWebhooks,
+	}
+}
+
+func decodeDocument(doc map[string]interface{}, out interface{}) error {
+	b, _ := json.Marshal(doc)
+	if err := json.Unmarshal(b, out); err != nil {
+		return fmt.Errorf("failed to unmarshal document: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"catalog-service/internal/models"
+	"context"
+)
+
+type WebhookRepository interface {
+	Save(ctx context.Context, webhook *models.Webhook) error
+	FindByID(ctx context.Context, id string) (*models.Webhook, error)
+	List(ctx context.Context, filter models.WebhookFilter) ([]*models.Webhook, error)
+	Delete(ctx context.Context, id string) error
+	SaveDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	FindDelivery(ctx context.Context, id string) (*models.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, webhookID string, limit int) ([]*models.WebhookDelivery, error)
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"catalog-service/internal/config"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	opensearchmock "catalog-service/test/mocks/opensearch"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookRepoTestSuite struct {
+	suite.Suite
+}
+
+func TestWebhookRepo(t *testing.T) {
+	suite.Run(t, new(WebhookRepoTestSuite))
+}
+
+func (suite *WebhookRepoTestSuite) SetupTest() {
+	config.Load()
+	logger.Setup("INFO", "json")
+}
+
+func (suite *WebhookRepoTestSuite) Test_BuildWebhookListBody() {
+	body := buildWebhookListBody(models.WebhookFilter{})
+	suite.Equal(map[string]interface{}{"match_all": map[string]interface{}{}}, body["query"])
+	suite.Equal(maxWebhooks, body["size"])
+
+	body = buildWebhookListBody(models.WebhookFilter{ServiceID: "svc-1", Label: "payments", EventType: "service.updated"})
+	suite.Equal(map[string]interface{}{"bool": map[string]interface{}{"filter": []interface{}{
+		map[string]interface{}{"term": map[string]interface{}{"service_ids": "svc-1"}},
+		map[string]interface{}{"term": map[string]interface{}{"labels": "payments"}},
+		map[string]interface{}{"term": map[string]interface{}{"event_types": "service.updated"}},
+	}}}, body["query"])
+}
+
+func (suite *WebhookRepoTestSuite) Test_Save_AssignsIDAndTimestamps() {
+	mockClient := new(opensearchmock.Client)
+	mockClient.On("IndexDocument", mock.Anything, mock.AnythingOfType("string"), mock.Anything, WebhookIndexName).Return(nil)
+	repo := &WebhookRepositoryImpl{Client: mockClient}
+
+	webhook := &models.Webhook{URL: "https://example.com/hook"}
+	suite.Require().NoError(repo.Save(context.Background(), webhook))
+
+	suite.NotEmpty(webhook.ID)
+	suite.False(webhook.CreatedAt.IsZero())
+	suite.Equal(webhook.CreatedAt, webhook.UpdatedAt)
+	mockClient.AssertExpectations(suite.T())
+}
+
+func (suite *WebhookRepoTestSuite) Test_ListDeliveries_DecodesHits() {
+	mockClient := new(opensearchmock.Client)
+	mockClient.On("Search", mock.Anything, DeliveryIndexName, mock.Anything).Return([]map[string]interface{}{
+		{"id": "d-1", "webhook_id": "wh-1", "status": "failed", "attempts": float64(3), "event": map[string]interface{}{"id": float64(9), "type": "service.deleted", "service_id": "svc-1"}},
+	}, 1, nil)
+	repo := &WebhookRepositoryImpl{Client: mockClient}
+
+	deliveries, err := repo.ListDeliveries(context.Background(), "wh-1", 10)
+
+	suite.Require().NoError(err)
+	suite.Require().Len(deliveries, 1)
+	suite.Equal(models.DeliveryFailed, deliveries[0].Status)
+	suite.Equal(3, deliveries[0].Attempts)
+	suite.Equal(int64(9), deliveries[0].Event.ID)
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"catalog-service/internal/logger"
+
+	"google.golang.org/grpc"
+)
+
+type Server interface {
+	Name() string
+	Serve() error
+	Shutdown(ctx context.Context) error
+}
+
+type httpServer struct {
+	name string
+	srv  *http.Server
+}
+
+func HTTP(name string, srv *http.Server) Server {
+	return &httpServer{name: name, srv: srv}
+}
+
+func (s *httpServer) Name() string {
+	return s.name
+}
+
+func (s *httpServer) Serve() error {
+	if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *httpServer) Shutdown(ctx context.Context) error {
+	return s.srv.Shutdown(ctx)
+}
+
+type grpcServer struct {
+	name string
+	addr string
+	srv  *grpc.Server
+}
+
+func GRPC(name, addr string, srv *grpc.Server) Server {
+	return &grpcServer{name: name, addr: addr, srv: srv}
+}
+
+func (s *grpcServer) Name() string {
+	return s.name
+}
+
+func (s *grpcServer) Serve() error {
+	lis, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	if err := s.srv.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
+
+func (s *grpcServer) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.srv.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.srv.Stop()
+		return ctx.Err()
+	}
+}
+
+// Run serves every server until ctx is cancelled or one of them fails, then
+// shuts all of them down within timeout. It returns the first serve error.
+func Run(ctx context.Context, timeout time.Duration, servers ...Server) error {
+	errs := make(chan error, len(servers))
+	for _, s := range servers {
+		go func(s Server) {
+			logger.NonContext.Infof("starting %s server", s.Name())
+			if err := s.Serve(); err != nil {
+				errs <- fmt.Errorf("%s server: %w", s.Name(), err)
+				return
+			}
+			errs <- nil
+		}(s)
+	}
+
+	var serveErr error
+	select {
+	case <-ctx.Done():
+		logger.NonContext.Info("Shutting down servers...")
+	case serveErr = <-errs:
+		if serveErr != nil {
+			logger.NonContext.Errorf(serveErr, "server failed, shutting down")
+		}
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	var wg sync.WaitGroup
+	for _, s := range servers {
+		wg.Add(1)
+		go func(s Server) {
+			defer wg.Done()
+			if err := s.Shutdown(shutdownCtx); err != nil {
+				logger.NonContext.Errorf(err, "%s server forced to shutdown", s.Name())
+				return
+			}
+			logger.NonContext.Infof("%s server exited gracefully", s.Name())
+		}(s)
+	}
+	wg.Wait()
+	return serveErr
+}
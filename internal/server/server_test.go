@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"catalog-service/internal/config"
+	"catalog-service/internal/logger"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type fakeServer struct {
+	name     string
+	serveErr error
+	stop     chan struct{}
+	shutdown atomic.Bool
+}
+
+func newFakeServer(name string, serveErr error) *fakeServer {
+	return &fakeServer{name: name, serveErr: serveErr, stop: make(chan struct{})}
+}
+
+func (f *fakeServer) Name() string {
+	return f.name
+}
+
+func (f *fakeServer) Serve() error {
+	if f.serveErr != nil {
+		return f.serveErr
+	}
+	<-f.stop
+	return nil
+}
+
+func (f *fakeServer) Shutdown(ctx context.Context) error {
+	f.shutdown.Store(true)
+	close(f.stop)
+	return nil
+}
+
+type ServerSuite struct {
+	suite.Suite
+}
+
+func TestServerSuite(t *testing.T) {
+	suite.Run(t, new(ServerSuite))
+}
+
+func (suite *ServerSuite) SetupTest() {
+	config.Load()
+	logger.Setup("INFO", "json")
+}
+
+func (suite *ServerSuite) Test_Run_ShutsDownAllServersWhenContextIsCancelled() {
+	a, b := newFakeServer("a", nil), newFakeServer("b", nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	err := Run(ctx, time.Second, a, b)
+
+	suite.NoError(err)
+	suite.True(a.shutdown.Load())
+	suite.True(b.shutdown.Load())
+}
+
+func (suite *ServerSuite) Test_Run_StopsEveryServerWhenOneFails() {
+	failure := errors.New("address already in use")
+	a, b := newFakeServer("http", nil), newFakeServer("grpc", failure)
+
+	err := Run(context.Background(), time.Second, a, b)
+
+	suite.ErrorIs(err, failure)
+	suite.ErrorContains(err, "grpc server")
+	suite.True(a.shutdown.Load())
+}
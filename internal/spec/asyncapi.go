@@ -0,0 +1,64 @@
+package spec
+
+import (
+	"strings"
+)
+
+type AsyncAPIDocument struct {
+	AsyncAPI  Text                              `json:"asyncapi"`
+	Info      OpenAPIInfo                       `json:"info"`
+	Channels  map[string]map[string]interface{} `json:"channels"`
+	OpsByName map[string]map[string]interface{} `json:"operations"`
+}
+
+func ParseAsyncAPI(content []byte) (*AsyncAPIDocument, error) {
+	raw, err := decodeDocument(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc AsyncAPIDocument
+	if err := decodeInto(raw, &doc); err != nil {
+		return nil, err
+	}
+	version := string(doc.AsyncAPI)
+	if !strings.HasPrefix(version, "2.") && !strings.HasPrefix(version, "3.") {
+		return nil, invalidf("unsupported asyncapi version %q, expected 2.x or 3.x", version)
+	}
+
+	var missing []string
+	if strings.TrimSpace(string(doc.Info.Title)) == "" {
+		missing = append(missing, "info.title")
+	}
+	if strings.TrimSpace(string(doc.Info.Version)) == "" {
+		missing = append(missing, "info.version")
+	}
+	if len(doc.Channels) == 0 {
+		missing = append(missing, "channels")
+	}
+	if len(missing) > 0 {
+		return nil, invalidf("missing required fields: %s", strings.Join(missing, ", "))
+	}
+	return &doc, nil
+}
+
+func (d *AsyncAPIDocument) Operations() []string {
+	var ops []string
+	for name, channel := range d.Channels {
+		ops = append(ops, name)
+		if address, ok := channel["address"].(string); ok {
+			ops = append(ops, address)
+		}
+		for _, action := range []string{"publish", "subscribe"} {
+			if op, ok := channel[action].(map[string]interface{}); ok {
+				if id, ok := op["operationId"].(string); ok {
+					ops = append(ops, id)
+				}
+			}
+		}
+	}
+	for name := range d.OpsByName {
+		ops = append(ops, name)
+	}
+	return uniqueSorted(ops)
+}
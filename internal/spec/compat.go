@@ -0,0 +1,312 @@
+package spec
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type ChangeType string
+
+const (
+	ChangeEndpointRemoved         ChangeType = "endpoint_removed"
+	ChangeParameterRemoved        ChangeType = "parameter_removed"
+	ChangeRequiredParameterAdded  ChangeType = "required_parameter_added"
+	ChangeParameterBecameRequired ChangeType = "parameter_became_required"
+	ChangeRequestBodyRequired     ChangeType = "request_body_required"
+	ChangeResponseRemoved         ChangeType = "response_removed"
+	ChangeResponseTypeChanged     ChangeType = "response_type_changed"
+)
+
+var pathParam = regexp.MustCompile(`\{[^}]*\}`)
+
+type BreakingChange struct {
+	Type   ChangeType `json:"type"`
+	Method string     `json:"method"`
+	Path   string     `json:"path"`
+	Detail string     `json:"detail"`
+}
+
+type endpoint struct {
+	method string
+	path   string
+	op     map[string]interface{}
+	params []map[string]interface{}
+}
+
+type apiModel struct {
+	root      map[string]interface{}
+	endpoints map[string]*endpoint
+}
+
+func CompareOpenAPI(previous, current []byte) ([]BreakingChange, error) {
+	prev, err := loadAPIModel(previous)
+	if err != nil {
+		return nil, fmt.Errorf("previous spec: %w", err)
+	}
+	curr, err := loadAPIModel(current)
+	if err != nil {
+		return nil, fmt.Errorf("current spec: %w", err)
+	}
+
+	var changes []BreakingChange
+	for _, key := range sortedEndpointKeys(prev.endpoints) {
+		old := prev.endpoints[key]
+		next, ok := curr.endpoints[key]
+		if !ok {
+			changes = append(changes, BreakingChange{Type: ChangeEndpointRemoved, Method: old.method, Path: old.path, Detail: "endpoint was removed"})
+			continue
+		}
+		changes = append(changes, compareParameters(prev, curr, old, next)...)
+		changes = append(changes, compareRequestBody(prev, curr, old, next)...)
+		changes = append(changes, compareResponses(prev, curr, old, next)...)
+	}
+	return changes, nil
+}
+
+func loadAPIModel(content []byte) (*apiModel, error) {
+	if _, err := ParseOpenAPI(content); err != nil {
+		return nil, err
+	}
+	root, _ := decodeDocument(content)
+	m := &apiModel{root: root, endpoints: map[string]*endpoint{}}
+
+	paths, _ := root["paths"].(map[string]interface{})
+	for path, rawItem := range paths {
+		item, _ := m.resolve(rawItem).(map[string]interface{})
+		shared := m.parameters(item["parameters"])
+		for _, method := range openAPIMethods {
+			op, ok := m.resolve(item[method]).(map[string]interface{})
+			if !ok {
+				continue
+			}
+			e := &endpoint{method: strings.ToUpper(method), path: path, op: op}
+			e.params = mergeParameters(shared, m.parameters(op["parameters"]))
+			m.endpoints[e.method+" "+pathParam.ReplaceAllString(path, "{}")] = e
+		}
+	}
+	return m, nil
+}
+
+func (m *apiModel) resolve(v interface{}) interface{} {
+	for depth := 0; depth < 32; depth++ {
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return v
+		}
+		ref, ok := obj["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, "#/") {
+			return v
+		}
+		var target interface{} = m.root
+		for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+			node, ok := target.(map[string]interface{})
+			if !ok {
+				return v
+			}
+			target = node[part]
+		}
+		if target == nil {
+			return v
+		}
+		v = target
+	}
+	return v
+}
+
+func (m *apiModel) parameters(raw interface{}) []map[string]interface{} {
+	list, _ := raw.([]interface{})
+	params := make([]map[string]interface{}, 0, len(list))
+	for _, p := range list {
+		if param, ok := m.resolve(p).(map[string]interface{}); ok {
+			params = append(params, param)
+		}
+	}
+	return params
+}
+
+func mergeParameters(shared, own []map[string]interface{}) []map[string]interface{} {
+	merged := append([]map[string]interface{}{}, own...)
+	for _, p := range shared {
+		if findParameter(own, p) == nil {
+			merged = append(merged, p)
+		}
+	}
+	return merged
+}
+
+func findParameter(params []map[string]interface{}, target map[string]interface{}) map[string]interface{} {
+	for _, p := range params {
+		if p["name"] == target["name"] && p["in"] == target["in"] {
+			return p
+		}
+	}
+	return nil
+}
+
+func compareParameters(prev, curr *apiModel, old, next *endpoint) []BreakingChange {
+	var changes []BreakingChange
+	for _, p := range old.params {
+		if p["in"] == "path" {
+			continue
+		}
+		if findParameter(next.params, p) == nil {
+			changes = append(changes, BreakingChange{Type: ChangeParameterRemoved, Method: next.method, Path: next.path,
+				Detail: fmt.Sprintf("%s parameter %q was removed", p["in"], p["name"])})
+		}
+	}
+	for _, p := range next.params {
+		if p["in"] == "path" || !isTrue(p["required"]) {
+			continue
+		}
+		existing := findParameter(old.params, p)
+		switch {
+		case existing == nil:
+			changes = append(changes, BreakingChange{Type: ChangeRequiredParameterAdded, Method: next.method, Path: next.path,
+				Detail: fmt.Sprintf("required %s parameter %q was added", p["in"], p["name"])})
+		case !isTrue(existing["required"]):
+			changes = append(changes, BreakingChange{Type: ChangeParameterBecameRequired, Method: next.method, Path: next.path,
+				Detail: fmt.Sprintf("%s parameter %q became required", p["in"], p["name"])})
+		}
+	}
+	return changes
+}
+
+func compareRequestBody(prev, curr *apiModel, old, next *endpoint) []BreakingChange {
+	newBody, _ := curr.resolve(next.op["requestBody"]).(map[string]interface{})
+	if newBody == nil || !isTrue(newBody["required"]) {
+		return nil
+	}
+	oldBody, _ := prev.resolve(old.op["requestBody"]).(map[string]interface{})
+	if oldBody != nil && isTrue(oldBody["required"]) {
+		return nil
+	}
+	return []BreakingChange{{Type: ChangeRequestBodyRequired, Method: next.method, Path: next.path, Detail: "request body became required"}}
+}
+
+func compareResponses(prev, curr *apiModel, old, next *endpoint) []BreakingChange {
+	oldResponses, _ := old.op["responses"].(map[string]interface{})
+	newResponses, _ := next.op["responses"].(map[string]interface{})
+
+	var changes []BreakingChange
+	for _, code := range sortedKeys(oldResponses) {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		oldResp, _ := prev.resolve(oldResponses[code]).(map[string]interface{})
+		rawNew, ok := newResponses[code]
+		if !ok {
+			changes = append(changes, BreakingChange{Type: ChangeResponseRemoved, Method: next.method, Path: next.path,
+				Detail: fmt.Sprintf("response %s was removed", code)})
+			continue
+		}
+		newResp, _ := curr.resolve(rawNew).(map[string]interface{})
+
+		oldContent, _ := oldResp["content"].(map[string]interface{})
+		newContent, _ := newResp["content"].(map[string]interface{})
+		for _, mediaType := range sortedKeys(oldContent) {
+			newMedia, ok := newContent[mediaType].(map[string]interface{})
+			if !ok {
+				changes = append(changes, BreakingChange{Type: ChangeResponseTypeChanged, Method: next.method, Path: next.path,
+					Detail: fmt.Sprintf("response %s no longer returns %s", code, mediaType)})
+				continue
+			}
+			oldMedia, _ := oldContent[mediaType].(map[string]interface{})
+			before, after := prev.schemaType(oldMedia["schema"]), curr.schemaType(newMedia["schema"])
+			if before != "" && after != "" && before != after {
+				changes = append(changes, BreakingChange{Type: ChangeResponseTypeChanged, Method: next.method, Path: next.path,
+					Detail: fmt.Sprintf("response %s %s changed from %s to %s", code, mediaType, before, after)})
+			}
+		}
+	}
+	return changes
+}
+
+func (m *apiModel) schemaType(raw interface{}) string {
+	schema, ok := m.resolve(raw).(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	t, _ := schema["type"].(string)
+	if t == "" {
+		if _, ok := schema["properties"]; ok {
+			t = "object"
+		}
+	}
+	if t == "array" {
+		if items := m.schemaType(schema["items"]); items != "" {
+			return "array<" + items + ">"
+		}
+	}
+	return t
+}
+
+func isTrue(v interface{}) bool {
+	b, _ := v.(bool)
+	return b
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedEndpointKeys(m map[string]*endpoint) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func CompareVersions(a, b string) int {
+	pa, pb := versionParts(a), versionParts(b)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+func MajorVersion(version string) (int, bool) {
+	parts := versionParts(version)
+	if len(parts) == 0 {
+		return 0, false
+	}
+	return parts[0], true
+}
+
+func versionParts(version string) []int {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+	var parts []int
+	for _, p := range strings.Split(version, ".") {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return parts
+		}
+		parts = append(parts, n)
+	}
+	return parts
+}
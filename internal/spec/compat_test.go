@@ -0,0 +1,155 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const petsV1 = `openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    parameters:
+      - $ref: '#/components/parameters/Tenant'
+    get:
+      parameters:
+        - name: limit
+          in: query
+        - name: tag
+          in: query
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '201':
+          description: created
+  /pets/{id}:
+    get:
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+    delete:
+      responses:
+        '204':
+          description: deleted
+components:
+  parameters:
+    Tenant:
+      name: X-Tenant
+      in: header
+  schemas:
+    Pet:
+      properties:
+        name:
+          type: string
+`
+
+const petsV2 = `openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.1.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          required: true
+        - name: owner
+          in: query
+          required: true
+        - name: X-Tenant
+          in: header
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                type: object
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+      responses:
+        '202':
+          description: accepted
+  /pets/{petId}:
+    get:
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                type: object
+  /owners:
+    get:
+      responses:
+        '200':
+          description: ok
+`
+
+type CompatTestSuite struct {
+	suite.Suite
+}
+
+func TestCompatSuite(t *testing.T) {
+	suite.Run(t, new(CompatTestSuite))
+}
+
+func (s *CompatTestSuite) Test_CompareOpenAPI_ReportsBreakingChanges() {
+	changes, err := CompareOpenAPI([]byte(petsV1), []byte(petsV2))
+	s.Require().NoError(err)
+
+	s.Equal([]BreakingChange{
+		{Type: ChangeEndpointRemoved, Method: "DELETE", Path: "/pets/{id}", Detail: "endpoint was removed"},
+		{Type: ChangeParameterRemoved, Method: "GET", Path: "/pets", Detail: `query parameter "tag" was removed`},
+		{Type: ChangeParameterBecameRequired, Method: "GET", Path: "/pets", Detail: `query parameter "limit" became required`},
+		{Type: ChangeRequiredParameterAdded, Method: "GET", Path: "/pets", Detail: `required query parameter "owner" was added`},
+		{Type: ChangeResponseTypeChanged, Method: "GET", Path: "/pets", Detail: "response 200 application/json changed from array<object> to object"},
+		{Type: ChangeParameterRemoved, Method: "POST", Path: "/pets", Detail: `header parameter "X-Tenant" was removed`},
+		{Type: ChangeRequestBodyRequired, Method: "POST", Path: "/pets", Detail: "request body became required"},
+		{Type: ChangeResponseRemoved, Method: "POST", Path: "/pets", Detail: "response 201 was removed"},
+	}, changes)
+}
+
+func (s *CompatTestSuite) Test_CompareOpenAPI_AllowsAdditiveChanges() {
+	changes, err := CompareOpenAPI([]byte(petsV2), []byte(petsV2))
+	s.Require().NoError(err)
+	s.Empty(changes)
+
+	_, err = CompareOpenAPI([]byte(petsV1), []byte("openapi: 3.0.0\n"))
+	s.ErrorIs(err, ErrInvalidSpec)
+}
+
+func (s *CompatTestSuite) Test_CompareVersions_OrdersSemver() {
+	s.Equal(-1, CompareVersions("1.9.0", "1.10.0"))
+	s.Equal(1, CompareVersions("v2", "1.99.1"))
+	s.Equal(0, CompareVersions("1.0.0", "1.0.0"))
+	s.Equal(-1, CompareVersions("1.0", "1.0.1"))
+
+	major, ok := MajorVersion("v3.1.0-rc.1")
+	s.True(ok)
+	s.Equal(3, major)
+	_, ok = MajorVersion("latest")
+	s.False(ok)
+}
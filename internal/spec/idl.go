@@ -0,0 +1,182 @@
+package spec
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	protoSyntax   = regexp.MustCompile(`\bsyntax\s*=\s*"([^"]*)"\s*;`)
+	protoSyntaxID = regexp.MustCompile(`\bsyntax\b`)
+	protoService  = regexp.MustCompile(`\bservice\s+(\w+)\s*\{`)
+	protoRPC      = regexp.MustCompile(`\brpc\s+(\w+)\s*\(`)
+
+	graphqlSchema    = regexp.MustCompile(`\bschema\s*(?:@\w+\s*)*\{`)
+	graphqlRootType  = regexp.MustCompile(`\b(query|mutation|subscription)\s*:\s*(\w+)`)
+	graphqlType      = regexp.MustCompile(`\b(?:extend\s+)?type\s+(\w+)[^{}]*\{`)
+	graphqlFieldName = regexp.MustCompile(`(\w+)\s*:`)
+)
+
+func parseProtobuf(content string) ([]string, error) {
+	src, err := stripSource(content, "//", true, false, `"'`)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkBalanced(src); err != nil {
+		return nil, err
+	}
+	if !protobufHint.MatchString(src) {
+		return nil, invalidf("no protobuf definitions found")
+	}
+	if protoSyntaxID.MatchString(src) {
+		m := protoSyntax.FindStringSubmatch(content)
+		if m == nil || (m[1] != "proto2" && m[1] != "proto3") {
+			return nil, invalidf(`syntax must be "proto2" or "proto3"`)
+		}
+	}
+
+	var ops []string
+	for _, loc := range protoService.FindAllStringSubmatchIndex(src, -1) {
+		service := src[loc[2]:loc[3]]
+		body := blockAt(src, loc[1]-1)
+		for _, m := range protoRPC.FindAllStringSubmatch(body, -1) {
+			ops = append(ops, service+"."+m[1], m[1])
+		}
+	}
+	return uniqueSorted(ops), nil
+}
+
+func parseGraphQL(content string) ([]string, error) {
+	src, err := stripSource(content, "#", false, true, `"`)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkBalanced(src); err != nil {
+		return nil, err
+	}
+	if !graphqlHint.MatchString(src) {
+		return nil, invalidf("no GraphQL type definitions found")
+	}
+
+	roots := map[string]string{"Query": "Query", "Mutation": "Mutation", "Subscription": "Subscription"}
+	if loc := graphqlSchema.FindStringIndex(src); loc != nil {
+		roots = map[string]string{}
+		for _, m := range graphqlRootType.FindAllStringSubmatch(blockAt(src, loc[1]-1), -1) {
+			roots[m[2]] = strings.ToUpper(m[1][:1]) + m[1][1:]
+		}
+	}
+
+	var ops []string
+	for _, loc := range graphqlType.FindAllStringSubmatchIndex(src, -1) {
+		root, ok := roots[src[loc[2]:loc[3]]]
+		if !ok {
+			continue
+		}
+		fields := removeNested(blockAt(src, loc[1]-1), '(', ')')
+		for _, m := range graphqlFieldName.FindAllStringSubmatch(fields, -1) {
+			ops = append(ops, root+"."+m[1], m[1])
+		}
+	}
+	return uniqueSorted(ops), nil
+}
+
+func stripSource(src, lineComment string, blockComments, tripleQuotes bool, quotes string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(src); {
+		switch {
+		case strings.HasPrefix(src[i:], lineComment):
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				return b.String(), nil
+			}
+			i += end
+		case blockComments && strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return "", invalidf("unterminated block comment")
+			}
+			i += end + 4
+			b.WriteByte(' ')
+		case tripleQuotes && strings.HasPrefix(src[i:], `"""`):
+			end := strings.Index(src[i+3:], `"""`)
+			if end < 0 {
+				return "", invalidf("unterminated block string")
+			}
+			i += end + 6
+			b.WriteString(`""`)
+		case strings.IndexByte(quotes, src[i]) >= 0:
+			quote := src[i]
+			j := i + 1
+			for ; j < len(src) && src[j] != quote && src[j] != '\n'; j++ {
+				if src[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(src) || src[j] != quote {
+				return "", invalidf("unterminated string literal")
+			}
+			i = j + 1
+			b.WriteString(`""`)
+		default:
+			b.WriteByte(src[i])
+			i++
+		}
+	}
+	return b.String(), nil
+}
+
+func checkBalanced(src string) error {
+	pairs := map[byte]byte{'}': '{', ')': '(', ']': '['}
+	var stack []byte
+	line := 1
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		switch c {
+		case '\n':
+			line++
+		case '{', '(', '[':
+			stack = append(stack, c)
+		case '}', ')', ']':
+			if len(stack) == 0 || stack[len(stack)-1] != pairs[c] {
+				return invalidf("unexpected %q on line %d", c, line)
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	if len(stack) > 0 {
+		return invalidf("unclosed %q", stack[len(stack)-1])
+	}
+	return nil
+}
+
+func blockAt(src string, open int) string {
+	depth := 0
+	for i := open; i < len(src); i++ {
+		switch src[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return src[open+1 : i]
+			}
+		}
+	}
+	return src[open+1:]
+}
+
+func removeNested(src string, open, close byte) string {
+	var b strings.Builder
+	depth := 0
+	for i := 0; i < len(src); i++ {
+		switch {
+		case src[i] == open:
+			depth++
+		case src[i] == close && depth > 0:
+			depth--
+		case depth == 0:
+			b.WriteByte(src[i])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,77 @@
+package spec
+
+import (
+	"strings"
+)
+
+type OpenAPIInfo struct {
+	Title       Text `json:"title"`
+	Description Text `json:"description"`
+	Version     Text `json:"version"`
+}
+
+type OpenAPIDocument struct {
+	OpenAPI Text                              `json:"openapi"`
+	Info    OpenAPIInfo                       `json:"info"`
+	Paths   map[string]map[string]interface{} `json:"paths"`
+}
+
+func IsOpenAPI(content []byte) bool {
+	doc, err := decodeDocument(content)
+	if err != nil {
+		return false
+	}
+	_, ok := doc["openapi"]
+	return ok
+}
+
+func ParseOpenAPI(content []byte) (*OpenAPIDocument, error) {
+	raw, err := decodeDocument(content)
+	if err != nil {
+		return nil, err
+	}
+	if swagger, ok := raw["swagger"]; ok {
+		return nil, invalidf("swagger %v documents are not supported, convert to OpenAPI 3", swagger)
+	}
+
+	var doc OpenAPIDocument
+	if err := decodeInto(raw, &doc); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(string(doc.OpenAPI), "3.") {
+		return nil, invalidf("unsupported openapi version %q, expected 3.x", doc.OpenAPI)
+	}
+
+	doc.Info.Title = Text(strings.TrimSpace(string(doc.Info.Title)))
+	doc.Info.Version = Text(strings.TrimSpace(string(doc.Info.Version)))
+	var missing []string
+	if doc.Info.Title == "" {
+		missing = append(missing, "info.title")
+	}
+	if doc.Info.Version == "" {
+		missing = append(missing, "info.version")
+	}
+	if len(missing) > 0 {
+		return nil, invalidf("missing required fields: %s", strings.Join(missing, ", "))
+	}
+	return &doc, nil
+}
+
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+func (d *OpenAPIDocument) Operations() []string {
+	var ops []string
+	for path, item := range d.Paths {
+		for _, method := range openAPIMethods {
+			op, ok := item[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			ops = append(ops, strings.ToUpper(method)+" "+path)
+			if id, ok := op["operationId"].(string); ok {
+				ops = append(ops, id)
+			}
+		}
+	}
+	return uniqueSorted(ops)
+}
@@ -0,0 +1,53 @@
+package spec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type OpenAPITestSuite struct {
+	suite.Suite
+}
+
+func TestOpenAPISuite(t *testing.T) {
+	suite.Run(t, new(OpenAPITestSuite))
+}
+
+func (s *OpenAPITestSuite) Test_ParseOpenAPI_ReadsJSONAndYAML() {
+	jsonDoc := []byte(`{"openapi": "3.0.3", "info": {"title": "Billing", "description": "Invoices", "version": "1.2.0"}, "paths": {}}`)
+	doc, err := ParseOpenAPI(jsonDoc)
+	s.Require().NoError(err)
+	s.Equal(Text("Billing"), doc.Info.Title)
+	s.Equal(Text("Invoices"), doc.Info.Description)
+	s.Equal(Text("1.2.0"), doc.Info.Version)
+	s.Equal(ContentTypeJSON, DetectContentType(jsonDoc))
+
+	yamlDoc := []byte("openapi: 3.1.0\ninfo:\n  title: Billing\n  version: 1.0\npaths: {}\n")
+	doc, err = ParseOpenAPI(yamlDoc)
+	s.Require().NoError(err)
+	s.Equal(Text("1.0"), doc.Info.Version)
+	s.Equal(ContentTypeYAML, DetectContentType(yamlDoc))
+}
+
+func (s *OpenAPITestSuite) Test_ParseOpenAPI_RejectsInvalidDocuments() {
+	cases := map[string]string{
+		"empty":         "",
+		"not an object": "- a\n- b\n",
+		"swagger":       `{"swagger": "2.0", "info": {"title": "a", "version": "1"}}`,
+		"old version":   `{"openapi": "2.0", "info": {"title": "a", "version": "1"}}`,
+		"missing info":  `{"openapi": "3.0.0", "info": {"title": " "}}`,
+		"malformed":     "openapi: [",
+	}
+	for name, content := range cases {
+		_, err := ParseOpenAPI([]byte(content))
+		s.True(errors.Is(err, ErrInvalidSpec), name)
+	}
+}
+
+func (s *OpenAPITestSuite) Test_IsOpenAPI_DetectsDocuments() {
+	s.True(IsOpenAPI([]byte("openapi: 3.0.0\n")))
+	s.False(IsOpenAPI([]byte("apiVersion: backstage.io/v1alpha1\n")))
+	s.False(IsOpenAPI([]byte("not: [valid")))
+}
@@ -0,0 +1,213 @@
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Format string
+
+const (
+	FormatOpenAPI  Format = "openapi"
+	FormatAsyncAPI Format = "asyncapi"
+	FormatProtobuf Format = "protobuf"
+	FormatGraphQL  Format = "graphql"
+
+	ContentTypeJSON     = "application/json"
+	ContentTypeYAML     = "application/yaml"
+	ContentTypeProtobuf = "text/x-protobuf"
+	ContentTypeGraphQL  = "application/graphql"
+)
+
+var (
+	protobufHint = regexp.MustCompile(`(?m)^\s*(syntax\s*=|package\s+[\w.]+\s*;|message\s+\w+\s*\{|service\s+\w+\s*\{)`)
+	graphqlHint  = regexp.MustCompile(`(?m)^\s*((extend\s+)?(type|interface|input|enum|union)\s+\w+|schema\s*\{|scalar\s+\w+|directive\s+@)`)
+)
+
+type Document struct {
+	Format      Format
+	ContentType string
+	Version     string
+	Operations  []string
+}
+
+var ErrInvalidSpec = errors.New("invalid specification")
+
+func invalidf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidSpec, fmt.Sprintf(format, args...))
+}
+
+func Parse(format Format, content []byte) (*Document, error) {
+	if format == "" {
+		format = Detect(content)
+	}
+	switch format {
+	case FormatOpenAPI:
+		doc, err := ParseOpenAPI(content)
+		if err != nil {
+			return nil, err
+		}
+		return &Document{Format: format, ContentType: DetectContentType(content), Version: string(doc.Info.Version), Operations: doc.Operations()}, nil
+	case FormatAsyncAPI:
+		doc, err := ParseAsyncAPI(content)
+		if err != nil {
+			return nil, err
+		}
+		return &Document{Format: format, ContentType: DetectContentType(content), Version: string(doc.Info.Version), Operations: doc.Operations()}, nil
+	case FormatProtobuf:
+		ops, err := parseProtobuf(string(content))
+		if err != nil {
+			return nil, err
+		}
+		return &Document{Format: format, ContentType: ContentTypeProtobuf, Operations: ops}, nil
+	case FormatGraphQL:
+		ops, err := parseGraphQL(string(content))
+		if err != nil {
+			return nil, err
+		}
+		return &Document{Format: format, ContentType: ContentTypeGraphQL, Operations: ops}, nil
+	case "":
+		return nil, invalidf("unable to detect the document format, expected openapi, asyncapi, protobuf or graphql")
+	}
+	return nil, invalidf("unsupported format %q, expected openapi, asyncapi, protobuf or graphql", format)
+}
+
+func Detect(content []byte) Format {
+	if doc, err := decodeDocument(content); err == nil {
+		if _, ok := doc["openapi"]; ok {
+			return FormatOpenAPI
+		}
+		if _, ok := doc["asyncapi"]; ok {
+			return FormatAsyncAPI
+		}
+	}
+	text := string(content)
+	if protobufHint.MatchString(text) {
+		return FormatProtobuf
+	}
+	if graphqlHint.MatchString(text) {
+		return FormatGraphQL
+	}
+	return ""
+}
+
+func FormatForContentType(contentType string) Format {
+	mediaType := strings.TrimSpace(strings.Split(contentType, ";")[0])
+	switch strings.ToLower(mediaType) {
+	case ContentTypeProtobuf, "application/x-protobuf", "text/x-proto":
+		return FormatProtobuf
+	case ContentTypeGraphQL, "application/graphql-sdl", "text/x-graphql":
+		return FormatGraphQL
+	}
+	return ""
+}
+
+func DetectContentType(content []byte) string {
+	trimmed := bytes.TrimSpace(content)
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid(trimmed) {
+		return ContentTypeJSON
+	}
+	return ContentTypeYAML
+}
+
+func decodeDocument(content []byte) (map[string]interface{}, error) {
+	if len(bytes.TrimSpace(content)) == 0 {
+		return nil, invalidf("document is empty")
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(content, &node); err != nil {
+		return nil, invalidf("document is neither valid JSON nor YAML: %v", err)
+	}
+	doc, ok := nodeValue(&node).(map[string]interface{})
+	if !ok {
+		return nil, invalidf("document must be an object")
+	}
+	return doc, nil
+}
+
+func nodeValue(n *yaml.Node) interface{} {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return nil
+		}
+		return nodeValue(n.Content[0])
+	case yaml.AliasNode:
+		return nodeValue(n.Alias)
+	case yaml.MappingNode:
+		m := make(map[string]interface{}, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			m[n.Content[i].Value] = nodeValue(n.Content[i+1])
+		}
+		return m
+	case yaml.SequenceNode:
+		list := make([]interface{}, 0, len(n.Content))
+		for _, item := range n.Content {
+			list = append(list, nodeValue(item))
+		}
+		return list
+	}
+
+	switch n.ShortTag() {
+	case "!!null":
+		return nil
+	case "!!bool":
+		var b bool
+		if err := n.Decode(&b); err == nil {
+			return b
+		}
+	case "!!int", "!!float":
+		if json.Valid([]byte(n.Value)) {
+			return json.Number(n.Value)
+		}
+	}
+	return n.Value
+}
+
+func decodeInto(doc map[string]interface{}, target interface{}) error {
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return invalidf("%v", err)
+	}
+	if err := json.Unmarshal(b, target); err != nil {
+		return invalidf("%v", err)
+	}
+	return nil
+}
+
+type Text string
+
+func (t *Text) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err == nil {
+		*t = Text(str)
+		return nil
+	}
+	var num json.Number
+	if err := json.Unmarshal(b, &num); err != nil {
+		return err
+	}
+	*t = Text(num.String())
+	return nil
+}
+
+func uniqueSorted(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		out = append(out, v)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,124 @@
+package spec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const petstoreOpenAPI = `{
+  "openapi": "3.0.3",
+  "info": {"title": "Petstore", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "get": {"operationId": "listPets"},
+      "post": {"operationId": "createPet"},
+      "parameters": []
+    },
+    "/pets/{id}": {"delete": {}}
+  }
+}`
+
+const ordersAsyncAPI = `asyncapi: 2.6.0
+info:
+  title: Orders
+  version: 1.0.0
+channels:
+  orders/created:
+    subscribe:
+      operationId: onOrderCreated
+`
+
+const billingProto = `// Billing service
+syntax = "proto3";
+package billing.v1;
+
+/* Invoices { */
+service Billing {
+  rpc GetInvoice(GetInvoiceRequest) returns (Invoice);
+  rpc ListInvoices(ListInvoicesRequest) returns (stream Invoice) {
+    option (google.api.http) = { get: "/v1/invoices" };
+  }
+}
+
+message Invoice {
+  string id = 1;
+}
+`
+
+const usersGraphQL = `"""
+The root query { of the API
+"""
+schema {
+  query: RootQuery
+  mutation: RootMutation
+}
+
+# type Ignored { field: String }
+type RootQuery {
+  user(id: ID!, filter: String = "a:b"): User
+  users: [User!]!
+}
+
+type RootMutation {
+  createUser(input: CreateUserInput!): User @deprecated(reason: "use register")
+}
+
+type User {
+  id: ID!
+}
+`
+
+type SpecTestSuite struct {
+	suite.Suite
+}
+
+func TestSpecSuite(t *testing.T) {
+	suite.Run(t, new(SpecTestSuite))
+}
+
+func (s *SpecTestSuite) Test_Parse_DetectsFormatAndExtractsOperations() {
+	cases := []struct {
+		content     string
+		format      Format
+		contentType string
+		operations  []string
+	}{
+		{petstoreOpenAPI, FormatOpenAPI, ContentTypeJSON, []string{"DELETE /pets/{id}", "GET /pets", "POST /pets", "createPet", "listPets"}},
+		{ordersAsyncAPI, FormatAsyncAPI, ContentTypeYAML, []string{"onOrderCreated", "orders/created"}},
+		{billingProto, FormatProtobuf, ContentTypeProtobuf, []string{"Billing.GetInvoice", "Billing.ListInvoices", "GetInvoice", "ListInvoices"}},
+		{usersGraphQL, FormatGraphQL, ContentTypeGraphQL, []string{"Mutation.createUser", "Query.user", "Query.users", "createUser", "user", "users"}},
+	}
+	for _, tc := range cases {
+		s.Equal(tc.format, Detect([]byte(tc.content)))
+		doc, err := Parse("", []byte(tc.content))
+		s.Require().NoError(err, tc.format)
+		s.Equal(tc.format, doc.Format)
+		s.Equal(tc.contentType, doc.ContentType, tc.format)
+		s.Equal(tc.operations, doc.Operations, tc.format)
+	}
+}
+
+func (s *SpecTestSuite) Test_Parse_RejectsInvalidDocuments() {
+	cases := map[Format]string{
+		FormatAsyncAPI: "asyncapi: 1.2.0\ninfo:\n  title: a\n  version: 1\n",
+		FormatProtobuf: "syntax = \"proto3\";\nservice Billing {\n  rpc Get(Req) returns (Res);\n",
+		FormatGraphQL:  "type Query {\n  user: User\n}}\n",
+		"":             "just some text",
+		"wsdl":         "<definitions/>",
+	}
+	for format, content := range cases {
+		_, err := Parse(format, []byte(content))
+		s.True(errors.Is(err, ErrInvalidSpec), string(format))
+	}
+
+	_, err := Parse(FormatProtobuf, []byte("syntax = \"proto4\";\nmessage A {}\n"))
+	s.ErrorContains(err, "proto2")
+}
+
+func (s *SpecTestSuite) Test_FormatForContentType() {
+	s.Equal(FormatProtobuf, FormatForContentType("text/x-protobuf; charset=utf-8"))
+	s.Equal(FormatGraphQL, FormatForContentType("application/graphql"))
+	s.Equal(Format(""), FormatForContentType("application/json"))
+}
@@ -0,0 +1,72 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"catalog-service/internal/config"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "catalog-service"
+
+// Tracer returns the tracer of the global provider, so spans follow the
+// provider installed by Setup or Record.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Setup installs the W3C trace context propagator and the exporter selected by
+// TRACING_EXPORTER. Without an exporter spans are not recorded, but incoming
+// trace context is still propagated and logged. The returned function flushes
+// pending spans.
+func Setup(ctx context.Context) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	switch config.TracingExporter() {
+	case "", "none":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(config.TracingOTLPEndpoint())}
+		if config.TracingOTLPInsecure() {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err := otlptracehttp.New(ctx, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+		}
+		provider := sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithResource(newResource()),
+			sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(float64(config.TracingSamplePercent())/100))),
+		)
+		otel.SetTracerProvider(provider)
+		return provider.Shutdown, nil
+	default:
+		return nil, fmt.Errorf("unknown TRACING_EXPORTER %q", config.TracingExporter())
+	}
+}
+
+// Record installs the W3C propagator and a provider that keeps every finished
+// span in memory, for tests.
+func Record() *tracetest.InMemoryExporter {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	return exporter
+}
+
+func newResource() *resource.Resource {
+	return resource.NewSchemaless(
+		attribute.String("service.name", config.AppName()),
+		attribute.String("deployment.environment.name", config.AppEnv()),
+	)
+}
@@ -0,0 +1,48 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"catalog-service/internal/config"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+)
+
+type TracingSuite struct {
+	suite.Suite
+}
+
+func TestTracingSuite(t *testing.T) {
+	suite.Run(t, new(TracingSuite))
+}
+
+func (suite *TracingSuite) SetupTest() {
+	config.Load()
+}
+
+func (suite *TracingSuite) Test_Setup_InstallsTraceContextPropagator() {
+	shutdown, err := Setup(context.Background())
+
+	suite.Require().NoError(err)
+	suite.NoError(shutdown(context.Background()))
+	suite.Contains(otel.GetTextMapPropagator().Fields(), "traceparent")
+}
+
+func (suite *TracingSuite) Test_Setup_RejectsUnknownExporter() {
+	suite.T().Setenv("TRACING_EXPORTER", "zipkin")
+
+	_, err := Setup(context.Background())
+
+	suite.ErrorContains(err, "zipkin")
+}
+
+func (suite *TracingSuite) Test_Setup_CreatesOTLPExporter() {
+	suite.T().Setenv("TRACING_EXPORTER", "otlp")
+
+	shutdown, err := Setup(context.Background())
+
+	suite.Require().NoError(err)
+	suite.NoError(shutdown(context.Background()))
+}
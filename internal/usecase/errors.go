@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"catalog-service/internal/repository"
+	"catalog-service/internal/spec"
+)
+
+var (
+	ErrServiceNotFound  = errors.New("service not found")
+	ErrVersionNotFound  = errors.New("version not found")
+	ErrSpecNotFound     = errors.New("spec not found")
+	ErrBreakingChange   = errors.New("breaking changes require a major version bump")
+	ErrNotComparable    = errors.New("compatibility checks require openapi specs")
+	ErrWebhookNotFound  = errors.New("webhook not found")
+	ErrDeliveryNotFound = errors.New("delivery not found")
+	ErrUnavailable      = repository.ErrUnavailable
+)
+
+type BreakingChangeError struct {
+	Previous string
+	Version  string
+	Changes  []spec.BreakingChange
+}
+
+func (e *BreakingChangeError) Error() string {
+	return fmt.Sprintf("version %s has %d breaking changes against %s", e.Version, len(e.Changes), e.Previous)
+}
+
+func (e *BreakingChangeError) Unwrap() error {
+	return ErrBreakingChange
+}
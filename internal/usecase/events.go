@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"slices"
+
+	"catalog-service/internal/models"
+)
+
+func newEvent(svc *models.Service, eventType models.EventType, changed, versions []string) *models.ServiceEvent {
+	if changed == nil {
+		changed = []string{}
+	}
+	return &models.ServiceEvent{
+		Type:          eventType,
+		ServiceID:     svc.ID,
+		ChangedFields: changed,
+		Versions:      versions,
+		Labels:        svc.Labels,
+	}
+}
+
+// versionEvents returns events for the versions of svc that are not in
+// previous and the ones deprecated since.
+func versionEvents(svc *models.Service, previous []models.Version) []*models.ServiceEvent {
+	known := make(map[string]bool, len(previous))
+	for _, v := range previous {
+		known[v.VersionNumber] = v.Deprecated
+	}
+	var published, deprecated []string
+	for _, v := range svc.Versions {
+		wasDeprecated, existed := known[v.VersionNumber]
+		if !existed {
+			published = append(published, v.VersionNumber)
+		}
+		if v.Deprecated && !wasDeprecated {
+			deprecated = append(deprecated, v.VersionNumber)
+		}
+	}
+	var events []*models.ServiceEvent
+	if len(published) > 0 {
+		events = append(events, newEvent(svc, models.EventVersionPublished, []string{"versions"}, published))
+	}
+	if len(deprecated) > 0 {
+		events = append(events, newEvent(svc, models.EventVersionDeprecated, []string{"versions"}, deprecated))
+	}
+	return events
+}
+
+// snapshot copies svc so the fields changed afterwards can be found with
+// changedFields.
+func snapshot(svc *models.Service) *models.Service {
+	previous := *svc
+	previous.Versions = slices.Clone(svc.Versions)
+	previous.Operations = slices.Clone(svc.Operations)
+	previous.Labels = slices.Clone(svc.Labels)
+	return &previous
+}
+
+func changedFields(svc, previous *models.Service) []string {
+	var changed []string
+	if svc.Description != previous.Description {
+		changed = append(changed, "description")
+	}
+	if !slices.Equal(svc.Versions, previous.Versions) {
+		changed = append(changed, "versions")
+	}
+	if !slices.Equal(svc.Operations, previous.Operations) {
+		changed = append(changed, "operations")
+	}
+	if !slices.Equal(svc.Labels, previous.Labels) {
+		changed = append(changed, "labels")
+	}
+	return changed
+}
+
+func createdFields(svc *models.Service) []string {
+	fields := []string{"name"}
+	if svc.Description != "" {
+		fields = append(fields, "description")
+	}
+	if len(svc.Versions) > 0 {
+		fields = append(fields, "versions")
+	}
+	if len(svc.Labels) > 0 {
+		fields = append(fields, "labels")
+	}
+	return fields
+}
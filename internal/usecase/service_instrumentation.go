@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"catalog-service/internal/dto"
+	"catalog-service/internal/metrics"
+	"catalog-service/internal/models"
+	"catalog-service/internal/tracing"
+
+	"go.opentelemetry.io/otel/codes"
+)
+
+// instrumentedServiceUsecase traces every call in its own span and records its
+// latency and outcome.
+type instrumentedServiceUsecase struct {
+	next ServiceUsecase
+}
+
+// instrument starts the span of a call. The returned function ends it with the
+// error of the call; a missing service is not a span error.
+func instrument(ctx context.Context, operation string) (context.Context, func(error)) {
+	start := time.Now()
+	ctx, span := tracing.Tracer().Start(ctx, "ServiceUsecase."+operation)
+	return ctx, func(err error) {
+		metrics.ObserveUsecase("service."+operation, start, err, ErrServiceNotFound)
+		if err != nil && !errors.Is(err, ErrServiceNotFound) {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+func (u *instrumentedServiceUsecase) Search(ctx context.Context, query string, page, limit int) (services []*dto.ServiceDTO, total int, err error) {
+	ctx, end := instrument(ctx, "Search")
+	defer func() { end(err) }()
+	return u.next.Search(ctx, query, page, limit)
+}
+
+func (u *instrumentedServiceUsecase) SearchWithFilter(ctx context.Context, query string, filter models.ServiceFilter, page, limit int) (services []*dto.ServiceDTO, total int, err error) {
+	ctx, end := instrument(ctx, "SearchWithFilter")
+	defer func() { end(err) }()
+	return u.next.SearchWithFilter(ctx, query, filter, page, limit)
+}
+
+func (u *instrumentedServiceUsecase) FindByID(ctx context.Context, id string) (service *dto.ServiceDTO, err error) {
+	ctx, end := instrument(ctx, "FindByID")
+	defer func() { end(err) }()
+	return u.next.FindByID(ctx, id)
+}
+
+func (u *instrumentedServiceUsecase) Create(ctx context.Context, req *dto.ServiceDTO) (service *dto.ServiceDTO, err error) {
+	ctx, end := instrument(ctx, "Create")
+	defer func() { end(err) }()
+	return u.next.Create(ctx, req)
+}
+
+func (u *instrumentedServiceUsecase) Delete(ctx context.Context, id string) (err error) {
+	ctx, end := instrument(ctx, "Delete")
+	defer func() { end(err) }()
+	return u.next.Delete(ctx, id)
+}
+
+func (u *instrumentedServiceUsecase) Update(ctx context.Context, id string, req *dto.ServiceDTO) (service *dto.ServiceDTO, err error) {
+	ctx, end := instrument(ctx, "Update")
+	defer func() { end(err) }()
+	return u.next.Update(ctx, id, req)
+}
+
+func (u *instrumentedServiceUsecase) Export(ctx context.Context, query string, filter models.ServiceFilter, fn func(*models.Service) error) (err error) {
+	ctx, end := instrument(ctx, "Export")
+	defer func() { end(err) }()
+	return u.next.Export(ctx, query, filter, fn)
+}
@@ -1,27 +1,40 @@
 package usecase
 
 import (
+	"catalog-service/internal/config"
 	"catalog-service/internal/constants"
 	"catalog-service/internal/dto"
 	"catalog-service/internal/models"
 	"catalog-service/internal/repository"
 	"context"
+	"errors"
+	"fmt"
+	"slices"
 )
 
 type ServiceUsecase interface {
 	Search(ctx context.Context, query string, page, limit int) ([]*dto.ServiceDTO, int, error)
+	SearchWithFilter(ctx context.Context, query string, filter models.ServiceFilter, page, limit int) ([]*dto.ServiceDTO, int, error)
 	FindByID(ctx context.Context, id string) (*dto.ServiceDTO, error)
 	Create(ctx context.Context, req *dto.ServiceDTO) (*dto.ServiceDTO, error)
 	Delete(ctx context.Context, id string) error
 	Update(ctx context.Context, id string, req *dto.ServiceDTO) (*dto.ServiceDTO, error)
+	Export(ctx context.Context, query string, filter models.ServiceFilter, fn func(*models.Service) error) error
 }
 
 type serviceUsecase struct {
-	repo repository.ServiceRepository
+	repo  repository.ServiceRepository
+	specs *specUsecase
 }
 
-func NewServiceUsecase(repo repository.ServiceRepository) ServiceUsecase {
-	return &serviceUsecase{repo: repo}
+// NewServiceUsecase records a change event for each write, which repo stores
+// in the outbox together with the write. OpenAPI documents sent with new
+// versions are stored in specs. Calls are recorded in the usecase metrics.
+func NewServiceUsecase(repo repository.ServiceRepository, specs repository.SpecRepository) ServiceUsecase {
+	return &instrumentedServiceUsecase{next: &serviceUsecase{
+		repo:  repo,
+		specs: &specUsecase{services: repo, specs: specs, rejectBreaking: config.SpecRejectBreakingChanges()},
+	}}
 }
 
 func (u *serviceUsecase) Search(ctx context.Context, query string, page, limit int) ([]*dto.ServiceDTO, int, error) {
@@ -29,6 +42,18 @@ func (u *serviceUsecase) Search(ctx context.Context, query string, page, limit i
 	if err != nil {
 		return nil, 0, err
 	}
+	return toServiceDTOs(services), total, nil
+}
+
+func (u *serviceUsecase) SearchWithFilter(ctx context.Context, query string, filter models.ServiceFilter, page, limit int) ([]*dto.ServiceDTO, int, error) {
+	services, total, err := u.repo.SearchWithFilter(ctx, query, filter, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return toServiceDTOs(services), total, nil
+}
+
+func toServiceDTOs(services []*models.Service) []*dto.ServiceDTO {
 	dtos := make([]*dto.ServiceDTO, 0, len(services))
 	for _, svc := range services {
 		dtos = append(dtos, &dto.ServiceDTO{
@@ -36,36 +61,51 @@ func (u *serviceUsecase) Search(ctx context.Context, query string, page, limit i
 			Name:        svc.Name,
 			Description: svc.Description,
 			Versions:    svc.Versions,
+			Operations:  svc.Operations,
+			Labels:      svc.Labels,
 			CreatedAt:   svc.CreatedAt.Format(constants.Iso8601Format),
 			UpdatedAt:   svc.UpdatedAt.Format(constants.Iso8601Format),
 		})
 	}
-	return dtos, total, nil
+	return dtos
 }
 
 func (u *serviceUsecase) FindByID(ctx context.Context, id string) (*dto.ServiceDTO, error) {
 	svc, err := u.repo.FindByID(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, notFound(err, id)
 	}
 	return &dto.ServiceDTO{
 		ID:          svc.ID,
 		Name:        svc.Name,
 		Description: svc.Description,
 		Versions:    svc.Versions,
+		Operations:  svc.Operations,
+		Labels:      svc.Labels,
 		CreatedAt:   svc.CreatedAt.Format(constants.Iso8601Format),
 		UpdatedAt:   svc.UpdatedAt.Format(constants.Iso8601Format),
 	}, nil
 }
 
 func (u *serviceUsecase) Create(ctx context.Context, req *dto.ServiceDTO) (*dto.ServiceDTO, error) {
+	records, err := openAPIRecords(req.Specs)
+	if err != nil {
+		return nil, err
+	}
 	svc := &models.Service{
 		ID:          req.ID,
 		Name:        req.Name,
 		Description: req.Description,
 		Versions:    req.Versions,
+		Labels:      req.Labels,
+	}
+	if len(records) > 0 {
+		err = u.specs.attach(ctx, svc, records, nil)
+	} else {
+		events := append([]*models.ServiceEvent{newEvent(svc, models.EventServiceCreated, createdFields(svc), nil)}, versionEvents(svc, nil)...)
+		err = u.repo.Create(ctx, svc, events...)
 	}
-	if err := u.repo.Create(ctx, svc); err != nil {
+	if err != nil {
 		return nil, err
 	}
 	return &dto.ServiceDTO{
@@ -73,27 +113,53 @@ func (u *serviceUsecase) Create(ctx context.Context, req *dto.ServiceDTO) (*dto.
 		Name:        svc.Name,
 		Description: svc.Description,
 		Versions:    svc.Versions,
+		Operations:  svc.Operations,
+		Labels:      svc.Labels,
 		CreatedAt:   svc.CreatedAt.Format(constants.Iso8601Format),
 		UpdatedAt:   svc.UpdatedAt.Format(constants.Iso8601Format),
 	}, nil
 }
 
 func (u *serviceUsecase) Delete(ctx context.Context, id string) error {
-	return u.repo.Delete(ctx, id)
+	if err := u.repo.Delete(ctx, id, newEvent(&models.Service{ID: id}, models.EventServiceDeleted, nil, nil)); err != nil {
+		return notFound(err, id)
+	}
+	return nil
 }
 
 func (u *serviceUsecase) Update(ctx context.Context, id string, req *dto.ServiceDTO) (*dto.ServiceDTO, error) {
-	svc, err := u.repo.FindByID(ctx, id)
+	records, err := openAPIRecords(req.Specs)
 	if err != nil {
 		return nil, err
 	}
-	if req.Description != "" {
+	svc, err := u.repo.FindByID(repository.WithoutCache(ctx), id)
+	if err != nil {
+		return nil, notFound(err, id)
+	}
+	before := snapshot(svc)
+	var changed []string
+	if req.Description != "" && req.Description != svc.Description {
 		svc.Description = req.Description
+		changed = append(changed, "description")
+	}
+	previous := append([]models.Version(nil), svc.Versions...)
+	if svc.MergeVersions(req.Versions) {
+		changed = append(changed, "versions")
+	}
+	if req.Labels != nil && !slices.Equal(req.Labels, svc.Labels) {
+		svc.Labels = req.Labels
+		changed = append(changed, "labels")
 	}
-	if len(req.Versions) > 0 {
-		svc.Versions = append(svc.Versions, req.Versions...)
+	if len(records) > 0 {
+		err = u.specs.attach(ctx, svc, records, before)
+	} else {
+		var events []*models.ServiceEvent
+		if len(changed) > 0 {
+			events = append([]*models.ServiceEvent{newEvent(svc, models.EventServiceUpdated, changed, nil)}, versionEvents(svc, previous)...)
+		}
+		err = u.repo.Update(ctx, svc, events...)
 	}
-	if err := u.repo.Update(ctx, svc); err != nil {
+	if err != nil {
 		return nil, err
 	}
 	return &dto.ServiceDTO{
@@ -101,7 +167,20 @@ func (u *serviceUsecase) Update(ctx context.Context, id string, req *dto.Service
 		Name:        svc.Name,
 		Description: svc.Description,
 		Versions:    svc.Versions,
+		Operations:  svc.Operations,
+		Labels:      svc.Labels,
 		CreatedAt:   svc.CreatedAt.Format(constants.Iso8601Format),
 		UpdatedAt:   svc.UpdatedAt.Format(constants.Iso8601Format),
 	}, nil
 }
+
+func (u *serviceUsecase) Export(ctx context.Context, query string, filter models.ServiceFilter, fn func(*models.Service) error) error {
+	return u.repo.Export(ctx, query, filter, fn)
+}
+
+func notFound(err error, id string) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("%w: %s: %v", ErrServiceNotFound, id, err)
+	}
+	return err
+}
@@ -2,17 +2,24 @@ package usecase
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
+	"catalog-service/internal/config"
 	"catalog-service/internal/constants"
 	"catalog-service/internal/dto"
+	"catalog-service/internal/logger"
 	"catalog-service/internal/models"
+	"catalog-service/internal/repository"
+	"catalog-service/internal/tracing"
 	mockrepo "catalog-service/test/mocks/repository"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type ServiceUsecaseSuite struct {
@@ -23,6 +30,11 @@ func TestServiceUsecaseSuite(t *testing.T) {
 	suite.Run(t, new(ServiceUsecaseSuite))
 }
 
+func (suite *ServiceUsecaseSuite) SetupTest() {
+	config.Load()
+	logger.Setup("INFO", "json")
+}
+
 func (suite *ServiceUsecaseSuite) Test_Search_Success() {
 	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
 	mockRepo := new(mockrepo.ServiceRepository)
@@ -39,7 +51,7 @@ func (suite *ServiceUsecaseSuite) Test_Search_Success() {
 			},
 		}, 1, nil)
 
-	uc := NewServiceUsecase(mockRepo)
+	uc := NewServiceUsecase(mockRepo, nil)
 	dtos, total, err := uc.Search(context.Background(), "", 1, 10)
 
 	suite.Require().NoError(err)
@@ -68,7 +80,7 @@ func (suite *ServiceUsecaseSuite) Test_Search_Error() {
 		On("Search", mock.Anything, "", 1, 10).
 		Return(nil, 0, assert.AnError)
 
-	uc := NewServiceUsecase(mockRepo)
+	uc := NewServiceUsecase(mockRepo, nil)
 	dtos, total, err := uc.Search(context.Background(), "", 1, 10)
 	suite.Error(err)
 	suite.Nil(dtos)
@@ -76,6 +88,158 @@ func (suite *ServiceUsecaseSuite) Test_Search_Error() {
 	mockRepo.AssertExpectations(suite.T())
 }
 
+func (suite *ServiceUsecaseSuite) Test_NotFoundErrorsAreTranslated() {
+	mockRepo := new(mockrepo.ServiceRepository)
+	notFound := fmt.Errorf("%w: services/missing", repository.ErrNotFound)
+	mockRepo.On("FindByID", mock.Anything, "missing").Return(nil, notFound)
+	mockRepo.On("Delete", mock.Anything, "missing", mock.Anything).Return(notFound)
+	mockRepo.On("FindByID", mock.Anything, "broken").Return(nil, assert.AnError)
+
+	uc := NewServiceUsecase(mockRepo, nil)
+
+	_, err := uc.FindByID(context.Background(), "missing")
+	suite.ErrorIs(err, ErrServiceNotFound)
+	_, err = uc.Update(context.Background(), "missing", &dto.ServiceDTO{Description: "new"})
+	suite.ErrorIs(err, ErrServiceNotFound)
+	suite.ErrorIs(uc.Delete(context.Background(), "missing"), ErrServiceNotFound)
+
+	_, err = uc.FindByID(context.Background(), "broken")
+	suite.ErrorIs(err, assert.AnError)
+	suite.NotErrorIs(err, ErrServiceNotFound)
+}
+
+func (suite *ServiceUsecaseSuite) Test_CallsAreTracedInChildSpans() {
+	spans := tracing.Record()
+	mockRepo := new(mockrepo.ServiceRepository)
+	mockRepo.On("FindByID", mock.Anything, "missing").Return(nil, fmt.Errorf("%w: services/missing", repository.ErrNotFound))
+	mockRepo.On("FindByID", mock.Anything, "broken").Return(nil, assert.AnError)
+	uc := NewServiceUsecase(mockRepo, nil)
+	ctx, parent := tracing.Tracer().Start(context.Background(), "request")
+
+	_, _ = uc.FindByID(ctx, "missing")
+	_, _ = uc.FindByID(ctx, "broken")
+	parent.End()
+
+	ended := spans.GetSpans()
+	suite.Require().Len(ended, 3)
+	for _, span := range ended[:2] {
+		suite.Equal("ServiceUsecase.FindByID", span.Name)
+		suite.Equal(parent.SpanContext().SpanID(), span.Parent.SpanID())
+	}
+	suite.Equal(codes.Unset, ended[0].Status.Code, "a missing service is not an error")
+	suite.Equal(codes.Error, ended[1].Status.Code)
+	mockRepo.AssertCalled(suite.T(), "FindByID", mock.MatchedBy(func(ctx context.Context) bool {
+		return trace.SpanContextFromContext(ctx).SpanID() != parent.SpanContext().SpanID()
+	}), "missing")
+}
+
+func (suite *ServiceUsecaseSuite) Test_WritesRecordEvents() {
+	mockRepo := new(mockrepo.ServiceRepository)
+	var recorded [][]*models.ServiceEvent
+	record := func(args mock.Arguments) {
+		var events []*models.ServiceEvent
+		for _, arg := range args[2:] {
+			events = append(events, arg.(*models.ServiceEvent))
+		}
+		recorded = append(recorded, events)
+	}
+	mockRepo.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(record).Return(nil)
+	mockRepo.On("FindByID", mock.Anything, "svc-1").Return(func(context.Context, string) *models.Service {
+		return &models.Service{ID: "svc-1", Description: "same", Labels: []string{"payments"}, Versions: []models.Version{{VersionNumber: "1.0"}}}
+	}, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(record).Return(nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything, mock.Anything).Run(record).Return(nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Run(record).Return(nil)
+	mockRepo.On("Delete", mock.Anything, "svc-1", mock.Anything).Run(record).Return(nil)
+	event := func(eventType models.EventType, serviceID string, fields, versions, labels []string) *models.ServiceEvent {
+		return &models.ServiceEvent{Type: eventType, ServiceID: serviceID, ChangedFields: fields, Versions: versions, Labels: labels}
+	}
+
+	uc := NewServiceUsecase(mockRepo, nil)
+	_, err := uc.Create(context.Background(), &dto.ServiceDTO{Name: "billing", Labels: []string{"payments"}, Versions: []models.Version{{VersionNumber: "1.0"}}})
+	suite.Require().NoError(err)
+	_, err = uc.Update(context.Background(), "svc-1", &dto.ServiceDTO{
+		Description: "same",
+		Versions:    []models.Version{{VersionNumber: "1.0", Deprecated: true}, {VersionNumber: "2.0"}},
+	})
+	suite.Require().NoError(err)
+	_, err = uc.Update(context.Background(), "svc-1", &dto.ServiceDTO{Labels: []string{"billing"}})
+	suite.Require().NoError(err)
+	_, err = uc.Update(context.Background(), "svc-1", &dto.ServiceDTO{Description: "same", Versions: []models.Version{{VersionNumber: "1.0"}}})
+	suite.Require().NoError(err)
+	suite.Require().NoError(uc.Delete(context.Background(), "svc-1"))
+
+	// The repository fills in the id of a created service.
+	suite.Equal([][]*models.ServiceEvent{
+		{
+			event(models.EventServiceCreated, "", []string{"name", "versions", "labels"}, nil, []string{"payments"}),
+			event(models.EventVersionPublished, "", []string{"versions"}, []string{"1.0"}, []string{"payments"}),
+		},
+		{
+			event(models.EventServiceUpdated, "svc-1", []string{"versions"}, nil, []string{"payments"}),
+			event(models.EventVersionPublished, "svc-1", []string{"versions"}, []string{"2.0"}, []string{"payments"}),
+			event(models.EventVersionDeprecated, "svc-1", []string{"versions"}, []string{"1.0"}, []string{"payments"}),
+		},
+		{event(models.EventServiceUpdated, "svc-1", []string{"labels"}, nil, []string{"billing"})},
+		nil,
+		{event(models.EventServiceDeleted, "svc-1", []string{}, nil, nil)},
+	}, recorded)
+}
+
+func (suite *ServiceUsecaseSuite) Test_CreateStoresSpecsSentWithVersions() {
+	mockRepo := new(mockrepo.ServiceRepository)
+	specs := new(mockrepo.SpecRepository)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(svc *models.Service) bool {
+		return assert.ObjectsAreEqual([]string{"DELETE /invoices/{id}", "GET /invoices"}, svc.Operations)
+	}), mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.Service).ID = "svc-1"
+	}).Return(nil)
+	specs.On("Save", mock.Anything, mock.MatchedBy(func(s *models.Spec) bool {
+		return s.ServiceID == "svc-1" && s.Version == "1.0.0" && s.Format == "openapi" && s.Content == billingOpenAPIv1
+	})).Return(nil)
+
+	uc := NewServiceUsecase(mockRepo, specs)
+	got, err := uc.Create(context.Background(), &dto.ServiceDTO{
+		Name:     "Billing",
+		Versions: []models.Version{{VersionNumber: "1.0.0"}},
+		Specs:    map[string]string{"1.0.0": billingOpenAPIv1},
+	})
+
+	suite.Require().NoError(err)
+	suite.Nil(got.Specs)
+	mockRepo.AssertExpectations(suite.T())
+	specs.AssertExpectations(suite.T())
+}
+
+func (suite *ServiceUsecaseSuite) Test_RejectsBreakingMinorVersionBeforeStoringIt() {
+	suite.T().Setenv("SPEC_REJECT_BREAKING_CHANGES", "true")
+	config.Load()
+	mockRepo := new(mockrepo.ServiceRepository)
+	specs := new(mockrepo.SpecRepository)
+	mockRepo.On("FindByID", mock.Anything, "svc-1").Return(&models.Service{ID: "svc-1", Name: "Billing", Versions: []models.Version{{VersionNumber: "1.0.0"}}}, nil)
+	specs.On("FindByService", mock.Anything, "svc-1").Return([]*models.Spec{{Version: "1.0.0", Format: "openapi"}}, nil)
+	specs.On("FindByVersion", mock.Anything, "svc-1", "1.0.0").Return(&models.Spec{Version: "1.0.0", Format: "openapi", Content: billingOpenAPIv1}, nil)
+
+	uc := NewServiceUsecase(mockRepo, specs)
+	_, err := uc.Update(context.Background(), "svc-1", &dto.ServiceDTO{
+		Versions: []models.Version{{VersionNumber: "1.1.0"}},
+		Specs:    map[string]string{"1.1.0": billingOpenAPIv11},
+	})
+
+	suite.ErrorIs(err, ErrBreakingChange)
+	mockRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything)
+	specs.AssertNotCalled(suite.T(), "Save", mock.Anything, mock.Anything)
+
+	_, err = uc.Create(context.Background(), &dto.ServiceDTO{
+		Name:     "Billing",
+		Versions: []models.Version{{VersionNumber: "1.0.0"}, {VersionNumber: "1.1.0"}},
+		Specs:    map[string]string{"1.0.0": billingOpenAPIv1, "1.1.0": billingOpenAPIv11},
+	})
+
+	suite.ErrorIs(err, ErrBreakingChange, "versions in one request are compared with each other")
+	mockRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
 func (suite *ServiceUsecaseSuite) assertServiceDTOEqual(got *dto.ServiceDTO, want struct {
 	ID, Name, Description, VersionNumber, Details, CreatedAt, UpdatedAt string
 }) {
@@ -0,0 +1,328 @@
+package usecase
+
+import (
+	"catalog-service/internal/config"
+	"catalog-service/internal/constants"
+	"catalog-service/internal/dto"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	"catalog-service/internal/repository"
+	"catalog-service/internal/spec"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+)
+
+type SpecUsecase interface {
+	ImportOpenAPI(ctx context.Context, content []byte, note string) (*dto.ServiceDTO, bool, error)
+	PutSpec(ctx context.Context, serviceID, version string, format spec.Format, content []byte) (*dto.SpecDTO, error)
+	GetSpec(ctx context.Context, serviceID, version string) (*models.Spec, error)
+	Compatibility(ctx context.Context, serviceID, version string) (*dto.CompatibilityDTO, error)
+}
+
+type specUsecase struct {
+	services       repository.ServiceRepository
+	specs          repository.SpecRepository
+	rejectBreaking bool
+}
+
+func NewSpecUsecase(services repository.ServiceRepository, specs repository.SpecRepository) SpecUsecase {
+	return &specUsecase{services: services, specs: specs, rejectBreaking: config.SpecRejectBreakingChanges()}
+}
+
+func (u *specUsecase) ImportOpenAPI(ctx context.Context, content []byte, note string) (*dto.ServiceDTO, bool, error) {
+	log := logger.NewContextLogger(ctx, "SpecUsecase/ImportOpenAPI")
+	doc, err := spec.ParseOpenAPI(content)
+	if err != nil {
+		return nil, false, err
+	}
+	name, version := string(doc.Info.Title), string(doc.Info.Version)
+
+	svc, err := u.services.FindByName(ctx, name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	created := svc == nil
+	var previous *models.Service
+	imported := models.Version{VersionNumber: version, Details: note}
+	if created {
+		svc = &models.Service{
+			Name:        name,
+			Description: string(doc.Info.Description),
+			Versions:    []models.Version{imported},
+		}
+	} else {
+		previous = snapshot(svc)
+		if doc.Info.Description != "" {
+			svc.Description = string(doc.Info.Description)
+		}
+		svc.MergeVersions([]models.Version{imported})
+	}
+
+	record := &models.Spec{
+		Version:     version,
+		Format:      string(spec.FormatOpenAPI),
+		ContentType: spec.DetectContentType(content),
+		Content:     string(content),
+		Operations:  doc.Operations(),
+	}
+	if err := u.attach(ctx, svc, []*models.Spec{record}, previous); err != nil {
+		return nil, false, err
+	}
+
+	log.Infof("imported openapi spec for service %s version %s (created=%t)", svc.ID, version, created)
+	return &dto.ServiceDTO{
+		ID:          svc.ID,
+		Name:        svc.Name,
+		Description: svc.Description,
+		Versions:    svc.Versions,
+		Operations:  svc.Operations,
+		CreatedAt:   svc.CreatedAt.Format(constants.Iso8601Format),
+		UpdatedAt:   svc.UpdatedAt.Format(constants.Iso8601Format),
+	}, created, nil
+}
+
+func (u *specUsecase) PutSpec(ctx context.Context, serviceID, version string, format spec.Format, content []byte) (*dto.SpecDTO, error) {
+	log := logger.NewContextLogger(ctx, "SpecUsecase/PutSpec")
+	svc, err := u.services.FindByID(repository.WithoutCache(ctx), serviceID)
+	if err != nil {
+		return nil, notFound(err, serviceID)
+	}
+	if !hasVersion(svc, version) {
+		return nil, fmt.Errorf("%w: %s", ErrVersionNotFound, version)
+	}
+
+	doc, err := spec.Parse(format, content)
+	if err != nil {
+		return nil, err
+	}
+	if doc.Version != "" && doc.Version != version {
+		log.Warnf("%s document declares version %s but is attached to version %s", doc.Format, doc.Version, version)
+	}
+
+	record := &models.Spec{
+		Version:     version,
+		Format:      string(doc.Format),
+		ContentType: doc.ContentType,
+		Content:     string(content),
+		Operations:  doc.Operations,
+	}
+	if err := u.attach(ctx, svc, []*models.Spec{record}, snapshot(svc)); err != nil {
+		return nil, err
+	}
+
+	log.Infof("stored %s spec for service %s version %s with %d operations", record.Format, svc.ID, version, len(record.Operations))
+	return toSpecDTO(record), nil
+}
+
+func (u *specUsecase) GetSpec(ctx context.Context, serviceID, version string) (*models.Spec, error) {
+	record, err := u.specs.FindByVersion(ctx, serviceID, version)
+	if err != nil {
+		return nil, specNotFound(err, serviceID, version)
+	}
+	return record, nil
+}
+
+func (u *specUsecase) Compatibility(ctx context.Context, serviceID, version string) (*dto.CompatibilityDTO, error) {
+	log := logger.NewContextLogger(ctx, "SpecUsecase/Compatibility")
+	svc, err := u.services.FindByID(ctx, serviceID)
+	if err != nil {
+		return nil, notFound(err, serviceID)
+	}
+	if !hasVersion(svc, version) {
+		return nil, fmt.Errorf("%w: %s", ErrVersionNotFound, version)
+	}
+	record, err := u.specs.FindByVersion(ctx, serviceID, version)
+	if err != nil {
+		return nil, specNotFound(err, serviceID, version)
+	}
+	if record.Format != string(spec.FormatOpenAPI) {
+		return nil, fmt.Errorf("%w: version %s has a %s spec", ErrNotComparable, version, record.Format)
+	}
+	existing, err := u.specs.FindByService(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.CompatibilityDTO{ServiceID: serviceID, Version: version, Compatible: true, BreakingChanges: []spec.BreakingChange{}}
+	previous, changes, err := u.compare(ctx, serviceID, record, existing)
+	if err != nil {
+		return nil, err
+	}
+	if previous != nil {
+		result.PreviousVersion = previous.Version
+		result.BreakingChanges = append(result.BreakingChanges, changes...)
+		result.Compatible = len(changes) == 0
+	}
+	log.Infof("service %s version %s has %d breaking changes against %q", serviceID, version, len(changes), result.PreviousVersion)
+	return result, nil
+}
+
+// compare diffs record against the closest lower version that has an openapi
+// spec. It returns a nil previous spec when there is nothing to compare with.
+// Specs without content are fetched.
+func (u *specUsecase) compare(ctx context.Context, serviceID string, record *models.Spec, existing []*models.Spec) (*models.Spec, []spec.BreakingChange, error) {
+	var previous *models.Spec
+	for _, other := range existing {
+		if other.Format != string(spec.FormatOpenAPI) || spec.CompareVersions(other.Version, record.Version) >= 0 {
+			continue
+		}
+		if previous == nil || spec.CompareVersions(other.Version, previous.Version) > 0 {
+			previous = other
+		}
+	}
+	if previous == nil {
+		return nil, nil, nil
+	}
+
+	if previous.Content == "" {
+		var err error
+		if previous, err = u.specs.FindByVersion(ctx, serviceID, previous.Version); err != nil {
+			return nil, nil, err
+		}
+	}
+	changes, err := spec.CompareOpenAPI([]byte(previous.Content), []byte(record.Content))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to compare %s with %s: %w", record.Version, previous.Version, err)
+	}
+	return previous, changes, nil
+}
+
+func (u *specUsecase) checkBreaking(ctx context.Context, serviceID string, record *models.Spec, existing []*models.Spec) error {
+	if !u.rejectBreaking || record.Format != string(spec.FormatOpenAPI) {
+		return nil
+	}
+	previous, changes, err := u.compare(ctx, serviceID, record, existing)
+	if err != nil || previous == nil || len(changes) == 0 {
+		return err
+	}
+	prevMajor, ok := spec.MajorVersion(previous.Version)
+	currMajor, ok2 := spec.MajorVersion(record.Version)
+	if !ok || !ok2 || currMajor > prevMajor {
+		return nil
+	}
+	return &BreakingChangeError{Previous: previous.Version, Version: record.Version, Changes: changes}
+}
+
+// attach stores records and svc with the events for the changes made to
+// previous, which is nil when svc is new. Nothing is stored when a record has
+// breaking changes against the other specs of the service.
+func (u *specUsecase) attach(ctx context.Context, svc *models.Service, records []*models.Spec, previous *models.Service) error {
+	create := previous == nil
+	replaced := make(map[string]bool, len(records))
+	for _, record := range records {
+		replaced[record.Version] = true
+	}
+	var existing []*models.Spec
+	if !create {
+		var err error
+		if existing, err = u.specs.FindByService(ctx, svc.ID); err != nil {
+			return err
+		}
+	}
+	candidates := slices.Clone(records)
+	for _, other := range existing {
+		if !replaced[other.Version] {
+			candidates = append(candidates, other)
+		}
+	}
+	for _, record := range records {
+		if err := u.checkBreaking(ctx, svc.ID, record, candidates); err != nil {
+			return err
+		}
+	}
+	var operations []string
+	for _, record := range candidates {
+		operations = append(operations, record.Operations...)
+	}
+	svc.Operations = mergeOperations(operations)
+
+	var err error
+	if create {
+		events := append([]*models.ServiceEvent{newEvent(svc, models.EventServiceCreated, createdFields(svc), nil)}, versionEvents(svc, nil)...)
+		err = u.services.Create(ctx, svc, events...)
+	} else {
+		var events []*models.ServiceEvent
+		if changed := changedFields(svc, previous); len(changed) > 0 {
+			events = append([]*models.ServiceEvent{newEvent(svc, models.EventServiceUpdated, changed, nil)}, versionEvents(svc, previous.Versions)...)
+		}
+		err = u.services.Update(ctx, svc, events...)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		record.ServiceID = svc.ID
+		if err := u.specs.Save(ctx, record); err != nil {
+			return fmt.Errorf("failed to store spec for %s %s: %w", svc.Name, record.Version, err)
+		}
+	}
+	return nil
+}
+
+// openAPIRecords parses the OpenAPI documents sent for new versions, keyed by
+// version number.
+func openAPIRecords(docs map[string]string) ([]*models.Spec, error) {
+	records := make([]*models.Spec, 0, len(docs))
+	for _, version := range slices.Sorted(maps.Keys(docs)) {
+		content := []byte(docs[version])
+		doc, err := spec.ParseOpenAPI(content)
+		if err != nil {
+			return nil, fmt.Errorf("version %s: %w", version, err)
+		}
+		records = append(records, &models.Spec{
+			Version:     version,
+			Format:      string(spec.FormatOpenAPI),
+			ContentType: spec.DetectContentType(content),
+			Content:     string(content),
+			Operations:  doc.Operations(),
+		})
+	}
+	return records, nil
+}
+
+func specNotFound(err error, serviceID, version string) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("%w: %s %s: %v", ErrSpecNotFound, serviceID, version, err)
+	}
+	return err
+}
+
+func hasVersion(svc *models.Service, version string) bool {
+	for _, v := range svc.Versions {
+		if v.VersionNumber == version {
+			return true
+		}
+	}
+	return false
+}
+
+func mergeOperations(operations []string) []string {
+	seen := make(map[string]bool, len(operations))
+	merged := make([]string, 0, len(operations))
+	for _, op := range operations {
+		if !seen[op] {
+			seen[op] = true
+			merged = append(merged, op)
+		}
+	}
+	sort.Strings(merged)
+	return merged
+}
+
+func toSpecDTO(record *models.Spec) *dto.SpecDTO {
+	return &dto.SpecDTO{
+		ServiceID:   record.ServiceID,
+		Version:     record.Version,
+		Format:      record.Format,
+		ContentType: record.ContentType,
+		Operations:  record.Operations,
+		CreatedAt:   record.CreatedAt.Format(constants.Iso8601Format),
+		UpdatedAt:   record.UpdatedAt.Format(constants.Iso8601Format),
+	}
+}
@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"catalog-service/internal/config"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	"catalog-service/internal/repository"
+	"catalog-service/internal/spec"
+	mockrepo "catalog-service/test/mocks/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const billingOpenAPI = `openapi: 3.0.3
+info:
+  title: Billing
+  description: Handles invoices
+  version: 2.0.0
+paths: {}
+`
+
+type SpecUsecaseSuite struct {
+	suite.Suite
+}
+
+func TestSpecUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(SpecUsecaseSuite))
+}
+
+func (suite *SpecUsecaseSuite) SetupTest() {
+	config.Load()
+	logger.Setup("INFO", "json")
+}
+
+func (suite *SpecUsecaseSuite) Test_ImportOpenAPI_CreatesServiceAndStoresSpec() {
+	services := new(mockrepo.ServiceRepository)
+	specs := new(mockrepo.SpecRepository)
+	services.On("FindByName", mock.Anything, "Billing").Return(nil, nil)
+	services.On("Create", mock.Anything, mock.MatchedBy(func(svc *models.Service) bool {
+		return svc.Description == "Handles invoices" &&
+			len(svc.Versions) == 1 && svc.Versions[0] == models.Version{VersionNumber: "2.0.0", Details: "first import"}
+	}), &models.ServiceEvent{
+		Type:          models.EventServiceCreated,
+		ChangedFields: []string{"name", "description", "versions"},
+	}, &models.ServiceEvent{
+		Type:          models.EventVersionPublished,
+		ChangedFields: []string{"versions"},
+		Versions:      []string{"2.0.0"},
+	}).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.Service).ID = "svc-1"
+	}).Return(nil)
+	specs.On("Save", mock.Anything, mock.MatchedBy(func(s *models.Spec) bool {
+		return s.ServiceID == "svc-1" && s.Version == "2.0.0" && s.Format == "openapi" &&
+			s.ContentType == spec.ContentTypeYAML && s.Content == billingOpenAPI
+	})).Return(nil)
+
+	uc := NewSpecUsecase(services, specs)
+	got, created, err := uc.ImportOpenAPI(context.Background(), []byte(billingOpenAPI), "first import")
+
+	suite.Require().NoError(err)
+	suite.True(created)
+	suite.Equal("svc-1", got.ID)
+	services.AssertExpectations(suite.T())
+	specs.AssertExpectations(suite.T())
+}
+
+func (suite *SpecUsecaseSuite) Test_ImportOpenAPI_MergesVersionIntoExistingService() {
+	services := new(mockrepo.ServiceRepository)
+	specs := new(mockrepo.SpecRepository)
+	existing := &models.Service{ID: "svc-1", Name: "Billing", Description: "old", Versions: []models.Version{{VersionNumber: "1.0.0"}}}
+	services.On("FindByName", mock.Anything, "Billing").Return(existing, nil)
+	services.On("Update", mock.Anything, existing, &models.ServiceEvent{
+		Type:          models.EventServiceUpdated,
+		ServiceID:     "svc-1",
+		ChangedFields: []string{"description", "versions"},
+	}, &models.ServiceEvent{
+		Type:          models.EventVersionPublished,
+		ServiceID:     "svc-1",
+		ChangedFields: []string{"versions"},
+		Versions:      []string{"2.0.0"},
+	}).Return(nil)
+	specs.On("FindByService", mock.Anything, "svc-1").Return([]*models.Spec{}, nil)
+	specs.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	uc := NewSpecUsecase(services, specs)
+	got, created, err := uc.ImportOpenAPI(context.Background(), []byte(billingOpenAPI), "")
+
+	suite.Require().NoError(err)
+	suite.False(created)
+	suite.Equal("Handles invoices", got.Description)
+	suite.Len(got.Versions, 2)
+	services.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *SpecUsecaseSuite) Test_ImportOpenAPI_RejectsInvalidDocument() {
+	services := new(mockrepo.ServiceRepository)
+	specs := new(mockrepo.SpecRepository)
+
+	uc := NewSpecUsecase(services, specs)
+	_, _, err := uc.ImportOpenAPI(context.Background(), []byte(`{"info": {}}`), "")
+
+	suite.True(errors.Is(err, spec.ErrInvalidSpec))
+	services.AssertNotCalled(suite.T(), "FindByName", mock.Anything, mock.Anything)
+}
+
+func (suite *SpecUsecaseSuite) Test_ImportOpenAPI_FailsWhenSpecCannotBeStored() {
+	services := new(mockrepo.ServiceRepository)
+	specs := new(mockrepo.SpecRepository)
+	services.On("FindByName", mock.Anything, "Billing").Return(nil, nil)
+	services.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	specs.On("Save", mock.Anything, mock.Anything).Return(assert.AnError)
+
+	uc := NewSpecUsecase(services, specs)
+	_, _, err := uc.ImportOpenAPI(context.Background(), []byte(billingOpenAPI), "")
+
+	suite.ErrorIs(err, assert.AnError)
+}
+
+func (suite *SpecUsecaseSuite) Test_PutSpec_StoresSpecAndIndexesOperationsOnService() {
+	services := new(mockrepo.ServiceRepository)
+	specs := new(mockrepo.SpecRepository)
+	existing := &models.Service{ID: "svc-1", Name: "Billing", Versions: []models.Version{{VersionNumber: "1.0.0"}, {VersionNumber: "2.0.0"}}}
+	services.On("FindByID", mock.Anything, "svc-1").Return(existing, nil)
+	specs.On("FindByService", mock.Anything, "svc-1").Return([]*models.Spec{
+		{Version: "1.0.0", Operations: []string{"Billing.Legacy", "Legacy"}},
+		{Version: "2.0.0", Operations: []string{"Billing.Stale"}},
+	}, nil)
+	services.On("Update", mock.Anything, mock.MatchedBy(func(svc *models.Service) bool {
+		return assert.ObjectsAreEqual([]string{"Billing.GetInvoice", "Billing.Legacy", "GetInvoice", "Legacy"}, svc.Operations)
+	}), &models.ServiceEvent{
+		Type:          models.EventServiceUpdated,
+		ServiceID:     "svc-1",
+		ChangedFields: []string{"operations"},
+	}).Return(nil)
+	specs.On("Save", mock.Anything, mock.MatchedBy(func(s *models.Spec) bool {
+		return s.ServiceID == "svc-1" && s.Version == "2.0.0" && s.Format == "protobuf" && s.ContentType == spec.ContentTypeProtobuf
+	})).Return(nil)
+
+	uc := NewSpecUsecase(services, specs)
+	got, err := uc.PutSpec(context.Background(), "svc-1", "2.0.0", "", []byte("syntax = \"proto3\";\nservice Billing {\n  rpc GetInvoice(Req) returns (Res);\n}\n"))
+
+	suite.Require().NoError(err)
+	suite.Equal([]string{"Billing.GetInvoice", "GetInvoice"}, got.Operations)
+	services.AssertExpectations(suite.T())
+	specs.AssertExpectations(suite.T())
+}
+
+func (suite *SpecUsecaseSuite) Test_PutSpec_ReportsMissingServiceVersionAndInvalidSpec() {
+	services := new(mockrepo.ServiceRepository)
+	specs := new(mockrepo.SpecRepository)
+	services.On("FindByID", mock.Anything, "missing").Return(nil, repository.ErrNotFound)
+	services.On("FindByID", mock.Anything, "svc-1").Return(&models.Service{ID: "svc-1", Versions: []models.Version{{VersionNumber: "1.0.0"}}}, nil)
+
+	uc := NewSpecUsecase(services, specs)
+
+	_, err := uc.PutSpec(context.Background(), "missing", "1.0.0", "", []byte("type Query { a: Int }"))
+	suite.ErrorIs(err, ErrServiceNotFound)
+
+	_, err = uc.PutSpec(context.Background(), "svc-1", "9.9.9", "", []byte("type Query { a: Int }"))
+	suite.ErrorIs(err, ErrVersionNotFound)
+
+	_, err = uc.PutSpec(context.Background(), "svc-1", "1.0.0", spec.FormatGraphQL, []byte("type Query { a: Int"))
+	suite.ErrorIs(err, spec.ErrInvalidSpec)
+
+	specs.AssertNotCalled(suite.T(), "Save", mock.Anything, mock.Anything)
+}
+
+func (suite *SpecUsecaseSuite) Test_StorageFailuresAreNotReportedAsNotFound() {
+	services := new(mockrepo.ServiceRepository)
+	specs := new(mockrepo.SpecRepository)
+	unavailable := fmt.Errorf("%w: breaker open", repository.ErrUnavailable)
+	services.On("FindByID", mock.Anything, "svc-1").Return(nil, unavailable)
+	specs.On("FindByVersion", mock.Anything, "svc-1", "1.0.0").Return(nil, unavailable)
+	specs.On("FindByVersion", mock.Anything, "svc-1", "2.0.0").Return(nil, fmt.Errorf("%w: svc-1_2.0.0", repository.ErrNotFound))
+
+	uc := NewSpecUsecase(services, specs)
+
+	_, err := uc.PutSpec(context.Background(), "svc-1", "1.0.0", "", []byte("type Query { a: Int }"))
+	suite.ErrorIs(err, repository.ErrUnavailable)
+	suite.NotErrorIs(err, ErrServiceNotFound)
+
+	_, err = uc.Compatibility(context.Background(), "svc-1", "1.0.0")
+	suite.ErrorIs(err, repository.ErrUnavailable)
+	suite.NotErrorIs(err, ErrServiceNotFound)
+
+	_, err = uc.GetSpec(context.Background(), "svc-1", "1.0.0")
+	suite.ErrorIs(err, repository.ErrUnavailable)
+	suite.NotErrorIs(err, ErrSpecNotFound)
+
+	_, err = uc.GetSpec(context.Background(), "svc-1", "2.0.0")
+	suite.ErrorIs(err, ErrSpecNotFound)
+}
+
+const billingOpenAPIv1 = `openapi: 3.0.3
+info:
+  title: Billing
+  version: 1.0.0
+paths:
+  /invoices:
+    get:
+      responses:
+        '200':
+          description: ok
+  /invoices/{id}:
+    delete:
+      responses:
+        '204':
+          description: deleted
+`
+
+const billingOpenAPIv11 = `openapi: 3.0.3
+info:
+  title: Billing
+  version: 1.1.0
+paths:
+  /invoices:
+    get:
+      responses:
+        '200':
+          description: ok
+`
+
+func (suite *SpecUsecaseSuite) Test_Compatibility_ComparesWithClosestPreviousVersion() {
+	services := new(mockrepo.ServiceRepository)
+	specs := new(mockrepo.SpecRepository)
+	services.On("FindByID", mock.Anything, "svc-1").Return(&models.Service{ID: "svc-1", Versions: []models.Version{
+		{VersionNumber: "0.9.0"}, {VersionNumber: "1.0.0"}, {VersionNumber: "1.1.0"}, {VersionNumber: "2.0.0"},
+	}}, nil)
+	specs.On("FindByVersion", mock.Anything, "svc-1", "1.1.0").Return(&models.Spec{Version: "1.1.0", Format: "openapi", Content: billingOpenAPIv11}, nil)
+	specs.On("FindByVersion", mock.Anything, "svc-1", "1.0.0").Return(&models.Spec{Version: "1.0.0", Format: "openapi", Content: billingOpenAPIv1}, nil)
+	specs.On("FindByService", mock.Anything, "svc-1").Return([]*models.Spec{
+		{Version: "0.9.0", Format: "openapi"},
+		{Version: "1.0.0", Format: "openapi"},
+		{Version: "1.1.0", Format: "openapi"},
+		{Version: "2.0.0", Format: "openapi"},
+	}, nil)
+
+	uc := NewSpecUsecase(services, specs)
+	got, err := uc.Compatibility(context.Background(), "svc-1", "1.1.0")
+
+	suite.Require().NoError(err)
+	suite.Equal("1.0.0", got.PreviousVersion)
+	suite.False(got.Compatible)
+	suite.Require().Len(got.BreakingChanges, 1)
+	suite.Equal(spec.ChangeEndpointRemoved, got.BreakingChanges[0].Type)
+	suite.Equal("/invoices/{id}", got.BreakingChanges[0].Path)
+}
+
+func (suite *SpecUsecaseSuite) Test_Compatibility_FirstVersionAndNonOpenAPISpecs() {
+	services := new(mockrepo.ServiceRepository)
+	specs := new(mockrepo.SpecRepository)
+	services.On("FindByID", mock.Anything, "svc-1").Return(&models.Service{ID: "svc-1", Versions: []models.Version{{VersionNumber: "1.0.0"}, {VersionNumber: "2.0.0"}}}, nil)
+	specs.On("FindByVersion", mock.Anything, "svc-1", "1.0.0").Return(&models.Spec{Version: "1.0.0", Format: "openapi", Content: billingOpenAPIv1}, nil)
+	specs.On("FindByVersion", mock.Anything, "svc-1", "2.0.0").Return(&models.Spec{Version: "2.0.0", Format: "graphql"}, nil)
+	specs.On("FindByService", mock.Anything, "svc-1").Return([]*models.Spec{{Version: "1.0.0", Format: "openapi"}}, nil)
+
+	uc := NewSpecUsecase(services, specs)
+	got, err := uc.Compatibility(context.Background(), "svc-1", "1.0.0")
+	suite.Require().NoError(err)
+	suite.True(got.Compatible)
+	suite.Empty(got.PreviousVersion)
+	suite.Empty(got.BreakingChanges)
+
+	_, err = uc.Compatibility(context.Background(), "svc-1", "2.0.0")
+	suite.ErrorIs(err, ErrNotComparable)
+
+	_, err = uc.Compatibility(context.Background(), "svc-1", "3.0.0")
+	suite.ErrorIs(err, ErrVersionNotFound)
+}
+
+func (suite *SpecUsecaseSuite) Test_ImportOpenAPI_RejectsBreakingMinorBumpWhenEnabled() {
+	services := new(mockrepo.ServiceRepository)
+	specs := new(mockrepo.SpecRepository)
+	existing := &models.Service{ID: "svc-1", Name: "Billing", Versions: []models.Version{{VersionNumber: "1.0.0"}}}
+	services.On("FindByName", mock.Anything, "Billing").Return(existing, nil)
+	specs.On("FindByService", mock.Anything, "svc-1").Return([]*models.Spec{{Version: "1.0.0", Format: "openapi"}}, nil)
+	specs.On("FindByVersion", mock.Anything, "svc-1", "1.0.0").Return(&models.Spec{Version: "1.0.0", Format: "openapi", Content: billingOpenAPIv1}, nil)
+
+	uc := &specUsecase{services: services, specs: specs, rejectBreaking: true}
+	_, _, err := uc.ImportOpenAPI(context.Background(), []byte(billingOpenAPIv11), "")
+
+	suite.ErrorIs(err, ErrBreakingChange)
+	var breaking *BreakingChangeError
+	suite.Require().ErrorAs(err, &breaking)
+	suite.Equal("1.0.0", breaking.Previous)
+	suite.Len(breaking.Changes, 1)
+	services.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything)
+	specs.AssertNotCalled(suite.T(), "Save", mock.Anything, mock.Anything)
+}
+
+func (suite *SpecUsecaseSuite) Test_ImportOpenAPI_AllowsBreakingMajorBump() {
+	services := new(mockrepo.ServiceRepository)
+	specs := new(mockrepo.SpecRepository)
+	existing := &models.Service{ID: "svc-1", Name: "Billing", Versions: []models.Version{{VersionNumber: "1.0.0"}}}
+	services.On("FindByName", mock.Anything, "Billing").Return(existing, nil)
+	services.On("Update", mock.Anything, existing, mock.Anything, mock.Anything).Return(nil)
+	specs.On("FindByService", mock.Anything, "svc-1").Return([]*models.Spec{{Version: "1.0.0", Format: "openapi"}}, nil)
+	specs.On("FindByVersion", mock.Anything, "svc-1", "1.0.0").Return(&models.Spec{Version: "1.0.0", Format: "openapi", Content: billingOpenAPIv1}, nil)
+	specs.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	uc := &specUsecase{services: services, specs: specs, rejectBreaking: true}
+	_, _, err := uc.ImportOpenAPI(context.Background(), []byte(billingOpenAPI), "")
+
+	suite.Require().NoError(err)
+	specs.AssertExpectations(suite.T())
+}
@@ -0,0 +1,216 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"catalog-service/internal/constants"
+	"catalog-service/internal/dto"
+	"catalog-service/internal/models"
+	"catalog-service/internal/repository"
+)
+
+const maxDeliveries = 100
+
+type WebhookUsecase interface {
+	Create(ctx context.Context, req *dto.WebhookDTO) (*dto.WebhookDTO, error)
+	List(ctx context.Context, filter models.WebhookFilter) ([]*dto.WebhookDTO, error)
+	FindByID(ctx context.Context, id string) (*dto.WebhookDTO, error)
+	Update(ctx context.Context, id string, req *dto.WebhookDTO) (*dto.WebhookDTO, error)
+	Delete(ctx context.Context, id string) error
+	Deliveries(ctx context.Context, id string) ([]*dto.DeliveryDTO, error)
+	Redeliver(ctx context.Context, id, deliveryID string) (*dto.DeliveryDTO, error)
+}
+
+type DeliveryQueue interface {
+	Enqueue(delivery *models.WebhookDelivery)
+}
+
+type webhookUsecase struct {
+	repo  repository.WebhookRepository
+	queue DeliveryQueue
+}
+
+func NewWebhookUsecase(repo repository.WebhookRepository, queue DeliveryQueue) WebhookUsecase {
+	return &webhookUsecase{repo: repo, queue: queue}
+}
+
+func (u *webhookUsecase) Create(ctx context.Context, req *dto.WebhookDTO) (*dto.WebhookDTO, error) {
+	webhook := &models.Webhook{Secret: req.Secret, Active: true}
+	applyWebhook(webhook, req)
+	if webhook.Secret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+		}
+		webhook.Secret = hex.EncodeToString(secret)
+	}
+	if err := u.repo.Save(ctx, webhook); err != nil {
+		return nil, err
+	}
+	// The secret is only returned when the webhook is created.
+	created := toWebhookDTO(webhook)
+	created.Secret = webhook.Secret
+	return created, nil
+}
+
+func (u *webhookUsecase) List(ctx context.Context, filter models.WebhookFilter) ([]*dto.WebhookDTO, error) {
+	webhooks, err := u.repo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	dtos := make([]*dto.WebhookDTO, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		dtos = append(dtos, toWebhookDTO(webhook))
+	}
+	return dtos, nil
+}
+
+func (u *webhookUsecase) FindByID(ctx context.Context, id string) (*dto.WebhookDTO, error) {
+	webhook, err := u.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toWebhookDTO(webhook), nil
+}
+
+// Update replaces the subscription. The secret is rotated only when one is
+// given, and active is kept when omitted.
+func (u *webhookUsecase) Update(ctx context.Context, id string, req *dto.WebhookDTO) (*dto.WebhookDTO, error) {
+	webhook, err := u.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	applyWebhook(webhook, req)
+	if req.Secret != "" {
+		webhook.Secret = req.Secret
+	}
+	if err := u.repo.Save(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return toWebhookDTO(webhook), nil
+}
+
+func (u *webhookUsecase) Delete(ctx context.Context, id string) error {
+	if err := u.repo.Delete(ctx, id); err != nil {
+		return webhookNotFound(err, id)
+	}
+	return nil
+}
+
+func (u *webhookUsecase) Deliveries(ctx context.Context, id string) ([]*dto.DeliveryDTO, error) {
+	if _, err := u.find(ctx, id); err != nil {
+		return nil, err
+	}
+	deliveries, err := u.repo.ListDeliveries(ctx, id, maxDeliveries)
+	if err != nil {
+		return nil, err
+	}
+	dtos := make([]*dto.DeliveryDTO, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		dtos = append(dtos, toDeliveryDTO(delivery))
+	}
+	return dtos, nil
+}
+
+// Redeliver sends the event of a past delivery again as a new delivery, with
+// its own attempts and retries.
+func (u *webhookUsecase) Redeliver(ctx context.Context, id, deliveryID string) (*dto.DeliveryDTO, error) {
+	if _, err := u.find(ctx, id); err != nil {
+		return nil, err
+	}
+	previous, err := u.repo.FindDelivery(ctx, deliveryID)
+	if errors.Is(err, repository.ErrNotFound) || (err == nil && previous.WebhookID != id) {
+		return nil, fmt.Errorf("%w: %s", ErrDeliveryNotFound, deliveryID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := &models.WebhookDelivery{
+		WebhookID:  id,
+		Event:      previous.Event,
+		Status:     models.DeliveryPending,
+		Redelivery: true,
+	}
+	if err := u.repo.SaveDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+	u.queue.Enqueue(delivery)
+	return toDeliveryDTO(delivery), nil
+}
+
+func (u *webhookUsecase) find(ctx context.Context, id string) (*models.Webhook, error) {
+	webhook, err := u.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, webhookNotFound(err, id)
+	}
+	return webhook, nil
+}
+
+func applyWebhook(webhook *models.Webhook, req *dto.WebhookDTO) {
+	webhook.URL = req.URL
+	webhook.EventTypes = make([]models.EventType, 0, len(req.EventTypes))
+	for _, t := range req.EventTypes {
+		webhook.EventTypes = append(webhook.EventTypes, models.EventType(t))
+	}
+	webhook.ServiceIDs = nonNil(req.ServiceIDs)
+	webhook.Labels = nonNil(req.Labels)
+	if req.Active != nil {
+		webhook.Active = *req.Active
+	}
+}
+
+func toWebhookDTO(webhook *models.Webhook) *dto.WebhookDTO {
+	eventTypes := make([]string, 0, len(webhook.EventTypes))
+	for _, t := range webhook.EventTypes {
+		eventTypes = append(eventTypes, string(t))
+	}
+	active := webhook.Active
+	return &dto.WebhookDTO{
+		ID:         webhook.ID,
+		URL:        webhook.URL,
+		EventTypes: eventTypes,
+		ServiceIDs: nonNil(webhook.ServiceIDs),
+		Labels:     nonNil(webhook.Labels),
+		Active:     &active,
+		CreatedAt:  webhook.CreatedAt.Format(constants.Iso8601Format),
+		UpdatedAt:  webhook.UpdatedAt.Format(constants.Iso8601Format),
+	}
+}
+
+func toDeliveryDTO(delivery *models.WebhookDelivery) *dto.DeliveryDTO {
+	d := &dto.DeliveryDTO{
+		ID:           delivery.ID,
+		WebhookID:    delivery.WebhookID,
+		Event:        delivery.Event,
+		Status:       string(delivery.Status),
+		Attempts:     delivery.Attempts,
+		ResponseCode: delivery.ResponseCode,
+		Error:        delivery.Error,
+		Redelivery:   delivery.Redelivery,
+		CreatedAt:    delivery.CreatedAt.Format(constants.Iso8601Format),
+		UpdatedAt:    delivery.UpdatedAt.Format(constants.Iso8601Format),
+	}
+	if delivery.NextRetryAt != nil {
+		d.NextRetryAt = delivery.NextRetryAt.Format(constants.Iso8601Format)
+	}
+	return d
+}
+
+func webhookNotFound(err error, id string) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("%w: %s: %v", ErrWebhookNotFound, id, err)
+	}
+	return err
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"catalog-service/internal/config"
+	"catalog-service/internal/dto"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	"catalog-service/internal/repository"
+	mockrepo "catalog-service/test/mocks/repository"
+	mockusecase "catalog-service/test/mocks/usecase"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookUsecaseSuite struct {
+	suite.Suite
+	repo  *mockrepo.WebhookRepository
+	queue *mockusecase.DeliveryQueue
+	uc    WebhookUsecase
+}
+
+func TestWebhookUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(WebhookUsecaseSuite))
+}
+
+func (suite *WebhookUsecaseSuite) SetupTest() {
+	config.Load()
+	logger.Setup("INFO", "json")
+	suite.repo = new(mockrepo.WebhookRepository)
+	suite.queue = new(mockusecase.DeliveryQueue)
+	suite.uc = NewWebhookUsecase(suite.repo, suite.queue)
+}
+
+func (suite *WebhookUsecaseSuite) Test_Create_GeneratesSecretAndReturnsItOnce() {
+	var saved *models.Webhook
+	suite.repo.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(*models.Webhook)
+		saved.ID = "wh-1"
+	}).Return(nil)
+
+	created, err := suite.uc.Create(context.Background(), &dto.WebhookDTO{
+		URL:        "https://example.com/hook",
+		EventTypes: []string{string(models.EventServiceCreated)},
+	})
+
+	suite.Require().NoError(err)
+	suite.Len(created.Secret, 64)
+	suite.Equal(saved.Secret, created.Secret)
+	suite.True(saved.Active)
+	suite.Equal([]models.EventType{models.EventServiceCreated}, saved.EventTypes)
+
+	suite.repo.On("FindByID", mock.Anything, "wh-1").Return(saved, nil)
+	found, err := suite.uc.FindByID(context.Background(), "wh-1")
+	suite.Require().NoError(err)
+	suite.Empty(found.Secret)
+}
+
+func (suite *WebhookUsecaseSuite) Test_Update_KeepsSecretAndActiveWhenOmitted() {
+	existing := &models.Webhook{ID: "wh-1", URL: "https://old.example.com", Secret: "0123456789abcdef", Active: false}
+	suite.repo.On("FindByID", mock.Anything, "wh-1").Return(existing, nil)
+	suite.repo.On("Save", mock.Anything, existing).Return(nil)
+
+	updated, err := suite.uc.Update(context.Background(), "wh-1", &dto.WebhookDTO{URL: "https://new.example.com", Labels: []string{"payments"}})
+
+	suite.Require().NoError(err)
+	suite.Equal("0123456789abcdef", existing.Secret)
+	suite.False(*updated.Active)
+	suite.Equal("https://new.example.com", updated.URL)
+	suite.Equal([]string{"payments"}, updated.Labels)
+}
+
+func (suite *WebhookUsecaseSuite) Test_FindByID_MapsNotFound() {
+	suite.repo.On("FindByID", mock.Anything, "missing").Return(nil, repository.ErrNotFound)
+
+	_, err := suite.uc.FindByID(context.Background(), "missing")
+
+	suite.ErrorIs(err, ErrWebhookNotFound)
+}
+
+func (suite *WebhookUsecaseSuite) Test_Redeliver_EnqueuesNewDelivery() {
+	event := models.ServiceEvent{ID: 7, Type: models.EventServiceUpdated, ServiceID: "svc-1"}
+	suite.repo.On("FindByID", mock.Anything, "wh-1").Return(&models.Webhook{ID: "wh-1"}, nil)
+	suite.repo.On("FindDelivery", mock.Anything, "d-1").Return(&models.WebhookDelivery{ID: "d-1", WebhookID: "wh-1", Event: event, Status: models.DeliveryFailed}, nil)
+	suite.repo.On("SaveDelivery", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.WebhookDelivery).ID = "d-2"
+	}).Return(nil)
+	suite.queue.On("Enqueue", mock.MatchedBy(func(d *models.WebhookDelivery) bool {
+		return d.ID == "d-2" && d.Redelivery && d.Status == models.DeliveryPending && d.Event.ID == 7
+	})).Return()
+
+	delivery, err := suite.uc.Redeliver(context.Background(), "wh-1", "d-1")
+
+	suite.Require().NoError(err)
+	suite.Equal("d-2", delivery.ID)
+	suite.True(delivery.Redelivery)
+	suite.queue.AssertExpectations(suite.T())
+}
+
+func (suite *WebhookUsecaseSuite) Test_Redeliver_RejectsDeliveryOfAnotherWebhook() {
+	suite.repo.On("FindByID", mock.Anything, "wh-1").Return(&models.Webhook{ID: "wh-1"}, nil)
+	suite.repo.On("FindDelivery", mock.Anything, "d-1").Return(&models.WebhookDelivery{ID: "d-1", WebhookID: "wh-2"}, nil)
+
+	_, err := suite.uc.Redeliver(context.Background(), "wh-1", "d-1")
+
+	suite.ErrorIs(err, ErrDeliveryNotFound)
+	suite.queue.AssertNotCalled(suite.T(), "Enqueue", mock.Anything)
+}
@@ -0,0 +1,315 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+
+	"catalog-service/internal/events"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	"catalog-service/internal/repository"
+)
+
+const (
+	SignatureHeader = "X-Catalog-Signature-256"
+	EventHeader     = "X-Catalog-Event"
+	DeliveryHeader  = "X-Catalog-Delivery"
+
+	queueSize      = 1024
+	maxErrorLength = 512
+	resubscribeGap = time.Second
+)
+
+type Options struct {
+	Workers        int
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Timeout        time.Duration
+}
+
+// Dispatcher delivers service events to matching webhooks. Failed deliveries
+// are retried with exponential backoff until MaxAttempts; retries that are
+// still pending when the process stops are not resumed, but can be
+// redelivered through the API.
+type Dispatcher struct {
+	repo   repository.WebhookRepository
+	client *http.Client
+	opts   Options
+	queue  chan *models.WebhookDelivery
+
+	mu      sync.Mutex
+	stopped bool
+	retries sync.WaitGroup
+	done    chan struct{}
+}
+
+func NewDispatcher(repo repository.WebhookRepository, opts Options) *Dispatcher {
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 1
+	}
+	return &Dispatcher{
+		repo:   repo,
+		client: &http.Client{Timeout: opts.Timeout},
+		opts:   opts,
+		queue:  make(chan *models.WebhookDelivery, queueSize),
+	}
+}
+
+// Sign returns the signature header value for body: the hex HMAC-SHA256 of the
+// body keyed with the webhook secret, prefixed with "sha256=".
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Start subscribes to broker and delivers its events in the background until
+// ctx is cancelled. Wait blocks until the dispatcher has stopped.
+func (d *Dispatcher) Start(ctx context.Context, broker *events.Broker) error {
+	sub, err := broker.Subscribe(ctx, 0)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to events: %w", err)
+	}
+
+	var workers sync.WaitGroup
+	for i := 0; i < d.opts.Workers; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			d.work(ctx)
+		}()
+	}
+
+	d.done = make(chan struct{})
+	go func() {
+		defer close(d.done)
+		d.consume(ctx, broker, sub)
+
+		d.mu.Lock()
+		d.stopped = true
+		d.mu.Unlock()
+		d.retries.Wait()
+		workers.Wait()
+	}()
+	return nil
+}
+
+func (d *Dispatcher) Wait() {
+	if d.done != nil {
+		<-d.done
+	}
+}
+
+// Enqueue schedules delivery for an immediate attempt.
+func (d *Dispatcher) Enqueue(delivery *models.WebhookDelivery) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.stopped {
+		return
+	}
+	select {
+	case d.queue <- delivery:
+	default:
+		logger.NonContext.Warnf("webhook queue full, delivery %s stays pending", delivery.ID)
+	}
+}
+
+func (d *Dispatcher) consume(ctx context.Context, broker *events.Broker, sub *events.Subscription) {
+	log := logger.NewContextLogger(ctx, "Dispatcher/consume")
+	var lastID int64
+	for {
+		if !d.drain(ctx, sub, &lastID) {
+			return
+		}
+
+		// The subscription ends when the broker closes or drops it: resume
+		// from the last dispatched event.
+		for {
+			if !sleep(ctx, resubscribeGap) {
+				return
+			}
+			var err error
+			if sub, err = broker.Subscribe(ctx, lastID); err == nil {
+				break
+			}
+			log.Errorf(err, "failed to resubscribe to events")
+		}
+		if sub.Gap {
+			log.Warnf("events after %d were trimmed from the log before they were delivered", lastID)
+		}
+	}
+}
+
+// drain dispatches events until the subscription ends and closes it. It
+// returns false when ctx is cancelled.
+func (d *Dispatcher) drain(ctx context.Context, sub *events.Subscription, lastID *int64) bool {
+	defer sub.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case event, ok := <-sub.Events:
+			if !ok {
+				return true
+			}
+			d.dispatch(ctx, event)
+			*lastID = event.ID
+		}
+	}
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, event *models.ServiceEvent) {
+	log := logger.NewContextLogger(ctx, "Dispatcher/dispatch")
+	webhooks, err := d.repo.List(ctx, models.WebhookFilter{})
+	if err != nil {
+		log.Errorf(err, "failed to load webhooks for event %d", event.ID)
+		return
+	}
+	for _, webhook := range webhooks {
+		if !webhook.Matches(event) {
+			continue
+		}
+		delivery := &models.WebhookDelivery{WebhookID: webhook.ID, Event: *event, Status: models.DeliveryPending}
+		if err := d.repo.SaveDelivery(ctx, delivery); err != nil {
+			log.Errorf(err, "failed to record delivery of event %d to webhook %s", event.ID, webhook.ID)
+			continue
+		}
+		d.Enqueue(delivery)
+	}
+}
+
+func (d *Dispatcher) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case delivery := <-d.queue:
+			d.attempt(ctx, delivery)
+		}
+	}
+}
+
+func (d *Dispatcher) attempt(ctx context.Context, delivery *models.WebhookDelivery) {
+	log := logger.NewContextLogger(ctx, "Dispatcher/attempt")
+	webhook, err := d.repo.FindByID(ctx, delivery.WebhookID)
+	if err != nil {
+		log.Errorf(err, "dropping delivery %s: webhook %s not found", delivery.ID, delivery.WebhookID)
+		return
+	}
+
+	delivery.Attempts++
+	code, err := d.send(ctx, webhook, delivery)
+	delivery.ResponseCode = code
+	delivery.Error = ""
+	delivery.NextRetryAt = nil
+	switch {
+	case err == nil:
+		delivery.Status = models.DeliverySucceeded
+	case delivery.Attempts >= d.opts.MaxAttempts:
+		delivery.Status = models.DeliveryFailed
+		delivery.Error = truncate(err.Error())
+	default:
+		delivery.Status = models.DeliveryPending
+		delivery.Error = truncate(err.Error())
+		wait := d.backoff(delivery.Attempts)
+		next := time.Now().UTC().Add(wait)
+		delivery.NextRetryAt = &next
+		defer d.retry(ctx, delivery, wait)
+	}
+	log.Infof("delivery %s of event %d to webhook %s: attempt %d %s", delivery.ID, delivery.Event.ID, webhook.ID, delivery.Attempts, delivery.Status)
+
+	if err := d.repo.SaveDelivery(ctx, delivery); err != nil {
+		log.Errorf(err, "failed to record delivery %s", delivery.ID)
+	}
+}
+
+func (d *Dispatcher) send(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery) (int, error) {
+	body, err := json.Marshal(delivery.Event)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "catalog-service-webhooks")
+	req.Header.Set(EventHeader, string(delivery.Event.Type))
+	req.Header.Set(DeliveryHeader, delivery.ID)
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, body))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("receiver responded %s", res.Status)
+	}
+	return res.StatusCode, nil
+}
+
+func (d *Dispatcher) retry(ctx context.Context, delivery *models.WebhookDelivery, wait time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.stopped {
+		return
+	}
+	d.retries.Add(1)
+	go func() {
+		defer d.retries.Done()
+		if sleep(ctx, wait) {
+			d.Enqueue(delivery)
+		}
+	}()
+}
+
+// backoff doubles the wait after every attempt, up to MaxBackoff, and picks a
+// random point in the upper half so receivers recovering from an outage are
+// not hit by every retry at once.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	wait := d.opts.InitialBackoff
+	for i := 1; i < attempts && wait < d.opts.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if d.opts.MaxBackoff > 0 && wait > d.opts.MaxBackoff {
+		wait = d.opts.MaxBackoff
+	}
+	if wait <= 1 {
+		return wait
+	}
+	return wait/2 + rand.N(wait/2)
+}
+
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
+func truncate(s string) string {
+	if len(s) > maxErrorLength {
+		return s[:maxErrorLength]
+	}
+	return s
+}
@@ -0,0 +1,202 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"catalog-service/internal/config"
+	"catalog-service/internal/events"
+	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
+	"catalog-service/internal/repository"
+	mockrepo "catalog-service/test/mocks/repository"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type memoryWebhooks struct {
+	repository.WebhookRepository
+	mu         sync.Mutex
+	webhooks   []*models.Webhook
+	deliveries map[string]models.WebhookDelivery
+	nextID     int
+}
+
+func (m *memoryWebhooks) List(context.Context, models.WebhookFilter) ([]*models.Webhook, error) {
+	return m.webhooks, nil
+}
+
+func (m *memoryWebhooks) FindByID(_ context.Context, id string) (*models.Webhook, error) {
+	for _, w := range m.webhooks {
+		if w.ID == id {
+			return w, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (m *memoryWebhooks) SaveDelivery(_ context.Context, delivery *models.WebhookDelivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if delivery.ID == "" {
+		m.nextID++
+		delivery.ID = "delivery-" + string(rune('0'+m.nextID))
+	}
+	m.deliveries[delivery.ID] = *delivery
+	return nil
+}
+
+func (m *memoryWebhooks) delivery(id string) models.WebhookDelivery {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.deliveries[id]
+}
+
+type received struct {
+	header http.Header
+	body   []byte
+}
+
+type DispatcherSuite struct {
+	suite.Suite
+	repo     *memoryWebhooks
+	receiver *httptest.Server
+	requests chan received
+	status   func(attempt int) int
+	broker   *events.Broker
+	cancel   context.CancelFunc
+	stop     func()
+}
+
+func TestDispatcherSuite(t *testing.T) {
+	suite.Run(t, new(DispatcherSuite))
+}
+
+func (suite *DispatcherSuite) SetupTest() {
+	config.Load()
+	logger.Setup("INFO", "json")
+
+	suite.requests = make(chan received, 16)
+	suite.status = func(int) int { return http.StatusOK }
+	attempts := 0
+	suite.receiver = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		attempts++
+		w.WriteHeader(suite.status(attempts))
+		suite.requests <- received{header: r.Header, body: body}
+	}))
+	suite.repo = &memoryWebhooks{deliveries: map[string]models.WebhookDelivery{}}
+
+	store := new(mockrepo.EventRepository)
+	store.On("LastID", mock.Anything).Return(int64(0), nil)
+	store.On("Append", mock.Anything, mock.Anything).Return(nil)
+	suite.broker = events.NewBroker(store)
+}
+
+func (suite *DispatcherSuite) TearDownTest() {
+	if suite.cancel != nil {
+		suite.cancel()
+		suite.stop()
+	}
+	suite.receiver.Close()
+}
+
+func (suite *DispatcherSuite) start(opts Options) {
+	d := NewDispatcher(suite.repo, opts)
+	ctx, cancel := context.WithCancel(context.Background())
+	suite.cancel = cancel
+	suite.Require().NoError(d.Start(ctx, suite.broker))
+	suite.stop = d.Wait
+}
+
+func (suite *DispatcherSuite) publish(event *models.ServiceEvent) {
+	suite.Require().NoError(suite.broker.Publish(context.Background(), event))
+}
+
+func (suite *DispatcherSuite) next() received {
+	select {
+	case r := <-suite.requests:
+		return r
+	case <-time.After(2 * time.Second):
+		suite.FailNow("webhook was not called")
+		return received{}
+	}
+}
+
+func (suite *DispatcherSuite) Test_DeliversMatchingEventsWithSignature() {
+	suite.repo.webhooks = []*models.Webhook{
+		{ID: "wh-1", URL: suite.receiver.URL, Secret: "0123456789abcdef", Active: true,
+			EventTypes: []models.EventType{models.EventVersionPublished}, Labels: []string{"payments"}},
+		{ID: "wh-2", URL: suite.receiver.URL, Secret: "0123456789abcdef", Active: true, ServiceIDs: []string{"other"}},
+		{ID: "wh-3", URL: suite.receiver.URL, Secret: "0123456789abcdef", Active: false},
+	}
+	suite.start(Options{MaxAttempts: 1, Timeout: time.Second})
+
+	suite.publish(&models.ServiceEvent{Type: models.EventServiceUpdated, ServiceID: "svc-1", Labels: []string{"payments"}})
+	suite.publish(&models.ServiceEvent{Type: models.EventVersionPublished, ServiceID: "svc-1", Versions: []string{"2.0"}, Labels: []string{"payments", "core"}})
+
+	r := suite.next()
+	suite.Equal(string(models.EventVersionPublished), r.header.Get(EventHeader))
+	suite.Equal(Sign("0123456789abcdef", r.body), r.header.Get(SignatureHeader))
+	suite.Contains(string(r.body), `"versions":["2.0"]`)
+	suite.Contains(string(r.body), `"id":2`)
+
+	id := r.header.Get(DeliveryHeader)
+	suite.Eventually(func() bool { return suite.repo.delivery(id).Status == models.DeliverySucceeded }, time.Second, time.Millisecond)
+	suite.Equal(http.StatusOK, suite.repo.delivery(id).ResponseCode)
+	suite.Equal("wh-1", suite.repo.delivery(id).WebhookID)
+	suite.Empty(suite.requests, "only wh-1 matches, and only the second event")
+}
+
+func (suite *DispatcherSuite) Test_RetriesFailedDeliveries() {
+	suite.status = func(attempt int) int {
+		if attempt < 3 {
+			return http.StatusServiceUnavailable
+		}
+		return http.StatusNoContent
+	}
+	suite.repo.webhooks = []*models.Webhook{{ID: "wh-1", URL: suite.receiver.URL, Secret: "0123456789abcdef", Active: true}}
+	suite.start(Options{MaxAttempts: 5, InitialBackoff: time.Millisecond, MaxBackoff: 4 * time.Millisecond, Timeout: time.Second})
+
+	suite.publish(&models.ServiceEvent{Type: models.EventServiceDeleted, ServiceID: "svc-1"})
+
+	first, second, third := suite.next(), suite.next(), suite.next()
+	suite.Equal(first.body, third.body)
+	suite.Equal(first.header.Get(DeliveryHeader), second.header.Get(DeliveryHeader))
+	id := third.header.Get(DeliveryHeader)
+	suite.Eventually(func() bool { return suite.repo.delivery(id).Status == models.DeliverySucceeded }, time.Second, time.Millisecond)
+	suite.Equal(3, suite.repo.delivery(id).Attempts)
+	suite.Empty(suite.repo.delivery(id).Error)
+}
+
+func (suite *DispatcherSuite) Test_GivesUpAfterMaxAttempts() {
+	suite.status = func(int) int { return http.StatusInternalServerError }
+	suite.repo.webhooks = []*models.Webhook{{ID: "wh-1", URL: suite.receiver.URL, Secret: "0123456789abcdef", Active: true}}
+	suite.start(Options{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Timeout: time.Second})
+
+	suite.publish(&models.ServiceEvent{Type: models.EventServiceDeleted, ServiceID: "svc-1"})
+
+	suite.next()
+	id := suite.next().header.Get(DeliveryHeader)
+	suite.Eventually(func() bool { return suite.repo.delivery(id).Status == models.DeliveryFailed }, time.Second, time.Millisecond)
+	delivery := suite.repo.delivery(id)
+	suite.Equal(2, delivery.Attempts)
+	suite.Equal(http.StatusInternalServerError, delivery.ResponseCode)
+	suite.Contains(delivery.Error, "500")
+	suite.Nil(delivery.NextRetryAt)
+}
+
+func (suite *DispatcherSuite) Test_BackoffGrowsExponentially() {
+	d := NewDispatcher(suite.repo, Options{InitialBackoff: time.Second, MaxBackoff: 10 * time.Second})
+	for attempts, max := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 8 * time.Second, 6: 10 * time.Second} {
+		wait := d.backoff(attempts)
+		suite.GreaterOrEqual(wait, max/2, "attempt %d", attempts)
+		suite.LessOrEqual(wait, max, "attempt %d", attempts)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"catalog-service/internal/api"
 	"catalog-service/internal/config"
 	"catalog-service/internal/dto"
+	"catalog-service/internal/events"
 	"catalog-service/internal/logger"
 	"catalog-service/internal/opensearch"
 	"catalog-service/internal/repository"
@@ -43,7 +44,13 @@ func (s *ServiceAPIDetailIntegrationSuite) SetupSuite() {
 	utils.CleanupTestData(s.client, testconstants.ServiceIndexName, s.T())
 	utils.LoadTestData(s.repo, s.T())
 
-	s.server = httptest.NewServer(api.NewRouter(&s.repo))
+	eventRepo, err := repository.NewEventRepository(client, 100)
+	s.Require().NoError(err)
+	s.server = httptest.NewServer(api.NewRouter(api.Dependencies{
+		Services: &s.repo,
+		Specs:    &repository.SpecRepositoryImpl{Client: client},
+		Events:   events.NewBroker(eventRepo),
+	}))
 }
 
 func (s *ServiceAPIDetailIntegrationSuite) TearDownSuite() {
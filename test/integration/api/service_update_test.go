@@ -11,7 +11,9 @@ import (
 	"catalog-service/internal/api"
 	"catalog-service/internal/config"
 	"catalog-service/internal/dto"
+	"catalog-service/internal/events"
 	"catalog-service/internal/logger"
+	"catalog-service/internal/models"
 	"catalog-service/internal/opensearch"
 	"catalog-service/internal/repository"
 	testconstants "catalog-service/test/constants"
@@ -44,7 +46,13 @@ func (s *ServiceAPIUpdateIntegrationSuite) SetupSuite() {
 	utils.CleanupTestData(s.client, testconstants.ServiceIndexName, s.T())
 	utils.LoadTestData(s.repo, s.T())
 
-	s.server = httptest.NewServer(api.NewRouter(&s.repo))
+	eventRepo, err := repository.NewEventRepository(client, 100)
+	s.Require().NoError(err)
+	s.server = httptest.NewServer(api.NewRouter(api.Dependencies{
+		Services: &s.repo,
+		Specs:    &repository.SpecRepositoryImpl{Client: client},
+		Events:   events.NewBroker(eventRepo),
+	}))
 }
 
 func (s *ServiceAPIUpdateIntegrationSuite) TearDownSuite() {
@@ -121,6 +129,65 @@ func (suite *ServiceAPIUpdateIntegrationSuite) Test_UpdateService_AppendVersionA
 	assert.Equal(suite.T(), "name", failResult.Errors[0].Entity)
 }
 
+func (suite *ServiceAPIUpdateIntegrationSuite) Test_UpdateService_ResentVersionOverwritesDetails() {
+	resp := suite.doGet("/api/services", nil)
+	defer resp.Body.Close()
+	var listResult struct {
+		Data struct {
+			Services []struct {
+				ID string `json:"id"`
+			} `json:"services"`
+		} `json:"data"`
+	}
+	suite.decodeResponse(resp.Body, &listResult)
+	suite.Require().NotEmpty(listResult.Data.Services)
+	svcID := listResult.Data.Services[0].ID
+
+	suite.putVersion(svcID, "3.0", "Third release")
+	updateResult := suite.putVersion(svcID, "3.0", "Third release, revised")
+
+	var matches []models.Version
+	for _, v := range updateResult.Data.Versions {
+		if v.VersionNumber == "3.0" {
+			matches = append(matches, v)
+		}
+	}
+	suite.Require().Len(matches, 1, "a re-sent version number should not be added again")
+	assert.Equal(suite.T(), "Third release, revised", matches[0].Details)
+
+	detailResp := suite.doGet("/api/services/"+svcID, nil)
+	defer detailResp.Body.Close()
+	var detail dto.ServiceDetailResponse
+	suite.decodeResponse(detailResp.Body, &detail)
+	count := 0
+	for _, v := range detail.Data.Versions {
+		if v.VersionNumber == "3.0" {
+			count++
+			assert.Equal(suite.T(), "Third release, revised", v.Details)
+		}
+	}
+	assert.Equal(suite.T(), 1, count)
+}
+
+func (s *ServiceAPIUpdateIntegrationSuite) putVersion(id, number, details string) dto.ServiceDetailResponse {
+	body, _ := json.Marshal(map[string]interface{}{
+		"versions": []map[string]interface{}{
+			{"version_number": number, "Details": details},
+		},
+	})
+	req, err := http.NewRequest("PUT", s.server.URL+"/api/services/"+id, bytes.NewReader(body))
+	s.Require().NoError(err)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	var result dto.ServiceDetailResponse
+	s.decodeResponse(resp.Body, &result)
+	return result
+}
+
 func (s *ServiceAPIUpdateIntegrationSuite) doGet(path string, headers map[string]string) *http.Response {
 	req, err := http.NewRequest("GET", s.server.URL+path, nil)
 	s.Require().NoError(err)
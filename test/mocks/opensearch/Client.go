@@ -3,9 +3,12 @@
 package opensearch
 
 import (
+	opensearch "catalog-service/internal/opensearch"
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Client is an autogenerated mock type for the Client type
@@ -13,6 +16,66 @@ type Client struct {
 	mock.Mock
 }
 
+// BulkIndex provides a mock function with given fields: ctx, indexName, docs, opts
+func (_m *Client) BulkIndex(ctx context.Context, indexName string, docs <-chan opensearch.BulkDocument, opts opensearch.BulkOptions) (*opensearch.BulkResult, error) {
+	ret := _m.Called(ctx, indexName, docs, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkIndex")
+	}
+
+	var r0 *opensearch.BulkResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, <-chan opensearch.BulkDocument, opensearch.BulkOptions) (*opensearch.BulkResult, error)); ok {
+		return rf(ctx, indexName, docs, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, <-chan opensearch.BulkDocument, opensearch.BulkOptions) *opensearch.BulkResult); ok {
+		r0 = rf(ctx, indexName, docs, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*opensearch.BulkResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, <-chan opensearch.BulkDocument, opensearch.BulkOptions) error); ok {
+		r1 = rf(ctx, indexName, docs, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClusterHealth provides a mock function with given fields: ctx
+func (_m *Client) ClusterHealth(ctx context.Context) (*opensearch.ClusterHealth, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClusterHealth")
+	}
+
+	var r0 *opensearch.ClusterHealth
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*opensearch.ClusterHealth, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *opensearch.ClusterHealth); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*opensearch.ClusterHealth)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteDocumentByID provides a mock function with given fields: ctx, indexName, id
 func (_m *Client) DeleteDocumentByID(ctx context.Context, indexName string, id string) error {
 	ret := _m.Called(ctx, indexName, id)
@@ -107,6 +170,24 @@ func (_m *Client) IndexExists(indexName string) (bool, error) {
 	return r0, r1
 }
 
+// Scroll provides a mock function with given fields: ctx, indexName, searchBody, keepAlive, fn
+func (_m *Client) Scroll(ctx context.Context, indexName string, searchBody map[string]interface{}, keepAlive time.Duration, fn opensearch.ScrollFunc) error {
+	ret := _m.Called(ctx, indexName, searchBody, keepAlive, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Scroll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]interface{}, time.Duration, opensearch.ScrollFunc) error); ok {
+		r0 = rf(ctx, indexName, searchBody, keepAlive, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Search provides a mock function with given fields: ctx, indexName, searchBody
 func (_m *Client) Search(ctx context.Context, indexName string, searchBody map[string]interface{}) ([]map[string]interface{}, int, error) {
 	ret := _m.Called(ctx, indexName, searchBody)
@@ -144,6 +225,36 @@ func (_m *Client) Search(ctx context.Context, indexName string, searchBody map[s
 	return r0, r1, r2
 }
 
+// Write provides a mock function with given fields: ctx, ops
+func (_m *Client) Write(ctx context.Context, ops []opensearch.WriteOperation) ([]opensearch.BulkItemResult, error) {
+	ret := _m.Called(ctx, ops)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Write")
+	}
+
+	var r0 []opensearch.BulkItemResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []opensearch.WriteOperation) ([]opensearch.BulkItemResult, error)); ok {
+		return rf(ctx, ops)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []opensearch.WriteOperation) []opensearch.BulkItemResult); ok {
+		r0 = rf(ctx, ops)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]opensearch.BulkItemResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []opensearch.WriteOperation) error); ok {
+		r1 = rf(ctx, ops)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewClient creates a new instance of Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewClient(t interface {
@@ -1,4 +1,4 @@
-// Code generated by mockery v2.52.3. DO NOT EDIT.
+// Code generated by mockery v2.53.7. DO NOT EDIT.
 
 package repository
 
@@ -7,6 +7,8 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	opensearch "catalog-service/internal/opensearch"
 )
 
 // ServiceRepository is an autogenerated mock type for the ServiceRepository type
@@ -14,17 +16,84 @@ type ServiceRepository struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: ctx, service
-func (_m *ServiceRepository) Create(ctx context.Context, service *models.Service) error {
-	ret := _m.Called(ctx, service)
+// BulkCreate provides a mock function with given fields: ctx, services, opts
+func (_m *ServiceRepository) BulkCreate(ctx context.Context, services <-chan *models.Service, opts opensearch.BulkOptions) (*opensearch.BulkResult, error) {
+	ret := _m.Called(ctx, services, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkCreate")
+	}
+
+	var r0 *opensearch.BulkResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, <-chan *models.Service, opensearch.BulkOptions) (*opensearch.BulkResult, error)); ok {
+		return rf(ctx, services, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, <-chan *models.Service, opensearch.BulkOptions) *opensearch.BulkResult); ok {
+		r0 = rf(ctx, services, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*opensearch.BulkResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, <-chan *models.Service, opensearch.BulkOptions) error); ok {
+		r1 = rf(ctx, services, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BulkUpsert provides a mock function with given fields: ctx, services, keyField, opts
+func (_m *ServiceRepository) BulkUpsert(ctx context.Context, services <-chan *models.Service, keyField string, opts opensearch.BulkOptions) (*opensearch.BulkResult, error) {
+	ret := _m.Called(ctx, services, keyField, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkUpsert")
+	}
+
+	var r0 *opensearch.BulkResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, <-chan *models.Service, string, opensearch.BulkOptions) (*opensearch.BulkResult, error)); ok {
+		return rf(ctx, services, keyField, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, <-chan *models.Service, string, opensearch.BulkOptions) *opensearch.BulkResult); ok {
+		r0 = rf(ctx, services, keyField, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*opensearch.BulkResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, <-chan *models.Service, string, opensearch.BulkOptions) error); ok {
+		r1 = rf(ctx, services, keyField, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, service, events
+func (_m *ServiceRepository) Create(ctx context.Context, service *models.Service, events ...*models.ServiceEvent) error {
+	_va := make([]interface{}, len(events))
+	for _i := range events {
+		_va[_i] = events[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, service)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.Service) error); ok {
-		r0 = rf(ctx, service)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Service, ...*models.ServiceEvent) error); ok {
+		r0 = rf(ctx, service, events...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -32,17 +101,42 @@ func (_m *ServiceRepository) Create(ctx context.Context, service *models.Service
 	return r0
 }
 
-// Delete provides a mock function with given fields: ctx, id
-func (_m *ServiceRepository) Delete(ctx context.Context, id string) error {
-	ret := _m.Called(ctx, id)
+// Delete provides a mock function with given fields: ctx, id, events
+func (_m *ServiceRepository) Delete(ctx context.Context, id string, events ...*models.ServiceEvent) error {
+	_va := make([]interface{}, len(events))
+	for _i := range events {
+		_va[_i] = events[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, id)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...*models.ServiceEvent) error); ok {
+		r0 = rf(ctx, id, events...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Export provides a mock function with given fields: ctx, query, filter, fn
+func (_m *ServiceRepository) Export(ctx context.Context, query string, filter models.ServiceFilter, fn func(*models.Service) error) error {
+	ret := _m.Called(ctx, query, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ServiceFilter, func(*models.Service) error) error); ok {
+		r0 = rf(ctx, query, filter, fn)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -80,6 +174,36 @@ func (_m *ServiceRepository) FindByID(ctx context.Context, id string) (*models.S
 	return r0, r1
 }
 
+// FindByName provides a mock function with given fields: ctx, name
+func (_m *ServiceRepository) FindByName(ctx context.Context, name string) (*models.Service, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByName")
+	}
+
+	var r0 *models.Service
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Service, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Service); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Service)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Search provides a mock function with given fields: ctx, query, page, limit
 func (_m *ServiceRepository) Search(ctx context.Context, query string, page int, limit int) ([]*models.Service, int, error) {
 	ret := _m.Called(ctx, query, page, limit)
@@ -117,17 +241,61 @@ func (_m *ServiceRepository) Search(ctx context.Context, query string, page int,
 	return r0, r1, r2
 }
 
-// Update provides a mock function with given fields: ctx, service
-func (_m *ServiceRepository) Update(ctx context.Context, service *models.Service) error {
-	ret := _m.Called(ctx, service)
+// SearchWithFilter provides a mock function with given fields: ctx, query, filter, page, limit
+func (_m *ServiceRepository) SearchWithFilter(ctx context.Context, query string, filter models.ServiceFilter, page int, limit int) ([]*models.Service, int, error) {
+	ret := _m.Called(ctx, query, filter, page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchWithFilter")
+	}
+
+	var r0 []*models.Service
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ServiceFilter, int, int) ([]*models.Service, int, error)); ok {
+		return rf(ctx, query, filter, page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ServiceFilter, int, int) []*models.Service); ok {
+		r0 = rf(ctx, query, filter, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Service)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.ServiceFilter, int, int) int); ok {
+		r1 = rf(ctx, query, filter, page, limit)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.ServiceFilter, int, int) error); ok {
+		r2 = rf(ctx, query, filter, page, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Update provides a mock function with given fields: ctx, service, events
+func (_m *ServiceRepository) Update(ctx context.Context, service *models.Service, events ...*models.ServiceEvent) error {
+	_va := make([]interface{}, len(events))
+	for _i := range events {
+		_va[_i] = events[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, service)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.Service) error); ok {
-		r0 = rf(ctx, service)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Service, ...*models.ServiceEvent) error); ok {
+		r0 = rf(ctx, service, events...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -0,0 +1,107 @@
+// Code generated by mockery v2.52.3. DO NOT EDIT.
+
+package repository
+
+import (
+	models "catalog-service/internal/models"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SpecRepository is an autogenerated mock type for the SpecRepository type
+type SpecRepository struct {
+	mock.Mock
+}
+
+// FindByService provides a mock function with given fields: ctx, serviceID
+func (_m *SpecRepository) FindByService(ctx context.Context, serviceID string) ([]*models.Spec, error) {
+	ret := _m.Called(ctx, serviceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByService")
+	}
+
+	var r0 []*models.Spec
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.Spec, error)); ok {
+		return rf(ctx, serviceID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.Spec); ok {
+		r0 = rf(ctx, serviceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Spec)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, serviceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByVersion provides a mock function with given fields: ctx, serviceID, version
+func (_m *SpecRepository) FindByVersion(ctx context.Context, serviceID string, version string) (*models.Spec, error) {
+	ret := _m.Called(ctx, serviceID, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByVersion")
+	}
+
+	var r0 *models.Spec
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Spec, error)); ok {
+		return rf(ctx, serviceID, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Spec); ok {
+		r0 = rf(ctx, serviceID, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Spec)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, serviceID, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: ctx, spec
+func (_m *SpecRepository) Save(ctx context.Context, spec *models.Spec) error {
+	ret := _m.Called(ctx, spec)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Spec) error); ok {
+		r0 = rf(ctx, spec)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewSpecRepository creates a new instance of SpecRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSpecRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SpecRepository {
+	mock := &SpecRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
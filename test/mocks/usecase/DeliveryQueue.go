@@ -0,0 +1,33 @@
+// Code generated by mockery v2.52.3. DO NOT EDIT.
+
+package usecase
+
+import (
+	models "catalog-service/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DeliveryQueue is an autogenerated mock type for the DeliveryQueue type
+type DeliveryQueue struct {
+	mock.Mock
+}
+
+// Enqueue provides a mock function with given fields: delivery
+func (_m *DeliveryQueue) Enqueue(delivery *models.WebhookDelivery) {
+	_m.Called(delivery)
+}
+
+// NewDeliveryQueue creates a new instance of DeliveryQueue. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDeliveryQueue(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DeliveryQueue {
+	mock := &DeliveryQueue{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -1,4 +1,4 @@
-// Code generated by mockery v2.52.3. DO NOT EDIT.
+// Code generated by mockery v2.53.7. DO NOT EDIT.
 
 package usecase
 
@@ -7,6 +7,8 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	models "catalog-service/internal/models"
 )
 
 // ServiceUsecase is an autogenerated mock type for the ServiceUsecase type
@@ -62,6 +64,24 @@ func (_m *ServiceUsecase) Delete(ctx context.Context, id string) error {
 	return r0
 }
 
+// Export provides a mock function with given fields: ctx, query, filter, fn
+func (_m *ServiceUsecase) Export(ctx context.Context, query string, filter models.ServiceFilter, fn func(*models.Service) error) error {
+	ret := _m.Called(ctx, query, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ServiceFilter, func(*models.Service) error) error); ok {
+		r0 = rf(ctx, query, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FindByID provides a mock function with given fields: ctx, id
 func (_m *ServiceUsecase) FindByID(ctx context.Context, id string) (*dto.ServiceDTO, error) {
 	ret := _m.Called(ctx, id)
@@ -129,6 +149,43 @@ func (_m *ServiceUsecase) Search(ctx context.Context, query string, page int, li
 	return r0, r1, r2
 }
 
+// SearchWithFilter provides a mock function with given fields: ctx, query, filter, page, limit
+func (_m *ServiceUsecase) SearchWithFilter(ctx context.Context, query string, filter models.ServiceFilter, page int, limit int) ([]*dto.ServiceDTO, int, error) {
+	ret := _m.Called(ctx, query, filter, page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchWithFilter")
+	}
+
+	var r0 []*dto.ServiceDTO
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ServiceFilter, int, int) ([]*dto.ServiceDTO, int, error)); ok {
+		return rf(ctx, query, filter, page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ServiceFilter, int, int) []*dto.ServiceDTO); ok {
+		r0 = rf(ctx, query, filter, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dto.ServiceDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.ServiceFilter, int, int) int); ok {
+		r1 = rf(ctx, query, filter, page, limit)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.ServiceFilter, int, int) error); ok {
+		r2 = rf(ctx, query, filter, page, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Update provides a mock function with given fields: ctx, id, req
 func (_m *ServiceUsecase) Update(ctx context.Context, id string, req *dto.ServiceDTO) (*dto.ServiceDTO, error) {
 	ret := _m.Called(ctx, id, req)
@@ -0,0 +1,160 @@
+// Code generated by mockery v2.52.3. DO NOT EDIT.
+
+package usecase
+
+import (
+	dto "catalog-service/internal/dto"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "catalog-service/internal/models"
+
+	spec "catalog-service/internal/spec"
+)
+
+// SpecUsecase is an autogenerated mock type for the SpecUsecase type
+type SpecUsecase struct {
+	mock.Mock
+}
+
+// Compatibility provides a mock function with given fields: ctx, serviceID, version
+func (_m *SpecUsecase) Compatibility(ctx context.Context, serviceID string, version string) (*dto.CompatibilityDTO, error) {
+	ret := _m.Called(ctx, serviceID, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Compatibility")
+	}
+
+	var r0 *dto.CompatibilityDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*dto.CompatibilityDTO, error)); ok {
+		return rf(ctx, serviceID, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *dto.CompatibilityDTO); ok {
+		r0 = rf(ctx, serviceID, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.CompatibilityDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, serviceID, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSpec provides a mock function with given fields: ctx, serviceID, version
+func (_m *SpecUsecase) GetSpec(ctx context.Context, serviceID string, version string) (*models.Spec, error) {
+	ret := _m.Called(ctx, serviceID, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSpec")
+	}
+
+	var r0 *models.Spec
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Spec, error)); ok {
+		return rf(ctx, serviceID, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Spec); ok {
+		r0 = rf(ctx, serviceID, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Spec)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, serviceID, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportOpenAPI provides a mock function with given fields: ctx, content, note
+func (_m *SpecUsecase) ImportOpenAPI(ctx context.Context, content []byte, note string) (*dto.ServiceDTO, bool, error) {
+	ret := _m.Called(ctx, content, note)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportOpenAPI")
+	}
+
+	var r0 *dto.ServiceDTO
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, string) (*dto.ServiceDTO, bool, error)); ok {
+		return rf(ctx, content, note)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, string) *dto.ServiceDTO); ok {
+		r0 = rf(ctx, content, note)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.ServiceDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, string) bool); ok {
+		r1 = rf(ctx, content, note)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []byte, string) error); ok {
+		r2 = rf(ctx, content, note)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// PutSpec provides a mock function with given fields: ctx, serviceID, version, format, content
+func (_m *SpecUsecase) PutSpec(ctx context.Context, serviceID string, version string, format spec.Format, content []byte) (*dto.SpecDTO, error) {
+	ret := _m.Called(ctx, serviceID, version, format, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutSpec")
+	}
+
+	var r0 *dto.SpecDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, spec.Format, []byte) (*dto.SpecDTO, error)); ok {
+		return rf(ctx, serviceID, version, format, content)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, spec.Format, []byte) *dto.SpecDTO); ok {
+		r0 = rf(ctx, serviceID, version, format, content)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.SpecDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, spec.Format, []byte) error); ok {
+		r1 = rf(ctx, serviceID, version, format, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSpecUsecase creates a new instance of SpecUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSpecUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SpecUsecase {
+	mock := &SpecUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
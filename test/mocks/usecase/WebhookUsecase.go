@@ -0,0 +1,229 @@
+// Code generated by mockery v2.52.3. DO NOT EDIT.
+
+package usecase
+
+import (
+	dto "catalog-service/internal/dto"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "catalog-service/internal/models"
+)
+
+// WebhookUsecase is an autogenerated mock type for the WebhookUsecase type
+type WebhookUsecase struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, req
+func (_m *WebhookUsecase) Create(ctx context.Context, req *dto.WebhookDTO) (*dto.WebhookDTO, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *dto.WebhookDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.WebhookDTO) (*dto.WebhookDTO, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.WebhookDTO) *dto.WebhookDTO); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.WebhookDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.WebhookDTO) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *WebhookUsecase) Delete(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Deliveries provides a mock function with given fields: ctx, id
+func (_m *WebhookUsecase) Deliveries(ctx context.Context, id string) ([]*dto.DeliveryDTO, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Deliveries")
+	}
+
+	var r0 []*dto.DeliveryDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*dto.DeliveryDTO, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*dto.DeliveryDTO); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dto.DeliveryDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: ctx, id
+func (_m *WebhookUsecase) FindByID(ctx context.Context, id string) (*dto.WebhookDTO, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *dto.WebhookDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*dto.WebhookDTO, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *dto.WebhookDTO); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.WebhookDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, filter
+func (_m *WebhookUsecase) List(ctx context.Context, filter models.WebhookFilter) ([]*dto.WebhookDTO, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*dto.WebhookDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.WebhookFilter) ([]*dto.WebhookDTO, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.WebhookFilter) []*dto.WebhookDTO); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dto.WebhookDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.WebhookFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Redeliver provides a mock function with given fields: ctx, id, deliveryID
+func (_m *WebhookUsecase) Redeliver(ctx context.Context, id string, deliveryID string) (*dto.DeliveryDTO, error) {
+	ret := _m.Called(ctx, id, deliveryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Redeliver")
+	}
+
+	var r0 *dto.DeliveryDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*dto.DeliveryDTO, error)); ok {
+		return rf(ctx, id, deliveryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *dto.DeliveryDTO); ok {
+		r0 = rf(ctx, id, deliveryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.DeliveryDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, deliveryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, id, req
+func (_m *WebhookUsecase) Update(ctx context.Context, id string, req *dto.WebhookDTO) (*dto.WebhookDTO, error) {
+	ret := _m.Called(ctx, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *dto.WebhookDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *dto.WebhookDTO) (*dto.WebhookDTO, error)); ok {
+		return rf(ctx, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *dto.WebhookDTO) *dto.WebhookDTO); ok {
+		r0 = rf(ctx, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.WebhookDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *dto.WebhookDTO) error); ok {
+		r1 = rf(ctx, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewWebhookUsecase creates a new instance of WebhookUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookUsecase {
+	mock := &WebhookUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}